@@ -0,0 +1,634 @@
+/*
+ * File: src/go/api.ternary.fission.auth.go
+ * Author: bthlops (David StJ)
+ * Date: August 4, 2025
+ * Title: Session Login and Role-Based API Token Authentication for the API Server
+ * Purpose: Provides a pluggable authentication subsystem protecting control-plane endpoints
+ * Reason: The API server previously exposed destructive reactor operations with no access control
+ *
+ * Change Log:
+ * 2025-08-04: Initial auth subsystem - file-backed credential store, server-side sessions,
+ *             scoped API tokens, and middleware wired into the energy field and portal routes
+ * 2025-08-07: Moved the login page markup out of this file and into frontend/templates/
+ *             login.gotemplate.html; serveLogin now renders it via s.frontend.RenderPage
+ * 2025-08-09: Switched serveLogin's render-error log line to the structured logger
+ * 2025-08-09: Added HTTP Basic Auth as a third credential path alongside the session cookie
+ *             and Bearer token (authenticate() now also checks r.BasicAuth() against the
+ *             existing AuthStore), static API keys loaded from Config.AuthAPIKeysFile at
+ *             startup (TokenManager.LoadStatic), and a ternary_fission_auth_failures_total
+ *             counter so operators can alert on brute-force attempts
+ * 2025-08-10: authContext now also reports which credential scheme authenticated the
+ *             request (Method: authMethodSession/authMethodBearer/authMethodBasic), so
+ *             csrfMiddleware can exempt by actual auth method instead of Authorization
+ *             header presence
+ * 2025-08-10: requireRole and requireViewerPage now call s.resolvedAuthContext(r) instead of
+ *             s.auth.authenticate(r) directly, reusing the single authenticate() call
+ *             loggingMiddleware already cached on the request context
+ *             (api.ternary.fission.server.go's authContextCacheKey) instead of repeating a
+ *             Basic-Auth caller's bcrypt comparison a second time
+ *
+ * Carry-over Context:
+ * - We keep the credential store behind an interface so an LDAP-backed implementation can be
+ *   dropped in later without touching the session or token machinery
+ * - We follow the repo convention of manual config parsing (no reflection) and in-memory
+ *   maps guarded by sync.RWMutex, mirroring activeConnections in the main server type
+ * - Static API keys share the same TokenManager/tokens map as runtime-issued ones; a key loaded
+ *   from AuthAPIKeysFile is simply a pre-populated entry a restart will always recreate, and
+ *   can still be revoked at runtime via DELETE /api/v1/tokens/{token} like any other
+ */
+
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"ternary-fission/logging"
+)
+
+// =============================================================================
+// ROLES
+// =============================================================================
+
+// We define the three access tiers used throughout the control API
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// We rank roles so requireRole can test "at least this role"
+var roleRank = map[string]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// We report whether "have" satisfies the minimum required "need" role
+func roleSatisfies(have, need string) bool {
+	return roleRank[have] >= roleRank[need]
+}
+
+// =============================================================================
+// CREDENTIAL STORE
+// =============================================================================
+
+// AuthStore resolves a username/password pair to a role. We keep this as an
+// interface so file-based credentials, LDAP, or another backend can be swapped
+// in without changing session or token handling.
+type AuthStore interface {
+	Authenticate(username, password string) (role string, ok bool)
+}
+
+// FileAuthStore loads "username:bcrypt_hash:role" lines from a flat file, in
+// the same spirit as parseConfigFile's simple key=value parsing.
+type FileAuthStore struct {
+	mu    sync.RWMutex
+	users map[string]fileAuthEntry
+}
+
+type fileAuthEntry struct {
+	passwordHash string
+	role         string
+}
+
+// We load credentials from disk; a missing file yields an empty store rather
+// than an error so a fresh checkout can still boot with auth disabled.
+func NewFileAuthStore(path string) (*FileAuthStore, error) {
+	store := &FileAuthStore{users: make(map[string]fileAuthEntry)}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to open auth users file: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		username := strings.TrimSpace(parts[0])
+		hash := strings.TrimSpace(parts[1])
+		role := strings.TrimSpace(parts[2])
+		if username == "" || hash == "" || roleRank[role] == 0 {
+			continue
+		}
+
+		store.users[username] = fileAuthEntry{passwordHash: hash, role: role}
+	}
+
+	return store, scanner.Err()
+}
+
+// We verify the supplied password against the stored bcrypt hash
+func (f *FileAuthStore) Authenticate(username, password string) (string, bool) {
+	f.mu.RLock()
+	entry, found := f.users[username]
+	f.mu.RUnlock()
+	if !found {
+		return "", false
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(entry.passwordHash), []byte(password)); err != nil {
+		return "", false
+	}
+	return entry.role, true
+}
+
+// =============================================================================
+// SESSIONS
+// =============================================================================
+
+// Session represents a logged-in browser; we hand the client an opaque
+// cookie value and keep the authoritative state server-side.
+type Session struct {
+	ID        string
+	Username  string
+	Role      string
+	ExpiresAt time.Time
+}
+
+// SessionManager tracks active sessions in memory, guarded like the server's
+// existing activeConnections map.
+type SessionManager struct {
+	mu       sync.RWMutex
+	sessions map[string]*Session
+	ttl      time.Duration
+}
+
+func NewSessionManager(ttl time.Duration) *SessionManager {
+	return &SessionManager{
+		sessions: make(map[string]*Session),
+		ttl:      ttl,
+	}
+}
+
+func (m *SessionManager) Create(username, role string) *Session {
+	session := &Session{
+		ID:        generateSecureToken(),
+		Username:  username,
+		Role:      role,
+		ExpiresAt: time.Now().Add(m.ttl),
+	}
+
+	m.mu.Lock()
+	m.sessions[session.ID] = session
+	m.mu.Unlock()
+
+	return session
+}
+
+func (m *SessionManager) Get(id string) (*Session, bool) {
+	m.mu.RLock()
+	session, found := m.sessions[id]
+	m.mu.RUnlock()
+
+	if !found || time.Now().After(session.ExpiresAt) {
+		return nil, false
+	}
+	return session, true
+}
+
+func (m *SessionManager) Delete(id string) {
+	m.mu.Lock()
+	delete(m.sessions, id)
+	m.mu.Unlock()
+}
+
+// We periodically drop expired sessions so long-running servers don't leak memory
+func (m *SessionManager) reap() {
+	now := time.Now()
+	m.mu.Lock()
+	for id, session := range m.sessions {
+		if now.After(session.ExpiresAt) {
+			delete(m.sessions, id)
+		}
+	}
+	m.mu.Unlock()
+}
+
+// =============================================================================
+// API TOKENS
+// =============================================================================
+
+// APIToken is a long-lived, scoped credential for machine clients (scripts,
+// Prometheus federation, CI) that should not need an interactive session.
+type APIToken struct {
+	Token     string    `json:"token"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TokenManager issues and validates API tokens
+type TokenManager struct {
+	mu     sync.RWMutex
+	tokens map[string]*APIToken
+}
+
+func NewTokenManager() *TokenManager {
+	return &TokenManager{tokens: make(map[string]*APIToken)}
+}
+
+func (m *TokenManager) Issue(name, role string) *APIToken {
+	token := &APIToken{
+		Token:     generateSecureToken(),
+		Name:      name,
+		Role:      role,
+		CreatedAt: time.Now(),
+	}
+
+	m.mu.Lock()
+	m.tokens[token.Token] = token
+	m.mu.Unlock()
+
+	return token
+}
+
+func (m *TokenManager) Lookup(token string) (*APIToken, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	t, found := m.tokens[token]
+	return t, found
+}
+
+func (m *TokenManager) List() []*APIToken {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tokens := make([]*APIToken, 0, len(m.tokens))
+	for _, t := range m.tokens {
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+func (m *TokenManager) Revoke(token string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, found := m.tokens[token]; !found {
+		return false
+	}
+	delete(m.tokens, token)
+	return true
+}
+
+// LoadStatic loads "token:name:role" lines from a flat file into the token
+// table, in the same spirit as NewFileAuthStore - a missing file is not an
+// error since a fresh checkout can still boot with only runtime-issued tokens.
+func (m *TokenManager) LoadStatic(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to open auth api keys file: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		token := strings.TrimSpace(parts[0])
+		name := strings.TrimSpace(parts[1])
+		role := strings.TrimSpace(parts[2])
+		if token == "" || name == "" || roleRank[role] == 0 {
+			continue
+		}
+
+		m.mu.Lock()
+		m.tokens[token] = &APIToken{Token: token, Name: name, Role: role, CreatedAt: time.Now()}
+		m.mu.Unlock()
+	}
+
+	return scanner.Err()
+}
+
+// We generate a 256-bit random token hex-encoded for use as a session ID or API token
+func generateSecureToken() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand failing is effectively unrecoverable; we surface a very
+		// unlikely-to-collide fallback rather than panicking the server.
+		return fmt.Sprintf("fallback-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// =============================================================================
+// AUTH MANAGER
+// =============================================================================
+
+// AuthManager wires the credential store, session manager, and token manager
+// together and exposes the HTTP handlers and middleware setupRoutes needs.
+type AuthManager struct {
+	config       *Config
+	store        AuthStore
+	sessions     *SessionManager
+	tokens       *TokenManager
+	authFailures *prometheus.CounterVec
+}
+
+func NewAuthManager(config *Config) *AuthManager {
+	store, err := NewFileAuthStore(config.AuthUsersFile)
+	if err != nil {
+		log.Printf("Warning: failed to load auth users file %s: %v", config.AuthUsersFile, err)
+		store = &FileAuthStore{users: make(map[string]fileAuthEntry)}
+	}
+
+	tokens := NewTokenManager()
+	if err := tokens.LoadStatic(config.AuthAPIKeysFile); err != nil {
+		log.Printf("Warning: failed to load auth api keys file %s: %v", config.AuthAPIKeysFile, err)
+	}
+
+	manager := &AuthManager{
+		config:   config,
+		store:    store,
+		sessions: NewSessionManager(time.Duration(config.SessionTTLMinutes) * time.Minute),
+		tokens:   tokens,
+	}
+
+	// We only register the counter when Prometheus is enabled, matching
+	// initializeRateLimiting, so tests constructing many servers in one
+	// process don't hit duplicate-registration panics
+	if config.PrometheusEnabled {
+		manager.authFailures = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ternary_fission_auth_failures_total",
+				Help: "Total number of authentication attempts that were rejected",
+			},
+			[]string{"reason"},
+		)
+		prometheus.MustRegister(manager.authFailures)
+	}
+
+	manager.startReaper()
+	return manager
+}
+
+// recordFailure increments the auth-failures counter for reason, a no-op when
+// Prometheus is disabled.
+func (a *AuthManager) recordFailure(reason string) {
+	if a.authFailures != nil {
+		a.authFailures.WithLabelValues(reason).Inc()
+	}
+}
+
+func (a *AuthManager) startReaper() {
+	go func() {
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			a.sessions.reap()
+		}
+	}()
+}
+
+// Auth methods authContext.Method reports, so callers that care which credential scheme
+// actually authenticated a request (CSRFGuard.Validate, notably) don't have to re-inspect
+// headers themselves.
+const (
+	authMethodSession = "session"
+	authMethodBearer  = "bearer"
+	authMethodBasic   = "basic"
+)
+
+// authContext carries the resolved identity for a request through to handlers
+type authContext struct {
+	Username string
+	Role     string
+	Method   string
+}
+
+// We resolve the caller's role from a session cookie, a bearer token, or HTTP
+// Basic credentials (checked against the same AuthStore as the login form),
+// returning ok=false when none is present or valid.
+func (a *AuthManager) authenticate(r *http.Request) (*authContext, bool) {
+	if cookie, err := r.Cookie(a.config.SessionCookieName); err == nil {
+		if session, found := a.sessions.Get(cookie.Value); found {
+			return &authContext{Username: session.Username, Role: session.Role, Method: authMethodSession}, true
+		}
+	}
+
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		token := strings.TrimPrefix(header, "Bearer ")
+		if apiToken, found := a.tokens.Lookup(token); found {
+			return &authContext{Username: apiToken.Name, Role: apiToken.Role, Method: authMethodBearer}, true
+		}
+		a.recordFailure("bearer")
+		return nil, false
+	}
+
+	if username, password, ok := r.BasicAuth(); ok {
+		if role, found := a.store.Authenticate(username, password); found {
+			return &authContext{Username: username, Role: role, Method: authMethodBasic}, true
+		}
+		a.recordFailure("basic")
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// requireRole wraps a handler so it only runs once a session or API token
+// satisfying the minimum role has been presented.
+func (s *TernaryFissionAPIServer) requireRole(minRole string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.config.AuthEnabled {
+			next(w, r)
+			return
+		}
+
+		ctx, ok := s.resolvedAuthContext(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Basic realm="ternary-fission", Bearer realm="ternary-fission"`)
+			s.auth.recordFailure("missing")
+			s.writeErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		if !roleSatisfies(ctx.Role, minRole) {
+			s.auth.recordFailure("forbidden")
+			s.writeErrorResponse(w, http.StatusForbidden, "Insufficient role for this operation")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// requireViewerPage protects browser-facing HTML routes (the dashboard). Unlike
+// requireRole it redirects to the login page instead of returning a JSON 401,
+// since the caller here is a browser tab rather than an API client.
+func (s *TernaryFissionAPIServer) requireViewerPage(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.config.AuthEnabled {
+			next(w, r)
+			return
+		}
+
+		ctx, ok := s.resolvedAuthContext(r)
+		if !ok || !roleSatisfies(ctx.Role, RoleViewer) {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// =============================================================================
+// LOGIN / LOGOUT / TOKEN HANDLERS
+// =============================================================================
+
+// loginPageData is the data passed to templates/login.gotemplate.html
+type loginPageData struct {
+	Title     string
+	Error     string
+	CSRFToken string
+	CSRFField string
+}
+
+// We render the login page, optionally showing an error from a failed attempt. The login form
+// is a plain HTML POST (no JavaScript to read the CSRF cookie and set a header), so we embed
+// the token as a hidden field instead; csrfMiddleware's Validate accepts either.
+func (s *TernaryFissionAPIServer) serveLogin(w http.ResponseWriter, r *http.Request) {
+	data := loginPageData{
+		Title:     "Sign In - Ternary Fission Energy Emulation System",
+		CSRFToken: s.csrf.TokenFor(w, r),
+		CSRFField: csrfFormField,
+	}
+	if r.URL.Query().Get("error") == "1" {
+		data.Error = "Invalid username or password"
+	}
+
+	if err := s.frontend.RenderPage(w, "login", data); err != nil {
+		s.logger.Error("Login page render error", logging.Fields{"error": err.Error()})
+		http.Error(w, "Template execution error", http.StatusInternalServerError)
+	}
+}
+
+// We validate submitted credentials and mint a session cookie on success
+func (s *TernaryFissionAPIServer) handleLoginSubmit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Redirect(w, r, "/login?error=1", http.StatusSeeOther)
+		return
+	}
+
+	username := r.FormValue("username")
+	password := r.FormValue("password")
+
+	role, ok := s.auth.store.Authenticate(username, password)
+	if !ok {
+		s.auth.recordFailure("login")
+		http.Redirect(w, r, "/login?error=1", http.StatusSeeOther)
+		return
+	}
+
+	session := s.auth.sessions.Create(username, role)
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.config.SessionCookieName,
+		Value:    session.ID,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  session.ExpiresAt,
+	})
+
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// We clear the session cookie and invalidate the server-side session
+func (s *TernaryFissionAPIServer) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(s.config.SessionCookieName); err == nil {
+		s.auth.sessions.Delete(cookie.Value)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     s.config.SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Expires:  time.Unix(0, 0),
+	})
+
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// We list currently issued API tokens (token values included - this surface
+// must only ever be reachable by admins)
+func (s *TernaryFissionAPIServer) listAPITokens(w http.ResponseWriter, r *http.Request) {
+	s.writeJSONResponse(w, http.StatusOK, s.auth.tokens.List())
+}
+
+// We mint a new scoped API token for machine clients
+func (s *TernaryFissionAPIServer) createAPIToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+		Role string `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" {
+		s.writeErrorResponse(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	if roleRank[req.Role] == 0 {
+		s.writeErrorResponse(w, http.StatusBadRequest, "role must be viewer, operator, or admin")
+		return
+	}
+
+	token := s.auth.tokens.Issue(req.Name, req.Role)
+	s.writeJSONResponse(w, http.StatusCreated, token)
+}
+
+// We revoke a previously issued API token
+func (s *TernaryFissionAPIServer) deleteAPIToken(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 4 {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid token")
+		return
+	}
+	token := parts[len(parts)-1]
+
+	if !s.auth.tokens.Revoke(token) {
+		s.writeErrorResponse(w, http.StatusNotFound, "Token not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,317 @@
+/*
+ * File: src/go/api.ternary.fission.csrf.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: CSRF Protection for Session-Cookie Callers
+ * Purpose: Issues and validates a per-session CSRF token (syncthing's cookie/header scheme) so
+ *          a browser tab with an authenticated session cookie can't be driven by a cross-site
+ *          form or fetch into mutating energy-field/portal state
+ * Reason: triggerPortalSimulation and dissipateEnergyField accepted POST/PUT from any Origin
+ *         with CORS wide open and nothing but the session cookie tying the request to a user -
+ *         exactly what CSRF tokens exist to close
+ *
+ * Change Log:
+ * 2025-08-09: Initial CSRFGuard (instance-ID-suffixed cookie/header, bounded LRU of per-session
+ *             tokens), csrfMiddleware, and the csrf_rejected_total counter
+ * 2025-08-10: Validate exempted any request carrying a non-empty Authorization header,
+ *             regardless of whether that header was what actually authenticated it - since
+ *             authenticate() checks the session cookie first and returns on a match without
+ *             inspecting the header, a cookie-authenticated request with a garbage
+ *             Authorization value was both CSRF-exempt and cookie-authorized. Validate now
+ *             takes the auth method AuthManager.authenticate actually resolved
+ *             (csrfMiddleware looks it up) and only exempts authMethodBearer/authMethodBasic
+ * 2025-08-10: Review caught that csrfMiddleware's own s.auth.authenticate(r) call duplicated
+ *             the identical call loggingMiddleware/requireRole/requireViewerPage/
+ *             rateLimitMiddleware each already make. csrfMiddleware now reads
+ *             s.resolvedAuthContext(r), which reuses loggingMiddleware's single cached call
+ *             instead of re-running a Basic-Auth caller's bcrypt comparison
+ *
+ * Carry-over Context:
+ * - Modeled on syncthing's CSRF scheme: the cookie and the header it must be echoed back as are
+ *   both named with a short per-process instance ID suffix (CSRF-Token-<id> /
+ *   X-CSRF-Token-<id>), so a stale token cached by the browser from a previous process restart
+ *   is rejected outright rather than silently treated as valid
+ * - Enforcement only applies to non-safe methods (POST/PUT/DELETE/PATCH) and is skipped
+ *   entirely for callers who actually authenticated via bearer/API-key or Basic (those schemes
+ *   are simply not exposed to the browser-driven CSRF threat - a session cookie is) and for
+ *   any Origin in Config.CSRFTrustedOrigins
+ * - Tokens are keyed by the session cookie when present, otherwise by a dedicated anonymous
+ *   marker cookie minted on first GET, so the dashboard's pre-login pages (login form itself)
+ *   are still protected
+ * - frontend/static/js/csrf.js patches window.fetch to read the cookie and attach the header,
+ *   so none of dashboard.js's existing fetch() call sites needed to change
+ */
+
+package main
+
+import (
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// csrfLRUCapacity bounds the number of distinct session/anonymous keys CSRFGuard remembers a
+// token for; eviction is least-recently-used, matching the kind of cap a single-process
+// server's in-memory session store never needed before (sessions already reap on TTL, but a
+// flood of anonymous pre-login visitors has no TTL of its own).
+const csrfLRUCapacity = 8192
+
+// csrfAnonCookieName marks a not-yet-authenticated browser so its CSRF token survives across
+// requests even before a session cookie exists (e.g. on the login page itself).
+const csrfAnonCookieName = "tfs_csrf_anon"
+
+// csrfFormField is the hidden input name the login page submits its token under, for the one
+// request in this app (POST /login) that is a plain HTML form post rather than a fetch() call
+// csrf.js can attach a header to.
+const csrfFormField = "csrf_token"
+
+// csrfLRU is a small fixed-capacity least-recently-used string->string cache, used instead of
+// an unbounded map so a long-running server can't be grown without limit by a flood of unique
+// anonymous visitors.
+type csrfLRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type csrfLRUEntry struct {
+	key   string
+	token string
+}
+
+func newCSRFLRU(capacity int) *csrfLRU {
+	return &csrfLRU{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *csrfLRU) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, found := c.entries[key]
+	if !found {
+		return "", false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*csrfLRUEntry).token, true
+}
+
+func (c *csrfLRU) Put(key, token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, found := c.entries[key]; found {
+		elem.Value.(*csrfLRUEntry).token = token
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&csrfLRUEntry{key: key, token: token})
+	c.entries[key] = elem
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*csrfLRUEntry).key)
+		}
+	}
+}
+
+// CSRFGuard mints and validates the per-session CSRF token browser callers must echo back as a
+// header on every non-safe request.
+type CSRFGuard struct {
+	config     *Config
+	instanceID string
+	trusted    map[string]bool
+	tokens     *csrfLRU
+
+	csrfRejected *prometheus.CounterVec
+}
+
+// NewCSRFGuard builds a guard with a fresh per-process instance ID, so tokens minted by a
+// previous run of the server are never mistaken for valid after a restart.
+func NewCSRFGuard(config *Config) *CSRFGuard {
+	guard := &CSRFGuard{
+		config:     config,
+		instanceID: generateSecureToken()[:5],
+		trusted:    parseCSRFTrustedOrigins(config.CSRFTrustedOrigins),
+		tokens:     newCSRFLRU(csrfLRUCapacity),
+	}
+
+	// We only register the counter when Prometheus is enabled, matching every other
+	// collector in this server, so tests constructing many servers in one process don't
+	// hit duplicate-registration panics
+	if config.PrometheusEnabled {
+		guard.csrfRejected = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ternary_fission_csrf_rejected_total",
+				Help: "Total number of requests rejected for a missing or mismatched CSRF token",
+			},
+			[]string{"reason"},
+		)
+		prometheus.MustRegister(guard.csrfRejected)
+	}
+
+	return guard
+}
+
+// parseCSRFTrustedOrigins splits a comma-separated origin list, mirroring
+// parseReactorInterceptorNames's comma-split-and-trim style.
+func parseCSRFTrustedOrigins(value string) map[string]bool {
+	trusted := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		origin := strings.TrimSpace(part)
+		if origin != "" {
+			trusted[origin] = true
+		}
+	}
+	return trusted
+}
+
+// cookieName and headerName both carry the instance ID suffix, so a token cookie left over
+// from a previous process restart names a cookie/header pair this process never set.
+func (g *CSRFGuard) cookieName() string { return "CSRF-Token-" + g.instanceID }
+func (g *CSRFGuard) headerName() string { return "X-CSRF-Token-" + g.instanceID }
+
+// sessionKey identifies the caller for token-storage purposes: the session cookie when
+// present, otherwise a dedicated anonymous marker cookie minted (and set on w) if missing.
+func (g *CSRFGuard) sessionKey(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(g.config.SessionCookieName); err == nil && cookie.Value != "" {
+		return "session:" + cookie.Value
+	}
+
+	if cookie, err := r.Cookie(csrfAnonCookieName); err == nil && cookie.Value != "" {
+		return "anon:" + cookie.Value
+	}
+
+	anonID := generateSecureToken()
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfAnonCookieName,
+		Value:    anonID,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return "anon:" + anonID
+}
+
+// EnsureToken mints (or reuses) the CSRF token for r's caller and sets it as a
+// JavaScript-readable cookie, so csrf.js can read it back and echo it on later requests. It is
+// called on every safe-method request, matching syncthing's token-on-every-GET behavior.
+func (g *CSRFGuard) EnsureToken(w http.ResponseWriter, r *http.Request) {
+	g.TokenFor(w, r)
+}
+
+// TokenFor mints (or reuses) and returns the caller's current token, for the login page to
+// embed as a hidden field since it can't rely on csrf.js reading the cookie back.
+func (g *CSRFGuard) TokenFor(w http.ResponseWriter, r *http.Request) string {
+	key := g.sessionKey(w, r)
+
+	token, found := g.tokens.Get(key)
+	if !found {
+		token = generateSecureToken()
+		g.tokens.Put(key, token)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     g.cookieName(),
+		Value:    token,
+		Path:     "/",
+		SameSite: http.SameSiteLaxMode,
+	})
+	return token
+}
+
+// recordRejection increments csrfRejected for reason, a no-op when Prometheus is disabled.
+func (g *CSRFGuard) recordRejection(reason string) {
+	if g.csrfRejected != nil {
+		g.csrfRejected.WithLabelValues(reason).Inc()
+	}
+}
+
+// Validate reports whether a non-safe-method request may proceed: callers who actually
+// authenticated via bearer token or HTTP Basic (authMethod, resolved by csrfMiddleware via
+// AuthManager.authenticate) and requests from a trusted Origin are exempt (not the
+// cross-site threat CSRF tokens defend against); everyone else, including a session-cookie
+// caller, must echo back the token minted for their session key as the X-CSRF-Token-<id>
+// header. We key the exemption on the auth method that actually succeeded rather than mere
+// Authorization-header presence: authenticate() checks the session cookie first and returns
+// on a match without inspecting the header, so a request carrying both a valid session
+// cookie and an arbitrary Authorization value would otherwise be both cookie-authorized and
+// CSRF-exempt - exactly what CSRF protection exists to prevent.
+func (g *CSRFGuard) Validate(r *http.Request, authMethod string) (ok bool, reason string) {
+	if authMethod == authMethodBearer || authMethod == authMethodBasic {
+		return true, ""
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && g.trusted[origin] {
+		return true, ""
+	}
+
+	var key string
+	if cookie, err := r.Cookie(g.config.SessionCookieName); err == nil && cookie.Value != "" {
+		key = "session:" + cookie.Value
+	} else if cookie, err := r.Cookie(csrfAnonCookieName); err == nil && cookie.Value != "" {
+		key = "anon:" + cookie.Value
+	} else {
+		return false, "missing_session"
+	}
+
+	expected, found := g.tokens.Get(key)
+	if !found {
+		return false, "missing_token"
+	}
+
+	// The header covers every fetch()-driven request (patched in by csrf.js); the form field
+	// covers the one plain HTML form post in this app, POST /login. r.FormValue only reads
+	// the body when Content-Type is application/x-www-form-urlencoded, so this never
+	// consumes the body of a JSON API request.
+	presented := r.Header.Get(g.headerName())
+	if presented == "" {
+		presented = r.FormValue(csrfFormField)
+	}
+	if presented == "" || presented != expected {
+		return false, "mismatch"
+	}
+	return true, ""
+}
+
+// csrfSafeMethods are exempt from token validation; EnsureToken still runs for them so a
+// token is ready by the time the browser issues its first mutating request.
+var csrfSafeMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+}
+
+// csrfMiddleware mints a token on every safe-method request and requires it back as a header
+// on every other one, short-circuiting with a structured 403 on a missing or mismatched token.
+func (s *TernaryFissionAPIServer) csrfMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if csrfSafeMethods[r.Method] {
+			s.csrf.EnsureToken(w, r)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var authMethod string
+		if authCtx, ok := s.resolvedAuthContext(r); ok {
+			authMethod = authCtx.Method
+		}
+
+		if ok, reason := s.csrf.Validate(r, authMethod); !ok {
+			s.csrf.recordRejection(reason)
+			s.writeErrorResponse(w, http.StatusForbidden, "Missing or invalid CSRF token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
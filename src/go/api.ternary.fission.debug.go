@@ -0,0 +1,176 @@
+/*
+ * File: src/go/api.ternary.fission.debug.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Runtime pprof and Diagnostic Support Bundle Endpoints
+ * Purpose: Mounts net/http/pprof handlers, a zipped support bundle (config, reactor status,
+ *          recent logs, goroutine dump, metrics scrape), and an arbitrary reactor-introspection
+ *          proxy under /api/v1/debug/, for operators diagnosing a live deployment
+ * Reason: Production incidents on this service previously required a redeploy with debug
+ *         logging turned up just to see a goroutine dump or a coherent support bundle; this
+ *         mirrors the diagnostic-endpoint pattern used by Teleport and syncthing's GUI
+ *
+ * Change Log:
+ * 2025-08-09: Initial pprof handlers, support-bundle zip, and reactor-passthrough proxy,
+ *             gated behind Config.DebugEnabled (itself AND-ed with main's -allow-profiling
+ *             flag) and requireRole(RoleAdmin, ...)
+ *
+ * Carry-over Context:
+ * - All /api/v1/debug/* routes are admin-only; this is diagnostic surface that can leak
+ *   memory contents (heap profile) or internal topology (reactor-passthrough), not something
+ *   a viewer/operator token should ever reach
+ * - redactedConfig() is a narrow, explicit allowlist of the handful of Config fields that
+ *   hold secrets (ReactorAuthToken, PushVAPIDPrivateKey) rather than a generic reflection-based
+ *   redactor, matching parseConfigFile's own manual-switch style over the Config struct
+ * - The recent-log lines come from logging.Logger's ring buffer (logging/ring_sink.go), added
+ *   alongside this request since no existing sink exposed its history back to the process
+ */
+
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	httppprof "net/http/pprof"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	"ternary-fission/logging"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// handleDebugRoutes dispatches the /api/v1/debug/* paths already stripped of their /api/v1
+// prefix by handleAPIRoutes. It is only reachable when Config.DebugEnabled is true and the
+// caller holds the admin role (enforced by the requireRole wrapper at the call site).
+func (s *TernaryFissionAPIServer) handleDebugRoutes(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/v1/debug")
+
+	switch {
+	case path == "/pprof/heap":
+		httppprof.Handler("heap").ServeHTTP(w, r)
+	case path == "/pprof/goroutine":
+		httppprof.Handler("goroutine").ServeHTTP(w, r)
+	case path == "/pprof/profile":
+		httppprof.Profile(w, r)
+	case path == "/pprof/trace":
+		httppprof.Trace(w, r)
+	case path == "/support-bundle":
+		s.handleSupportBundle(w, r)
+	case strings.HasPrefix(path, "/reactor-passthrough/"):
+		s.handleReactorPassthrough(w, r, strings.TrimPrefix(path, "/reactor-passthrough/"))
+	default:
+		s.writeErrorResponse(w, http.StatusNotFound, "Unknown debug endpoint")
+	}
+}
+
+// redactedConfig returns a copy of s.config with the fields that hold credentials blanked
+// out, safe to serialize into a support bundle that may be attached to a ticket.
+func (s *TernaryFissionAPIServer) redactedConfig() Config {
+	redacted := *s.config
+	redacted.ReactorAuthToken = redactSecret(redacted.ReactorAuthToken)
+	redacted.PushVAPIDPrivateKey = redactSecret(redacted.PushVAPIDPrivateKey)
+	return redacted
+}
+
+func redactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "[REDACTED]"
+}
+
+// handleSupportBundle streams a zip containing the redacted config, a fresh reactor status
+// snapshot, the last N log lines, a goroutine dump, and a Prometheus metrics scrape - enough
+// for an operator to attach one file to an incident without shelling into the host.
+func (s *TernaryFissionAPIServer) handleSupportBundle(w http.ResponseWriter, r *http.Request) {
+	logLines := 200
+	if raw := r.URL.Query().Get("log_lines"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n > 0 {
+			logLines = n
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=support-bundle-%d.zip", time.Now().Unix()))
+
+	zw := zip.NewWriter(w)
+	defer zw.Close()
+
+	s.writeBundleJSON(zw, "config.json", s.redactedConfig())
+
+	status, _, err := s.fetchSystemStatus()
+	if err != nil {
+		s.writeBundleText(zw, "status.txt", fmt.Sprintf("failed to fetch reactor status: %v", err))
+	} else {
+		s.writeBundleJSON(zw, "status.json", status)
+	}
+
+	s.writeBundleText(zw, "logs.txt", strings.Join(s.logger.RecentLines(logLines), "\n"))
+
+	if gw, err := zw.Create("goroutines.txt"); err == nil {
+		_ = pprof.Lookup("goroutine").WriteTo(gw, 2)
+	}
+
+	if mw, err := zw.Create("metrics.txt"); err == nil {
+		rec := httptest.NewRecorder()
+		promhttp.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		_, _ = io.Copy(mw, rec.Body)
+	}
+}
+
+func (s *TernaryFissionAPIServer) writeBundleJSON(zw *zip.Writer, name string, v interface{}) {
+	f, err := zw.Create(name)
+	if err != nil {
+		return
+	}
+	encoded, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return
+	}
+	_, _ = f.Write(encoded)
+}
+
+func (s *TernaryFissionAPIServer) writeBundleText(zw *zip.Writer, name, content string) {
+	f, err := zw.Create(name)
+	if err != nil {
+		return
+	}
+	_, _ = f.Write([]byte(content))
+}
+
+// handleReactorPassthrough forwards an arbitrary GET under the reactor's own API root,
+// for introspection endpoints this server has no dedicated handler for.
+func (s *TernaryFissionAPIServer) handleReactorPassthrough(w http.ResponseWriter, r *http.Request, reactorPath string) {
+	if r.Method != http.MethodGet {
+		s.writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed - use GET")
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, fmt.Sprintf("%s/%s", s.config.ReactorBaseURL, reactorPath), nil)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to build reactor request")
+		return
+	}
+
+	resp, err := s.reactorClient.Do(req)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to contact reactor")
+		return
+	}
+	defer resp.Body.Close()
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+
+	s.logger.Debug("Reactor passthrough request served", logging.Fields{"path": reactorPath, "status": resp.StatusCode})
+}
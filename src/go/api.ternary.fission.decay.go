@@ -0,0 +1,457 @@
+/*
+ * File: src/go/api.ternary.fission.decay.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Radioactive Decay-Chain Subsystem for Fission Products
+ * Purpose: Tracks fission fragments through their beta/gamma decay chains down to a stable
+ *          nuclide, contributing "decay heat" back into the energy accounting
+ * Reason: The dashboard advertised waste management / decay heat with no backing code - the
+ *         fragments computeFragmentBurst synthesizes never decayed
+ *
+ * Change Log:
+ * 2025-08-09: Initial DecayChainTracker: exponential decay-time sampling (lambda = ln2/half
+ *             life), cumulative-branching-ratio daughter selection, a decay heat ring
+ *             buffer, and the /api/v1/decay-chains{,/{nuclide}} handlers. Nuclide half-lives
+ *             and branching ratios come from an injectable DecayDataSource so the backing
+ *             table can be swapped (JEFF-3.3 vs ENDF/B-VIII.0) without touching the tracker
+ * 2025-08-09: IngestStatus now takes the parent nucleus's Z alongside its mass, threaded
+ *             through from the nucdata-backed parentNucleusZA instead of the fixed U-235 ratio
+ *
+ * Carry-over Context:
+ * - We hook ingestion into the same updateReactorMetrics/getSystemStatus call sites that
+ *   already feed the TSDB store (api.ternary.fission.tsdb.go), so this is additive rather
+ *   than a second polling path; the tracker keeps its own previous-events/previous-energy
+ *   baseline so it doesn't matter which of those two call sites observes a given tick first
+ * - Both bundled data sources (newJEFF33DecayTable/newENDFB8DecayTable) currently read from
+ *   the same small hand-entered nuclide table, since neither evaluated library's files ship
+ *   in this repo; swapping to the real ones means pointing nuclideTable.entries at rows
+ *   parsed from the actual JEFF-3.3 / ENDF/B-VIII.0 decay sublibraries
+ * - Fragment (Z, mass number) from computeFragmentBurst rarely lands exactly on a table
+ *   entry, so lookup picks the nearest nuclide by a Z-weighted distance, matching the
+ *   approximate-by-design spirit of the fragment synthesis itself
+ */
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// NUCLIDE DATA
+// =============================================================================
+
+// decayMode is one branch a nuclide can decay through.
+type decayMode struct {
+	Type           string  // "beta_minus", "gamma" (isomeric transition), or "alpha"
+	BranchingRatio float64 // fraction of decays following this branch, branches for a nuclide sum to ~1
+	DaughterZ      int
+	DaughterA      int
+	QValueMeV      float64 // energy released per decay along this branch
+}
+
+// nuclideData is one row of a decay table: a nuclide's identity, half-life, and
+// its decay branches. HalfLifeSeconds <= 0 means the nuclide is treated as stable.
+type nuclideData struct {
+	Symbol          string
+	Z               int
+	A               int
+	HalfLifeSeconds float64
+	Modes           []decayMode
+}
+
+func (n nuclideData) stable() bool {
+	return n.HalfLifeSeconds <= 0 || len(n.Modes) == 0
+}
+
+// DecayDataSource looks up a nuclide's half-life and decay branches by (Z, A). It is
+// the tracker's only dependency on a specific evaluated data library, so a different
+// library can be swapped in without changing DecayChainTracker.
+type DecayDataSource interface {
+	Lookup(z, a int) (nuclideData, bool)
+}
+
+// nuclideTable is a DecayDataSource backed by an in-memory slice, matched by nearest
+// (Z, A) rather than exact lookup since synthesized fragments rarely land exactly on
+// a tabulated nuclide.
+type nuclideTable struct {
+	entries []nuclideData
+}
+
+func (t *nuclideTable) Lookup(z, a int) (nuclideData, bool) {
+	if len(t.entries) == 0 {
+		return nuclideData{}, false
+	}
+
+	best := t.entries[0]
+	bestDistance := nuclideDistance(best, z, a)
+	for _, entry := range t.entries[1:] {
+		if d := nuclideDistance(entry, z, a); d < bestDistance {
+			best, bestDistance = entry, d
+		}
+	}
+	return best, true
+}
+
+// nuclideDistance weights Z more heavily than A: two nuclides with the same mass
+// number but different charge are chemically and decay-wise unrelated, while a few
+// mass numbers off the same element is a reasonable approximation.
+func nuclideDistance(n nuclideData, z, a int) int {
+	dz := n.Z - z
+	da := n.A - a
+	return dz*dz*4 + da*da
+}
+
+// fissionProductNuclides is a small, hand-entered slice of real fission-product decay
+// chains relevant to U-235 ternary fission: the Kr-92 mass chain, the I-135/Xe-135
+// "iodine pit" chain, the Ba-141 mass chain, the classic Cs-137/Ba-137m branching
+// example, and stable He-4 for the alpha. Half-lives are approximate published values.
+var fissionProductNuclides = []nuclideData{
+	{Symbol: "He-4", Z: 2, A: 4, HalfLifeSeconds: 0},
+
+	{Symbol: "Kr-92", Z: 36, A: 92, HalfLifeSeconds: 1.84,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 37, DaughterA: 92, QValueMeV: 6.0}}},
+	{Symbol: "Rb-92", Z: 37, A: 92, HalfLifeSeconds: 4.49,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 38, DaughterA: 92, QValueMeV: 8.1}}},
+	{Symbol: "Sr-92", Z: 38, A: 92, HalfLifeSeconds: 9576,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 39, DaughterA: 92, QValueMeV: 1.7}}},
+	{Symbol: "Y-92", Z: 39, A: 92, HalfLifeSeconds: 12744,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 40, DaughterA: 92, QValueMeV: 3.6}}},
+	{Symbol: "Zr-92", Z: 40, A: 92, HalfLifeSeconds: 0},
+
+	{Symbol: "I-135", Z: 53, A: 135, HalfLifeSeconds: 23652,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 54, DaughterA: 135, QValueMeV: 1.15}}},
+	{Symbol: "Xe-135", Z: 54, A: 135, HalfLifeSeconds: 32904,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 55, DaughterA: 135, QValueMeV: 1.16}}},
+	{Symbol: "Cs-135", Z: 55, A: 135, HalfLifeSeconds: 0},
+
+	{Symbol: "Ba-141", Z: 56, A: 141, HalfLifeSeconds: 1096.2,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 57, DaughterA: 141, QValueMeV: 3.2}}},
+	{Symbol: "La-141", Z: 57, A: 141, HalfLifeSeconds: 14112,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 58, DaughterA: 141, QValueMeV: 2.5}}},
+	{Symbol: "Ce-141", Z: 58, A: 141, HalfLifeSeconds: 2808086,
+		Modes: []decayMode{{Type: "beta_minus", BranchingRatio: 1.0, DaughterZ: 59, DaughterA: 141, QValueMeV: 0.58}}},
+	{Symbol: "Pr-141", Z: 59, A: 141, HalfLifeSeconds: 0},
+
+	{Symbol: "Cs-137", Z: 55, A: 137, HalfLifeSeconds: 952422192,
+		Modes: []decayMode{
+			{Type: "beta_minus", BranchingRatio: 0.946, DaughterZ: 56, DaughterA: 137, QValueMeV: 0.512},
+			{Type: "beta_minus", BranchingRatio: 0.054, DaughterZ: 56, DaughterA: 137, QValueMeV: 1.176},
+		}},
+	{Symbol: "Ba-137m", Z: 56, A: 137, HalfLifeSeconds: 153.12,
+		Modes: []decayMode{{Type: "gamma", BranchingRatio: 1.0, DaughterZ: 56, DaughterA: 137, QValueMeV: 0.662}}},
+	{Symbol: "Ba-137", Z: 56, A: 137, HalfLifeSeconds: 0},
+}
+
+// newJEFF33DecayTable builds the decay data source selected by DecayChainDataSource
+// "jeff-3.3" (the default).
+func newJEFF33DecayTable() DecayDataSource {
+	return &nuclideTable{entries: fissionProductNuclides}
+}
+
+// newENDFB8DecayTable builds the decay data source selected by DecayChainDataSource
+// "endfb-8.0".
+func newENDFB8DecayTable() DecayDataSource {
+	return &nuclideTable{entries: fissionProductNuclides}
+}
+
+// selectDecayDataSource resolves Config.DecayChainDataSource to a DecayDataSource,
+// defaulting to JEFF-3.3 for unrecognized or empty values.
+func selectDecayDataSource(config *Config) DecayDataSource {
+	switch config.DecayChainDataSource {
+	case "endfb-8.0":
+		return newENDFB8DecayTable()
+	default:
+		return newJEFF33DecayTable()
+	}
+}
+
+// =============================================================================
+// DECAY CHAIN TRACKER
+// =============================================================================
+
+// pendingDecay is one nuclide instance queued to decay at a future time.
+type pendingDecay struct {
+	nuclide     nuclideData
+	scheduledAt time.Time
+}
+
+// decayChainRecord accumulates what a single nuclide has done across the run:
+// how many instances have been produced (by fragment synthesis or as a daughter),
+// how many have since decayed, and how much decay heat it has contributed.
+type decayChainRecord struct {
+	Nuclide            nuclideData
+	ProducedCount      uint64
+	DecayedCount       uint64
+	HeatContributedMeV float64
+}
+
+// DecayChainTracker queues synthesized fission fragments as nuclides, samples their
+// decay times exponentially (lambda = ln2/half-life), and walks each through its decay
+// chain - selecting a daughter by cumulative branching ratio - until it reaches a
+// stable nuclide. Each decay's energy release is recorded as a decay heat sample.
+type DecayChainTracker struct {
+	mu      sync.Mutex
+	source  DecayDataSource
+	rand    *rand.Rand
+	pending []pendingDecay
+	records map[string]*decayChainRecord
+	heat    *ringBuffer
+
+	heatWindow time.Duration
+
+	haveBaseline   bool
+	previousEvents uint64
+	previousEnergy float64
+}
+
+// NewDecayChainTracker builds a tracker backed by source, with a decay-heat sampling
+// window of Config.DecayHeatWindowSeconds (defaulting to 60s for zero/negative values).
+func NewDecayChainTracker(config *Config, source DecayDataSource) *DecayChainTracker {
+	windowSeconds := orDefault(config.DecayHeatWindowSeconds, 60)
+
+	return &DecayChainTracker{
+		source:     source,
+		rand:       rand.New(rand.NewSource(2)),
+		records:    make(map[string]*decayChainRecord),
+		heat:       newRingBuffer(10000),
+		heatWindow: time.Duration(windowSeconds) * time.Second,
+	}
+}
+
+// IngestStatus observes the reactor's latest event/energy totals, enqueues decay
+// nuclides for any fission events that occurred since the last observation, and
+// processes any previously queued decays that have come due. It is safe to call from
+// multiple call sites (the poll ticker and on-demand status requests) since it keeps
+// its own baseline internally, matching how recordTSDBSample is fed.
+func (t *DecayChainTracker) IngestStatus(status SystemStatusResponse, parentMassAMU, parentZ float64, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.haveBaseline {
+		deltaEvents := status.TotalFissionEvents - t.previousEvents
+		deltaEnergy := status.TotalEnergySimulated - t.previousEnergy
+		if deltaEvents > 0 && deltaEnergy > 0 {
+			burst := computeFragmentBurst(status.TotalFissionEvents, deltaEnergy/float64(deltaEvents), parentMassAMU, parentZ)
+			t.enqueueFragmentsLocked(burst.Fragments, now)
+		}
+	}
+	t.previousEvents = status.TotalFissionEvents
+	t.previousEnergy = status.TotalEnergySimulated
+	t.haveBaseline = true
+
+	t.processDueLocked(now)
+}
+
+// enqueueFragmentsLocked looks up each fragment's nearest nuclide and, if it is not
+// already stable, schedules its first decay. Callers must hold t.mu.
+func (t *DecayChainTracker) enqueueFragmentsLocked(fragments []fragment, now time.Time) {
+	for _, f := range fragments {
+		nuclide, ok := t.source.Lookup(f.Z, int(math.Round(f.MassNumber)))
+		if !ok {
+			continue
+		}
+		t.recordLocked(nuclide).ProducedCount++
+		if !nuclide.stable() {
+			t.scheduleLocked(nuclide, now)
+		}
+	}
+}
+
+// scheduleLocked draws an exponentially distributed decay time for nuclide
+// (lambda = ln2/half-life) and queues it. Callers must hold t.mu.
+func (t *DecayChainTracker) scheduleLocked(nuclide nuclideData, now time.Time) {
+	lambda := math.Ln2 / nuclide.HalfLifeSeconds
+	waitSeconds := t.rand.ExpFloat64() / lambda
+	t.pending = append(t.pending, pendingDecay{nuclide: nuclide, scheduledAt: now.Add(time.Duration(waitSeconds * float64(time.Second)))})
+}
+
+// processDueLocked walks every pending decay whose scheduled time has arrived,
+// records its heat contribution, picks a daughter by cumulative branching ratio, and
+// re-queues the daughter if it is itself unstable. Callers must hold t.mu.
+func (t *DecayChainTracker) processDueLocked(now time.Time) {
+	remaining := t.pending[:0]
+	for _, p := range t.pending {
+		if now.Before(p.scheduledAt) {
+			remaining = append(remaining, p)
+			continue
+		}
+
+		mode, ok := pickBranch(t.rand, p.nuclide.Modes)
+		record := t.recordLocked(p.nuclide)
+		record.DecayedCount++
+		record.HeatContributedMeV += mode.QValueMeV
+		t.heat.add(tsPoint{Timestamp: p.scheduledAt, Value: mode.QValueMeV})
+
+		if !ok {
+			continue
+		}
+
+		daughter, found := t.source.Lookup(mode.DaughterZ, mode.DaughterA)
+		if !found {
+			continue
+		}
+		t.recordLocked(daughter).ProducedCount++
+		if !daughter.stable() {
+			t.scheduleLocked(daughter, p.scheduledAt)
+		}
+	}
+	t.pending = remaining
+}
+
+// pickBranch samples one of modes by cumulative branching ratio. It returns false if
+// modes is empty (a stable nuclide reached the end of its chain).
+func pickBranch(r *rand.Rand, modes []decayMode) (decayMode, bool) {
+	if len(modes) == 0 {
+		return decayMode{}, false
+	}
+
+	roll := r.Float64()
+	cumulative := 0.0
+	for _, mode := range modes {
+		cumulative += mode.BranchingRatio
+		if roll <= cumulative {
+			return mode, true
+		}
+	}
+	return modes[len(modes)-1], true
+}
+
+// recordLocked returns the accumulator for nuclide, creating it on first use.
+// Callers must hold t.mu.
+func (t *DecayChainTracker) recordLocked(nuclide nuclideData) *decayChainRecord {
+	record, ok := t.records[nuclide.Symbol]
+	if !ok {
+		record = &decayChainRecord{Nuclide: nuclide}
+		t.records[nuclide.Symbol] = record
+	}
+	return record
+}
+
+// HeatRateMeVPerSecond averages decay heat samples from the last heatWindow into a
+// MeV/s rate, for the dashboard's "Decay Heat" metric.
+func (t *DecayChainTracker) HeatRateMeVPerSecond(now time.Time) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	samples := t.heat.since(now.Add(-t.heatWindow))
+	if len(samples) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, sample := range samples {
+		total += sample.Value
+	}
+	return total / t.heatWindow.Seconds()
+}
+
+// decayChainSummary is one nuclide's entry in the GET /api/v1/decay-chains listing.
+type decayChainSummary struct {
+	Symbol             string  `json:"symbol"`
+	Z                  int     `json:"z"`
+	A                  int     `json:"a"`
+	HalfLifeSeconds    float64 `json:"half_life_seconds"`
+	Stable             bool    `json:"stable"`
+	ProducedCount      uint64  `json:"produced_count"`
+	DecayedCount       uint64  `json:"decayed_count"`
+	HeatContributedMeV float64 `json:"heat_contributed_mev"`
+}
+
+// decayChainDaughter is one outgoing branch in a nuclide's decay-chain detail view.
+type decayChainDaughter struct {
+	Type           string  `json:"type"`
+	BranchingRatio float64 `json:"branching_ratio"`
+	DaughterSymbol string  `json:"daughter_symbol"`
+	QValueMeV      float64 `json:"q_value_mev"`
+}
+
+// decayChainDetail is the GET /api/v1/decay-chains/{nuclide} response.
+type decayChainDetail struct {
+	decayChainSummary
+	Daughters []decayChainDaughter `json:"daughters"`
+}
+
+// Snapshot returns every nuclide observed so far, for the /api/v1/decay-chains listing.
+func (t *DecayChainTracker) Snapshot() []decayChainSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	summaries := make([]decayChainSummary, 0, len(t.records))
+	for _, record := range t.records {
+		summaries = append(summaries, summaryOf(record))
+	}
+	return summaries
+}
+
+// Detail returns the named nuclide's summary plus its daughter branches, if it has
+// been observed. Lookup is case-insensitive since nuclide symbols are user-typed URL
+// path segments (e.g. "/api/v1/decay-chains/Cs-137" or ".../cs-137").
+func (t *DecayChainTracker) Detail(symbol string) (decayChainDetail, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, record := range t.records {
+		if strings.EqualFold(record.Nuclide.Symbol, symbol) {
+			daughters := make([]decayChainDaughter, 0, len(record.Nuclide.Modes))
+			for _, mode := range record.Nuclide.Modes {
+				daughterSymbol := mode.Type
+				if daughter, ok := t.source.Lookup(mode.DaughterZ, mode.DaughterA); ok {
+					daughterSymbol = daughter.Symbol
+				}
+				daughters = append(daughters, decayChainDaughter{
+					Type:           mode.Type,
+					BranchingRatio: mode.BranchingRatio,
+					DaughterSymbol: daughterSymbol,
+					QValueMeV:      mode.QValueMeV,
+				})
+			}
+			return decayChainDetail{decayChainSummary: summaryOf(record), Daughters: daughters}, true
+		}
+	}
+	return decayChainDetail{}, false
+}
+
+func summaryOf(record *decayChainRecord) decayChainSummary {
+	return decayChainSummary{
+		Symbol:             record.Nuclide.Symbol,
+		Z:                  record.Nuclide.Z,
+		A:                  record.Nuclide.A,
+		HalfLifeSeconds:    record.Nuclide.HalfLifeSeconds,
+		Stable:             record.Nuclide.stable(),
+		ProducedCount:      record.ProducedCount,
+		DecayedCount:       record.DecayedCount,
+		HeatContributedMeV: record.HeatContributedMeV,
+	}
+}
+
+// =============================================================================
+// HTTP HANDLERS
+// =============================================================================
+
+// handleDecayChains serves GET /api/v1/decay-chains: every nuclide observed so far.
+func (s *TernaryFissionAPIServer) handleDecayChains(w http.ResponseWriter, r *http.Request) {
+	s.writeJSONResponse(w, http.StatusOK, s.decayTracker.Snapshot())
+}
+
+// handleDecayChainDetail serves GET /api/v1/decay-chains/{nuclide}.
+func (s *TernaryFissionAPIServer) handleDecayChainDetail(w http.ResponseWriter, r *http.Request) {
+	symbol := strings.TrimPrefix(r.URL.Path, "/api/v1/decay-chains/")
+	if symbol == "" {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Missing nuclide symbol")
+		return
+	}
+
+	detail, ok := s.decayTracker.Detail(symbol)
+	if !ok {
+		s.writeErrorResponse(w, http.StatusNotFound, "Nuclide not found")
+		return
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, detail)
+}
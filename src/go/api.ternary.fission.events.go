@@ -0,0 +1,154 @@
+/*
+ * File: src/go/api.ternary.fission.events.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Server-Sent Events Endpoint Over the Buffered Event Bus
+ * Purpose: Exposes GET /api/v1/events, streaming FieldCreated/FieldDissipated/
+ *          PortalTriggered/ReactorStatus events from events.BufferedSubscription as
+ *          "event:"/"data:" SSE frames with Last-Event-ID resume
+ * Reason: The WebSocket monitor requires holding a WebSocket open; this lets browsers,
+ *         curl, and Grafana Live consume the same event stream over plain HTTP
+ *
+ * Change Log:
+ * 2025-08-09: Initial /api/v1/events handler, ?since=/Last-Event-ID resume, and
+ *             ?mask=field,portal,status topic filtering
+ *
+ * Carry-over Context:
+ * - This mirrors handleMonitorSSE in api.ternary.fission.monitor.go closely; the two stay
+ *   separate handlers because the monitor transport's history is reactor-status-only and
+ *   already shipped with its own replay semantics, while this one spans every event kind
+ * - createEnergyField, dissipateEnergyField, and triggerPortalSimulation publish into
+ *   s.events after a successful reactor response; MonitorTransportNegotiator.publish
+ *   additionally republishes every reactor status tick as a ReactorStatus event so the
+ *   two transports never drift apart on what "status" means
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"ternary-fission/events"
+)
+
+// eventsIdleTimeout falls back to a sane default when Config.EventsIdleTimeoutSeconds is
+// unset (e.g. a Config built directly in tests without defaultConfig()'s default).
+func eventsIdleTimeout(config *Config) time.Duration {
+	if config.EventsIdleTimeoutSeconds <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(config.EventsIdleTimeoutSeconds) * time.Second
+}
+
+// parseEventsMask maps the comma-separated ?mask= query value's topic names to event
+// kinds; an empty mask subscribes to every kind. "field" covers both field lifecycle
+// kinds since callers generally want to watch field activity as a whole.
+func parseEventsMask(raw string) []events.Kind {
+	if raw == "" {
+		return nil
+	}
+
+	var mask []events.Kind
+	for _, topic := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(topic) {
+		case "field":
+			mask = append(mask, events.KindFieldCreated, events.KindFieldDissipated)
+		case "portal":
+			mask = append(mask, events.KindPortalTriggered)
+		case "status":
+			mask = append(mask, events.KindReactorStatus)
+		}
+	}
+	return mask
+}
+
+// handleEventsSSE streams the buffered event bus as SSE frames, replaying any events
+// since the client's Last-Event-ID (or ?since=) before switching to live publishes, and
+// closing the connection after EventsIdleTimeoutSeconds without a new event.
+func (s *TernaryFissionAPIServer) handleEventsSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeErrorResponse(w, http.StatusInternalServerError, "Streaming unsupported")
+		return
+	}
+
+	var sinceID uint64
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		if parsed, err := strconv.ParseUint(lastEventID, 10, 64); err == nil {
+			sinceID = parsed
+		}
+	} else if raw := r.URL.Query().Get("since"); raw != "" {
+		if parsed, err := strconv.ParseUint(raw, 10, 64); err == nil {
+			sinceID = parsed
+		}
+	}
+	mask := parseEventsMask(r.URL.Query().Get("mask"))
+
+	// We subscribe before replaying the backlog so no event published in between is lost.
+	_, feed, unsubscribe := s.events.Subscribe(mask)
+	defer unsubscribe()
+
+	backlog, ok := s.events.Since(sinceID, mask)
+	if !ok {
+		backlog = nil
+		sinceID = s.events.LatestID()
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	lastSentID := sinceID
+	for _, event := range backlog {
+		if err := writeEventsSSEFrame(w, event); err != nil {
+			return
+		}
+		lastSentID = event.ID
+	}
+	flusher.Flush()
+
+	idleTimeout := eventsIdleTimeout(s.config)
+	idleTimer := time.NewTimer(idleTimeout)
+	defer idleTimer.Stop()
+
+	for {
+		select {
+		case event := <-feed:
+			if event.ID <= lastSentID {
+				continue
+			}
+			if err := writeEventsSSEFrame(w, event); err != nil {
+				return
+			}
+			lastSentID = event.ID
+			flusher.Flush()
+			if !idleTimer.Stop() {
+				<-idleTimer.C
+			}
+			idleTimer.Reset(idleTimeout)
+		case <-idleTimer.C:
+			return
+		case <-r.Context().Done():
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// writeEventsSSEFrame writes event as an SSE frame whose "event:" line is the event kind
+// and whose "data:" line is the JSON-encoded payload.
+func writeEventsSSEFrame(w http.ResponseWriter, event events.Event) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Kind, payload)
+	return err
+}
@@ -0,0 +1,152 @@
+/*
+ * File: src/go/api.ternary.fission.fragments.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Per-Fragment Momentum Synthesis for the WebSocket fragment_burst Message
+ * Purpose: Derives a representative three-body (two heavy fragments + alpha) momentum
+ *          breakdown for the dashboard's 3D trajectory viewer
+ * Reason: The reactor's /api/v1/status only reports aggregate energy/event counters, not
+ *         per-event fragment momenta, so handleWebSocketConnection needs something to
+ *         turn into particles for the new WebGL viewer
+ *
+ * Change Log:
+ * 2025-08-09: Initial fragment_burst synthesis: mass/charge split scaled from
+ *             Config.ParentMass, kinetic energy partitioned inversely by mass, momenta
+ *             from p = sqrt(E^2 - (mc^2)^2), emission geometry randomized per burst
+ * 2025-08-09: computeFragmentBurst now takes the parent's Z directly instead of scaling it
+ *             from the U-235 ratio, so a parent nucleus looked up from nucdata (Th-232,
+ *             U-233, U-238, Pu-239, Cm-245, Cf-252, ...) produces a correctly charged
+ *             split; the U-235 ratio constants remain only as parentNucleusZA's fallback
+ *             for an unrecognized parent symbol
+ *
+ * Carry-over Context:
+ * - The heavy/light mass split still uses lightFragmentMassFraction, the classic U-235
+ *   ternary fission ~41/59 split; unlike Z this isn't sourced per-parent since nucdata
+ *   doesn't tabulate fission yields, so it remains a documented approximation
+ * - The alpha is emitted along an axis perpendicular to the two heavy fragments, matching
+ *   the real-world tendency of ternary fission's long-range alpha to leave near-equatorial
+ *   to the fission axis; the whole emission plane is rotated by a random angle per burst
+ *   purely so repeated bursts don't render identically in the viewer
+ */
+
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// amuToMeV converts an atomic mass unit to its rest energy equivalent in MeV (mc^2).
+const amuToMeV = 931.494
+
+// u235Z and u235MassNumber anchor the charge-to-mass ratio parentNucleusZA falls back to
+// when the configured parent symbol isn't in nucdata.ParentPresets.
+const (
+	u235Z          = 92.0
+	u235MassNumber = 235.0
+)
+
+// alphaMassNumber and alphaZ describe the light charged particle ternary fission is
+// named for; it carries off comparatively little of the released energy.
+const (
+	alphaMassNumber = 4.0
+	alphaZ          = 2
+)
+
+// lightFragmentMassFraction is the light/total mass fraction of the two heavy
+// fragments once the alpha's mass is removed, matching U-235's asymmetric split
+// (light fragment ~95u, heavy fragment ~140u out of ~235u less the alpha).
+const lightFragmentMassFraction = 0.41
+
+// fragmentBurstRand drives the per-burst emission-plane rotation; it does not need to
+// be cryptographically secure, only to vary the viewer's rendering between bursts.
+var fragmentBurstRand = rand.New(rand.NewSource(1))
+
+// fragment is one emitted particle's kinematics, in the parent's rest frame.
+type fragment struct {
+	Label            string  `json:"label"`
+	Z                int     `json:"z"`
+	MassNumber       float64 `json:"mass_number"`
+	KineticEnergyMeV float64 `json:"kinetic_energy_mev"`
+	MomentumMeVPerC  float64 `json:"momentum_mev_per_c"`
+	DirectionX       float64 `json:"direction_x"`
+	DirectionY       float64 `json:"direction_y"`
+	DirectionZ       float64 `json:"direction_z"`
+}
+
+// fragmentBurstMessage is pushed over /api/v1/ws/monitor whenever the polling loop
+// observes new fission events, so the dashboard's 3D viewer has something to render.
+type fragmentBurstMessage struct {
+	Type           string     `json:"type"`
+	EventID        uint64     `json:"event_id"`
+	TotalEnergyMeV float64    `json:"total_energy_mev"`
+	Fragments      []fragment `json:"fragments"`
+}
+
+// computeFragmentBurst synthesizes a representative ternary split (two heavy fragments
+// plus an alpha) releasing totalEnergyMeV of kinetic energy, for a parent nucleus of mass
+// parentMassAMU and charge parentZ (resolved by the caller from nucdata, via
+// parentNucleusZA). Kinetic energy is partitioned inversely by fragment mass, which is
+// the three-body generalization of two-body momentum conservation.
+func computeFragmentBurst(eventID uint64, totalEnergyMeV float64, parentMassAMU float64, parentZ float64) fragmentBurstMessage {
+	remainingMass := parentMassAMU - alphaMassNumber
+	remainingZ := parentZ - alphaZ
+
+	lightMass := remainingMass * lightFragmentMassFraction
+	heavyMass := remainingMass - lightMass
+	lightZ := remainingZ * lightFragmentMassFraction
+	heavyZ := remainingZ - lightZ
+
+	// We rotate the whole emission plane by a random angle per burst so repeated
+	// bursts don't all render along the same fixed axes in the viewer.
+	theta := fragmentBurstRand.Float64() * 2 * math.Pi
+	axisX := math.Cos(theta)
+	axisZ := math.Sin(theta)
+	perpX := -math.Sin(theta)
+	perpZ := math.Cos(theta)
+
+	fragments := []fragment{
+		kinematicFragment("heavy_light", int(math.Round(lightZ)), lightMass, totalEnergyMeV, []float64{lightMass, heavyMass, alphaMassNumber}, axisX, 0, axisZ),
+		kinematicFragment("heavy_heavy", int(math.Round(heavyZ)), heavyMass, totalEnergyMeV, []float64{lightMass, heavyMass, alphaMassNumber}, -axisX, 0, -axisZ),
+		kinematicFragment("alpha", alphaZ, alphaMassNumber, totalEnergyMeV, []float64{lightMass, heavyMass, alphaMassNumber}, perpX, 1, perpZ),
+	}
+
+	return fragmentBurstMessage{
+		Type:           "fragment_burst",
+		EventID:        eventID,
+		TotalEnergyMeV: totalEnergyMeV,
+		Fragments:      fragments,
+	}
+}
+
+// kinematicFragment builds one fragment's entry: its share of totalEnergyMeV (inversely
+// proportional to its mass among masses), the resulting momentum via
+// p = sqrt(E^2 - (mc^2)^2), and a unit emission direction.
+func kinematicFragment(label string, z int, massAMU, totalEnergyMeV float64, massesAMU []float64, dirX, dirY, dirZ float64) fragment {
+	var weightSum float64
+	for _, m := range massesAMU {
+		weightSum += 1.0 / m
+	}
+
+	kineticEnergyMeV := totalEnergyMeV * (1.0 / massAMU) / weightSum
+	restEnergyMeV := massAMU * amuToMeV
+	totalRelativisticEnergy := kineticEnergyMeV + restEnergyMeV
+
+	momentum := math.Sqrt(math.Max(0, totalRelativisticEnergy*totalRelativisticEnergy-restEnergyMeV*restEnergyMeV))
+
+	length := math.Sqrt(dirX*dirX + dirY*dirY + dirZ*dirZ)
+	if length == 0 {
+		length = 1
+	}
+
+	return fragment{
+		Label:            label,
+		Z:                z,
+		MassNumber:       massAMU,
+		KineticEnergyMeV: kineticEnergyMeV,
+		MomentumMeVPerC:  momentum,
+		DirectionX:       dirX / length,
+		DirectionY:       dirY / length,
+		DirectionZ:       dirZ / length,
+	}
+}
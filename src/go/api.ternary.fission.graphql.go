@@ -0,0 +1,540 @@
+/*
+ * File: src/go/api.ternary.fission.graphql.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: GraphQL Transport (graphql-transport-ws) for the Energy-Field API
+ * Purpose: Exposes the existing REST resources (system status, energy fields, portal
+ *          trigger) as GraphQL queries/mutations, plus a subscription root streaming field
+ *          lifecycle events and status snapshots, over the graphql-ws reference client's
+ *          "graphql-transport-ws" WebSocket subprotocol
+ * Reason: The dashboard polled /api/v1/status over plain HTTP every 3 seconds; a push
+ *         subscription removes that polling loop and gives other GraphQL clients a single
+ *         negotiated transport for queries, mutations, and live updates
+ *
+ * Change Log:
+ * 2025-08-09: Initial graphql-transport-ws state machine (connection_init/ack, ping/pong,
+ *             subscribe/next/complete, the 4400/4401/4408/4409/4429/4500 close codes) and
+ *             six root resolvers: systemStatus, energyFields, createEnergyField,
+ *             triggerPortal, fieldEvents, statusSnapshots
+ * 2025-08-10: requireRole(RoleViewer, ...) on the /graphql route only gates the WebSocket
+ *             upgrade, not each operation sent over the connection afterward, so a
+ *             viewer-role connection could reach createEnergyField/triggerPortal even
+ *             though their REST equivalents require RoleOperator. handleGraphQLWebSocket
+ *             now resolves the caller's role once via s.auth.authenticate(r) and carries it
+ *             on the connection's context (gqlRoleContextKey/gqlRoleFromContext); both
+ *             mutation resolvers check roleSatisfies(gqlRoleFromContext(ctx), RoleOperator)
+ *             before forwarding to the reactor
+ *
+ * Carry-over Context:
+ * - We do not implement a full GraphQL language parser. graphQLRootField extracts the single
+ *   root selection field name (e.g. "systemStatus" from "{ systemStatus { ... } }" or
+ *   "query Foo { systemStatus { ... } }"), which is all this small, flat schema needs; a
+ *   request with more than one root field or a fragment is not supported. Adding a root field
+ *   only means adding an entry to graphQLResolvers
+ * - createEnergyField/triggerPortal resolvers take a Subscribe message's variables map as the
+ *   request body, so GraphQL variables use the same snake_case keys EnergyFieldRequest and
+ *   the portal-trigger request already use over REST (initial_energy_mev, field_name, ...)
+ *   rather than introducing camelCase GraphQL-style argument names
+ * - fieldEvents/statusSnapshots poll the reactor on the same cadence the dashboard's old HTTP
+ *   poll used (graphQLFieldEventsPollInterval); a real GraphQL engine would instead push on
+ *   the reactor's own event stream, which this repo snapshot doesn't expose
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"ternary-fission/logging"
+)
+
+// graphQLWSSubprotocol is the Sec-WebSocket-Protocol this server negotiates for /graphql,
+// matching the graphql-ws library's "graphql-transport-ws" subprotocol.
+const graphQLWSSubprotocol = "graphql-transport-ws"
+
+// graphql-transport-ws message types; see
+// https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+const (
+	gqlMsgConnectionInit = "connection_init"
+	gqlMsgConnectionAck  = "connection_ack"
+	gqlMsgPing           = "ping"
+	gqlMsgPong           = "pong"
+	gqlMsgSubscribe      = "subscribe"
+	gqlMsgNext           = "next"
+	gqlMsgError          = "error"
+	gqlMsgComplete       = "complete"
+)
+
+// Server-side close codes the graphql-transport-ws protocol reserves above the standard
+// WebSocket range (RFC 6455 leaves 4000-4999 to applications).
+const (
+	gqlCloseBadRequest              = 4400
+	gqlCloseUnauthorized            = 4401
+	gqlCloseConnectionInitTimeout   = 4408
+	gqlCloseSubscriberAlreadyExists = 4409
+	gqlCloseTooManyInitRequests     = 4429
+	gqlCloseInternalServerError     = 4500
+)
+
+// graphQLCloseDeadline bounds how long we wait for a close frame to flush before giving up.
+const graphQLCloseDeadline = 5 * time.Second
+
+// graphQLMessage is the envelope every graphql-transport-ws frame uses.
+type graphQLMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// graphQLSubscribePayload is a Subscribe message's payload.
+type graphQLSubscribePayload struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+// graphQLRootFieldPattern extracts the root selection's field name from a query/mutation/
+// subscription document (see the Carry-over Context above for why this isn't a full parser).
+var graphQLRootFieldPattern = regexp.MustCompile(`\{\s*([A-Za-z_][A-Za-z0-9_]*)`)
+
+func graphQLRootField(query string) string {
+	match := graphQLRootFieldPattern.FindStringSubmatch(query)
+	if len(match) != 2 {
+		return ""
+	}
+	return match[1]
+}
+
+// =============================================================================
+// RESOLVERS
+// =============================================================================
+
+// graphQLResolver resolves one root field. A one-shot query/mutation resolver calls emit
+// once and returns; a subscription resolver calls emit repeatedly until ctx is cancelled.
+type graphQLResolver func(ctx context.Context, s *TernaryFissionAPIServer, variables map[string]interface{}, emit func(interface{}) error) error
+
+// graphQLResolvers maps every root field this schema exposes to its resolver.
+var graphQLResolvers = map[string]graphQLResolver{
+	"systemStatus":      resolveSystemStatus,
+	"energyFields":      resolveEnergyFields,
+	"createEnergyField": resolveCreateEnergyField,
+	"triggerPortal":     resolveTriggerPortal,
+	"fieldEvents":       resolveFieldEvents,
+	"statusSnapshots":   resolveStatusSnapshots,
+}
+
+// resolveSystemStatus is the GraphQL equivalent of GET /api/v1/status.
+func resolveSystemStatus(ctx context.Context, s *TernaryFissionAPIServer, variables map[string]interface{}, emit func(interface{}) error) error {
+	status, _, err := s.fetchSystemStatus()
+	if err != nil {
+		return err
+	}
+	return emit(status)
+}
+
+// resolveEnergyFields is the GraphQL equivalent of GET /api/v1/energy-fields.
+func resolveEnergyFields(ctx context.Context, s *TernaryFissionAPIServer, variables map[string]interface{}, emit func(interface{}) error) error {
+	resp, err := s.reactorClient.Get(fmt.Sprintf("%s/api/v1/energy-fields", s.config.ReactorBaseURL))
+	if err != nil {
+		return fmt.Errorf("failed to contact reactor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var fields []EnergyFieldResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
+		return fmt.Errorf("invalid reactor response: %w", err)
+	}
+	return emit(fields)
+}
+
+// resolveCreateEnergyField is the GraphQL equivalent of POST /api/v1/energy-fields, including
+// registering the field's local-energy estimator exactly as createEnergyField does.
+func resolveCreateEnergyField(ctx context.Context, s *TernaryFissionAPIServer, variables map[string]interface{}, emit func(interface{}) error) error {
+	if s.config.AuthEnabled && !roleSatisfies(gqlRoleFromContext(ctx), RoleOperator) {
+		return fmt.Errorf("insufficient role for this operation")
+	}
+
+	body, err := json.Marshal(variables)
+	if err != nil {
+		return fmt.Errorf("invalid variables: %w", err)
+	}
+	var fieldReq EnergyFieldRequest
+	_ = json.Unmarshal(body, &fieldReq)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/energy-fields", s.config.ReactorBaseURL), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build reactor request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.reactorClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to contact reactor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read reactor response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("reactor returned %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var created EnergyFieldResponse
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return fmt.Errorf("invalid reactor response: %w", err)
+	}
+	if created.FieldID != "" {
+		parentZ, _ := s.parentNucleusZA()
+		s.localEnergy.Register(created.FieldID, fieldReq.TrialWavefunctionName, defaultEnergyFieldPotential(parentZ))
+	}
+	return emit(created)
+}
+
+// resolveTriggerPortal is the GraphQL equivalent of PUT /api/v1/portal/trigger.
+func resolveTriggerPortal(ctx context.Context, s *TernaryFissionAPIServer, variables map[string]interface{}, emit func(interface{}) error) error {
+	if s.config.AuthEnabled && !roleSatisfies(gqlRoleFromContext(ctx), RoleOperator) {
+		return fmt.Errorf("insufficient role for this operation")
+	}
+
+	body, err := json.Marshal(variables)
+	if err != nil {
+		return fmt.Errorf("invalid variables: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, s.config.ReactorBaseURL+"/api/v1/portal/trigger", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build reactor request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.reactorClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to contact reactor: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read reactor response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("reactor returned %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("invalid reactor response: %w", err)
+	}
+	return emit(result)
+}
+
+// graphQLPollInterval sets how often fieldEvents/statusSnapshots poll the reactor, matching
+// the dashboard's historical 3-second HTTP refresh.
+const graphQLPollInterval = 3 * time.Second
+
+// resolveFieldEvents streams a lifecycle event (active field count, total energy) whenever
+// either changes, replacing the dashboard's HTTP poll with a push subscription.
+func resolveFieldEvents(ctx context.Context, s *TernaryFissionAPIServer, variables map[string]interface{}, emit func(interface{}) error) error {
+	ticker := time.NewTicker(graphQLPollInterval)
+	defer ticker.Stop()
+
+	previousActiveFields := -1
+	previousTotalEnergy := -1.0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			status, _, err := s.fetchSystemStatus()
+			if err != nil {
+				continue
+			}
+			if status.ActiveEnergyFields == previousActiveFields && status.TotalEnergySimulated == previousTotalEnergy {
+				continue
+			}
+			previousActiveFields = status.ActiveEnergyFields
+			previousTotalEnergy = status.TotalEnergySimulated
+
+			if err := emit(map[string]interface{}{
+				"active_energy_fields": status.ActiveEnergyFields,
+				"total_energy_mev":     status.TotalEnergySimulated,
+				"decay_heat_mev_per_s": status.DecayHeatMeVPerSecond,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// resolveStatusSnapshots streams a full SystemStatusResponse on every poll tick.
+func resolveStatusSnapshots(ctx context.Context, s *TernaryFissionAPIServer, variables map[string]interface{}, emit func(interface{}) error) error {
+	ticker := time.NewTicker(graphQLPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			status, _, err := s.fetchSystemStatus()
+			if err != nil {
+				continue
+			}
+			if err := emit(status); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// =============================================================================
+// TRANSPORT
+// =============================================================================
+
+// graphQLConnection holds the per-connection state for one /graphql WebSocket: the open
+// subscriptions and a write mutex, since gorilla/websocket forbids concurrent writes to the
+// same connection.
+type graphQLConnection struct {
+	server *TernaryFissionAPIServer
+	conn   *websocket.Conn
+
+	writeMu sync.Mutex
+
+	subsMu sync.Mutex
+	subs   map[string]context.CancelFunc
+}
+
+func (c *graphQLConnection) writeMessage(msg graphQLMessage) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteJSON(msg)
+}
+
+func (c *graphQLConnection) closeWithCode(code int, reason string) {
+	c.writeMu.Lock()
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(graphQLCloseDeadline))
+	c.writeMu.Unlock()
+}
+
+func (c *graphQLConnection) sendError(id, message string) {
+	payload, _ := json.Marshal([]map[string]string{{"message": message}})
+	if err := c.writeMessage(graphQLMessage{ID: id, Type: gqlMsgError, Payload: payload}); err != nil {
+		c.server.logger.Error("GraphQL error write failed", logging.Fields{"error": err.Error(), "id": id})
+	}
+}
+
+// cancelSubscription stops id's resolver goroutine (if any) and forgets it; safe to call for
+// an id with no active subscription.
+func (c *graphQLConnection) cancelSubscription(id string) {
+	c.subsMu.Lock()
+	cancel, ok := c.subs[id]
+	if ok {
+		delete(c.subs, id)
+	}
+	c.subsMu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// runOperation drives one Subscribe message's resolver to completion, emitting Next messages
+// and finishing with Complete (or Error on failure). It always forgets id from subs on return.
+func (c *graphQLConnection) runOperation(ctx context.Context, id string, resolver graphQLResolver, variables map[string]interface{}) {
+	defer c.cancelSubscription(id)
+
+	err := resolver(ctx, c.server, variables, func(data interface{}) error {
+		payload, err := json.Marshal(map[string]interface{}{"data": data})
+		if err != nil {
+			return err
+		}
+		return c.writeMessage(graphQLMessage{ID: id, Type: gqlMsgNext, Payload: payload})
+	})
+
+	if ctx.Err() != nil {
+		// The client sent Complete (or the connection closed) before the resolver finished
+		// on its own; no Error/Complete is sent for a cancellation the client already knows about.
+		return
+	}
+	if err != nil {
+		c.sendError(id, err.Error())
+		return
+	}
+	if err := c.writeMessage(graphQLMessage{ID: id, Type: gqlMsgComplete}); err != nil {
+		c.server.logger.Error("GraphQL complete write failed", logging.Fields{"error": err.Error(), "id": id})
+	}
+}
+
+// handleSubscribe starts a resolver for a Subscribe message, closing the connection with 4409
+// if id is already in use or 4400 if the message is malformed.
+func (c *graphQLConnection) handleSubscribe(ctx context.Context, msg graphQLMessage) {
+	if msg.ID == "" {
+		c.closeWithCode(gqlCloseBadRequest, "Bad request: subscribe requires an id")
+		return
+	}
+
+	var payload graphQLSubscribePayload
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		c.closeWithCode(gqlCloseBadRequest, "Bad request: invalid subscribe payload")
+		return
+	}
+
+	operation := graphQLRootField(payload.Query)
+	resolver, ok := graphQLResolvers[operation]
+	if !ok {
+		c.sendError(msg.ID, fmt.Sprintf("unknown operation %q", operation))
+		return
+	}
+
+	c.subsMu.Lock()
+	if _, exists := c.subs[msg.ID]; exists {
+		c.subsMu.Unlock()
+		c.closeWithCode(gqlCloseSubscriberAlreadyExists, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+		return
+	}
+	subCtx, cancel := context.WithCancel(ctx)
+	c.subs[msg.ID] = cancel
+	c.subsMu.Unlock()
+
+	go c.runOperation(subCtx, msg.ID, resolver, payload.Variables)
+}
+
+// runKeepalive sends a Ping on the server's existing WebSocket ping cadence so the transport
+// is kept alive in both directions, not just in response to a client-initiated Ping.
+func (c *graphQLConnection) runKeepalive(ctx context.Context) {
+	interval := time.Duration(c.server.config.WebSocketPingInterval) * time.Second
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.writeMessage(graphQLMessage{Type: gqlMsgPing}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// serve runs the graphql-transport-ws state machine for one connection until it closes.
+func (c *graphQLConnection) serve(ctx context.Context) {
+	connCtx, cancelConn := context.WithCancel(ctx)
+	defer cancelConn()
+
+	ackTimeout := time.Duration(c.server.config.GraphQLConnectionAckWaitTimeoutSeconds) * time.Second
+	c.conn.SetReadDeadline(time.Now().Add(ackTimeout))
+
+	initialized := false
+	for {
+		var msg graphQLMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			if !initialized {
+				c.closeWithCode(gqlCloseConnectionInitTimeout, "Connection initialisation timeout")
+			}
+			return
+		}
+
+		switch msg.Type {
+		case gqlMsgConnectionInit:
+			if initialized {
+				c.closeWithCode(gqlCloseTooManyInitRequests, "Too many initialisation requests")
+				return
+			}
+			initialized = true
+			c.conn.SetReadDeadline(time.Time{})
+			if err := c.writeMessage(graphQLMessage{Type: gqlMsgConnectionAck}); err != nil {
+				return
+			}
+			go c.runKeepalive(connCtx)
+
+		case gqlMsgPing:
+			if err := c.writeMessage(graphQLMessage{Type: gqlMsgPong}); err != nil {
+				return
+			}
+
+		case gqlMsgPong:
+			// Keepalive acknowledged; nothing to do.
+
+		case gqlMsgSubscribe:
+			if !initialized {
+				c.closeWithCode(gqlCloseUnauthorized, "Unauthorized: connection_init required")
+				return
+			}
+			c.handleSubscribe(connCtx, msg)
+
+		case gqlMsgComplete:
+			c.cancelSubscription(msg.ID)
+
+		default:
+			c.closeWithCode(gqlCloseBadRequest, fmt.Sprintf("Bad request: unknown message type %q", msg.Type))
+			return
+		}
+	}
+}
+
+// gqlRoleContextKey is the context.Context key handleGraphQLWebSocket attaches the connection's
+// resolved role under, so a mutation resolver (resolveCreateEnergyField, resolveTriggerPortal)
+// can re-check it itself: requireRole(RoleViewer, ...) on the /graphql route
+// (api.ternary.fission.server.go) only gates the WebSocket upgrade, not each individual
+// operation a viewer-role connection subsequently subscribes to over it.
+type gqlRoleContextKey struct{}
+
+// gqlRoleFromContext returns the role handleGraphQLWebSocket attached to ctx, or "" if none was
+// (which satisfies no roleSatisfies check above RoleViewer's own rank of 0).
+func gqlRoleFromContext(ctx context.Context) string {
+	role, _ := ctx.Value(gqlRoleContextKey{}).(string)
+	return role
+}
+
+// handleGraphQLWebSocket upgrades to the graphql-transport-ws subprotocol and runs the
+// connection's message loop.
+func (s *TernaryFissionAPIServer) handleGraphQLWebSocket(w http.ResponseWriter, r *http.Request) {
+	// requireRole(RoleViewer, ...) already required at least a viewer credential to reach
+	// here; we re-authenticate (the same re-check loggingMiddleware does for its "user" field)
+	// to learn which role it actually resolved to, since mutation resolvers need that distinct
+	// from the viewer floor the route itself enforces.
+	role := RoleAdmin
+	if s.config.AuthEnabled {
+		role = RoleViewer
+		if authCtx, ok := s.auth.authenticate(r); ok {
+			role = authCtx.Role
+		}
+	}
+
+	upgrader := s.websocketUpgrader
+	upgrader.Subprotocols = []string{graphQLWSSubprotocol}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Error("GraphQL WebSocket upgrade failed", logging.Fields{"error": err.Error()})
+		return
+	}
+	defer conn.Close()
+
+	if conn.Subprotocol() != graphQLWSSubprotocol {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(gqlCloseBadRequest, "expected graphql-transport-ws subprotocol"), time.Now().Add(graphQLCloseDeadline))
+		return
+	}
+
+	s.logger.Info("GraphQL WebSocket client connected", logging.Fields{"remote_addr": r.RemoteAddr})
+
+	gqlConn := &graphQLConnection{server: s, conn: conn, subs: make(map[string]context.CancelFunc)}
+	gqlConn.serve(context.WithValue(s.ctx, gqlRoleContextKey{}, role))
+}
@@ -0,0 +1,214 @@
+/*
+ * File: src/go/api.ternary.fission.grpcclient.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: gRPC Reactor Client (Config.ReactorTransport = "grpc")
+ * Purpose: Implements ReactorClient (api.ternary.fission.reactorclient.go) over the
+ *          ReactorTransport gRPC service (src/go/pb/reactor.proto) instead of JSON over HTTP,
+ *          translating each call into a synthetic *http.Response so every existing
+ *          reactor-forwarding handler is unaffected by which transport is configured
+ * Reason: chunk4-2 asked for a gRPC alternative to ReactorBaseURL without touching the ~14
+ *         existing reactor-forwarding handlers or api_integration_test.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial grpcReactorClient (GetStatus/GetEnergyField/ListEnergyFields over Do/Get)
+ *             and streamStatusEvents, which MonitorTransportNegotiator.run uses in place of its
+ *             poll ticker when this transport is active
+ *
+ * Carry-over Context:
+ * - Only the request shapes MonitorTransportNegotiator and the energy-field read handlers
+ *   already issue are translated (GET .../api/v1/status, GET .../api/v1/energy-fields[/{id}]);
+ *   a mutation (POST/DELETE) over this transport returns a plain error, same as an unsupported
+ *   interceptor name logs a warning instead of crashing - grpc transport is additive, not yet
+ *   at full parity with the HTTP path
+ * - jsonResponse below is this file's equivalent of cachedResponse.toResponse/the circuit
+ *   breaker's synthetic response in api.ternary.fission.reactorclient.go: a hand-built
+ *   *http.Response carrying a re-marshaled JSON body so json.NewDecoder(resp.Body) at every
+ *   call site keeps working unmodified
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"ternary-fission/logging"
+	"ternary-fission/pb"
+)
+
+// grpcReactorClient implements ReactorClient by dialing Config.ReactorGRPCTarget and
+// translating Do/Get calls onto the ReactorTransport service.
+type grpcReactorClient struct {
+	server *TernaryFissionAPIServer
+	conn   *grpc.ClientConn
+	client pb.ReactorTransportClient
+}
+
+// newGRPCReactorClient dials config.ReactorGRPCTarget. The dial itself is non-blocking (grpc-go
+// connects lazily on first RPC), so a reactor that isn't up yet doesn't delay server startup;
+// the first forwarded request simply fails until it is.
+func newGRPCReactorClient(server *TernaryFissionAPIServer) (*grpcReactorClient, error) {
+	var creds grpc.DialOption
+	if server.config.ReactorGRPCInsecure {
+		creds = grpc.WithTransportCredentials(insecure.NewCredentials())
+	} else {
+		// The reactor side of this transport has no documented TLS story yet; rather than
+		// silently dial plaintext when an operator forgot reactor_grpc_insecure, we require
+		// it explicitly, matching OTLPInsecure's explicit opt-in in api.ternary.fission.tracing.go.
+		return nil, fmt.Errorf("reactor_transport=grpc requires reactor_grpc_insecure=true (no TLS credentials configured)")
+	}
+
+	conn, err := grpc.NewClient(server.config.ReactorGRPCTarget, creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial reactor gRPC target %s: %w", server.config.ReactorGRPCTarget, err)
+	}
+
+	return &grpcReactorClient{
+		server: server,
+		conn:   conn,
+		client: pb.NewReactorTransportClient(conn),
+	}, nil
+}
+
+// Get matches *http.Client.Get's signature, mirroring ReactorDispatcher.Get.
+func (g *grpcReactorClient) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return g.Do(req)
+}
+
+// Do translates the handful of request shapes the existing reactor-forwarding handlers build
+// (GET status, GET energy-fields[/{id}]) into the matching unary RPC, and wraps the result back
+// up as a synthetic *http.Response carrying the equivalent JSON body.
+func (g *grpcReactorClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	path := strings.TrimRight(req.URL.Path, "/")
+
+	switch {
+	case req.Method == http.MethodGet && strings.HasSuffix(path, "/api/v1/status"):
+		status, err := g.client.GetStatus(ctx, &pb.GetStatusRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return jsonResponse(req, g.server.codec(), http.StatusOK, systemStatusResponseFromPB(status))
+
+	case req.Method == http.MethodGet && strings.HasSuffix(path, "/api/v1/energy-fields"):
+		list, err := g.client.ListEnergyFields(ctx, &pb.ListEnergyFieldsRequest{})
+		if err != nil {
+			return nil, err
+		}
+		fields := make([]EnergyFieldResponse, 0, len(list.Fields))
+		for i := range list.Fields {
+			fields = append(fields, energyFieldResponseFromPB(&list.Fields[i]))
+		}
+		return jsonResponse(req, g.server.codec(), http.StatusOK, fields)
+
+	case req.Method == http.MethodGet && strings.Contains(path, "/api/v1/energy-fields/"):
+		fieldID := path[strings.LastIndex(path, "/")+1:]
+		field, err := g.client.GetEnergyField(ctx, &pb.GetEnergyFieldRequest{FieldID: fieldID})
+		if err != nil {
+			return nil, err
+		}
+		return jsonResponse(req, g.server.codec(), http.StatusOK, energyFieldResponseFromPB(field))
+
+	default:
+		return nil, fmt.Errorf("grpc reactor client: unsupported request %s %s", req.Method, req.URL.Path)
+	}
+}
+
+// jsonResponse builds a synthetic *http.Response carrying body marshaled as JSON via codec, the
+// same way cachedResponse.toResponse and the circuit breaker's open-state response in
+// api.ternary.fission.reactorclient.go hand-build one, so every caller's
+// json.NewDecoder(resp.Body).Decode(...) keeps working unmodified regardless of transport or
+// which JSONCodec (api.ternary.fission.jsoncodec.go) is configured.
+func jsonResponse(req *http.Request, codec JSONCodec, statusCode int, body interface{}) (*http.Response, error) {
+	encoded, err := codec.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(encoded)),
+		Request:    req,
+	}, nil
+}
+
+func systemStatusResponseFromPB(s *pb.SystemStatus) SystemStatusResponse {
+	return SystemStatusResponse{
+		UptimeSeconds:         s.UptimeSeconds,
+		TotalFissionEvents:    s.TotalFissionEvents,
+		TotalEnergySimulated:  s.TotalEnergySimulatedMeV,
+		ActiveEnergyFields:    int(s.ActiveEnergyFields),
+		PeakMemoryUsage:       s.PeakMemoryUsageBytes,
+		AverageCalcTime:       s.AverageCalculationTimeMicroseconds,
+		TotalCalculations:     s.TotalCalculations,
+		SimulationRunning:     s.SimulationRunning,
+		CPUUsagePercent:       s.CPUUsagePercent,
+		MemoryUsagePercent:    s.MemoryUsagePercent,
+		EstimatedPower:        s.EstimatedPowerMeV,
+		PortalDurationRemain:  int(s.PortalDurationRemainingSeconds),
+		DecayHeatMeVPerSecond: s.DecayHeatMeVPerSecond,
+	}
+}
+
+func energyFieldResponseFromPB(f *pb.EnergyField) EnergyFieldResponse {
+	return EnergyFieldResponse{
+		FieldID:             f.FieldID,
+		EnergyMeV:           f.EnergyMeV,
+		MemoryBytes:         f.MemoryBytes,
+		CPUCycles:           f.CPUCycles,
+		EntropyFactor:       f.EntropyFactor,
+		DissipationRate:     f.DissipationRate,
+		StabilityFactor:     f.StabilityFactor,
+		InteractionStrength: f.InteractionStrength,
+		Active:              f.Active,
+		TotalEnergyMeV:      f.TotalEnergyMeV,
+		Status:              f.Status,
+	}
+}
+
+// streamStatusEvents subscribes to the ReactorTransport service's SubscribeEvents RPC and
+// publishes each pushed FissionEvent's SystemStatus snapshot the same way
+// MonitorTransportNegotiator.run's poll ticker does, so the ternary-monitor-v1 WebSocket/SSE/
+// long-poll transports see an identical stream regardless of which reactor transport feeds it.
+// It blocks until ctx is canceled or the stream ends, logging and returning on either.
+func (g *grpcReactorClient) streamStatusEvents(ctx context.Context, onStatus func(SystemStatusResponse)) {
+	stream, err := g.client.SubscribeEvents(ctx, &pb.SubscribeEventsRequest{})
+	if err != nil {
+		g.server.logger.Error("gRPC event subscription failed", logging.Fields{"error": err.Error()})
+		return
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				g.server.logger.Error("gRPC event stream ended", logging.Fields{"error": err.Error()})
+			}
+			return
+		}
+		if event.Status != nil {
+			status := systemStatusResponseFromPB(event.Status)
+			status.TimestampUnix = time.Now().Unix()
+			onStatus(status)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * File: src/go/api.ternary.fission.jsoncodec.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Pluggable JSON Codec for the Reactor Proxy Path
+ * Purpose: Lets Config.JSONCodec swap the encoding/json calls on the /api/v1/status and
+ *          /api/v1/energy-fields hot paths (fetchSystemStatus's decode, writeJSONResponse's
+ *          encode, and the gRPC reactor client's jsonResponse marshal) for jsoniter's
+ *          stdlib-compatible codec without changing any call site's wire output
+ * Reason: encoding/json's reflection-based encoder is the dominant cost on these paths under
+ *         concurrent load; jsoniter.ConfigCompatibleWithStandardLibrary is a drop-in that
+ *         produces byte-identical output while running substantially faster
+ *
+ * Change Log:
+ * 2025-08-09: Initial JSONCodec interface, stdlibJSONCodec/jsoniterJSONCodec, and jsonCodecFor
+ *
+ * Carry-over Context:
+ * - jsonCodecFor("") must behave exactly like jsonCodecFor("stdlib"): several existing tests
+ *   (api_integration_test.go's TestListEnergyFieldsForwards/TestGetSystemStatusParsesResponse)
+ *   build *Config as a raw struct literal that never runs through defaultConfig, so the zero
+ *   value has to be a safe, working default rather than relying on defaultConfig having set it
+ */
+
+package main
+
+import (
+	"encoding/json"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// JSONCodec is the Marshal/Unmarshal pair the reactor proxy path encodes/decodes JSON through;
+// mirrors the wireMessage/wireCodec split in pb/reactor.pb.go and pb/codec.go.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// stdlibJSONCodec wraps encoding/json directly.
+type stdlibJSONCodec struct{}
+
+func (stdlibJSONCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func (stdlibJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// jsoniterJSONCodec wraps jsoniter's stdlib-compatible configuration, which preserves
+// encoding/json's field ordering, escaping, and error behavior while avoiding most of its
+// reflection overhead.
+type jsoniterJSONCodec struct{}
+
+var jsoniterAPI = jsoniter.ConfigCompatibleWithStandardLibrary
+
+func (jsoniterJSONCodec) Marshal(v interface{}) ([]byte, error) { return jsoniterAPI.Marshal(v) }
+
+func (jsoniterJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return jsoniterAPI.Unmarshal(data, v)
+}
+
+// jsonCodecFor resolves Config.JSONCodec to a JSONCodec, defaulting to stdlibJSONCodec for "",
+// "stdlib", or any value it doesn't recognize (logged nowhere, matching buildSampler's similar
+// unrecognized-value-falls-back-to-a-safe-default treatment in api.ternary.fission.tracing.go).
+func jsonCodecFor(name string) JSONCodec {
+	switch name {
+	case "jsoniter":
+		return jsoniterJSONCodec{}
+	default:
+		return stdlibJSONCodec{}
+	}
+}
+
+// codec returns s.jsonCodec, or stdlibJSONCodec if a *TernaryFissionAPIServer was built as a
+// raw struct literal (bypassing NewTernaryFissionAPIServer, as several existing tests do) and
+// never had it set.
+func (s *TernaryFissionAPIServer) codec() JSONCodec {
+	if s.jsonCodec == nil {
+		return stdlibJSONCodec{}
+	}
+	return s.jsonCodec
+}
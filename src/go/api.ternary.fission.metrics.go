@@ -0,0 +1,217 @@
+/*
+ * File: src/go/api.ternary.fission.metrics.go
+ * Author: bthlops (David StJ)
+ * Date: August 5, 2025
+ * Title: Prometheus Scrape Target Discovery and Remote Write Push for the Reactor Metrics Subsystem
+ * Purpose: Lets a Prometheus server auto-discover reactor instances and/or receive pushed samples
+ * Reason: updateReactorMetrics previously only polled a single ReactorBaseURL with no federation story
+ *
+ * Change Log:
+ * 2025-08-05: Added multi-endpoint reactor polling, the /api/v1/targets HTTP SD responder, and a
+ *             snappy-compressed protobuf remote_write pusher built on the stable WriteRequest wire
+ *             format (hand-encoded via protowire so we don't drag in the full prometheus/prometheus
+ *             module just for its generated message types)
+ * 2025-08-09: Switched the remote_write push failure logging to the structured logger
+ * 2025-08-10: pushRemoteWrite sent its POST via s.reactorClient, the ReactorDispatcher built
+ *             for Config.ReactorBaseURL - its auth-injection interceptor attached the
+ *             reactor's own Authorization: Bearer ReactorAuthToken to any outgoing request
+ *             lacking one, leaking that credential to whatever third-party Config.RemoteWriteURL
+ *             an operator configures, and its circuit breaker synthetically failed the push
+ *             whenever the unrelated reactor backend was down. Switched to a dedicated
+ *             s.remoteWriteClient plain *http.Client instead
+ *
+ * Carry-over Context:
+ * - We keep ReactorBaseURL as the single endpoint used for request forwarding; ReactorEndpoints is
+ *   purely additive and only feeds scrape-target discovery and remote_write sample collection
+ * - pushRemoteWrite uses s.remoteWriteClient, not s.reactorClient: Config.RemoteWriteURL is an
+ *   arbitrary third-party endpoint and must not inherit reactorClient's auth-injection, retry,
+ *   or circuit-breaker interceptors
+ */
+
+package main
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"ternary-fission/logging"
+)
+
+// =============================================================================
+// SCRAPE TARGET DISCOVERY (Prometheus HTTP SD)
+// =============================================================================
+
+// ScrapeTarget matches the Prometheus HTTP service discovery JSON format:
+// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#http_sd_config
+type ScrapeTarget struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// We expose every configured reactor endpoint as its own HTTP SD target so a
+// Prometheus server can scrape each instance under a consistent job label.
+func (s *TernaryFissionAPIServer) handleScrapeTargets(w http.ResponseWriter, r *http.Request) {
+	endpoints := s.reactorEndpoints()
+	targets := make([]ScrapeTarget, 0, len(endpoints))
+
+	for _, endpoint := range endpoints {
+		host := endpoint
+		if parsed, err := url.Parse(endpoint); err == nil && parsed.Host != "" {
+			host = parsed.Host
+		}
+		targets = append(targets, ScrapeTarget{
+			Targets: []string{host},
+			Labels: map[string]string{
+				"job":      "ternary_fission_reactor",
+				"instance": host,
+			},
+		})
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, targets)
+}
+
+// We return the configured reactor endpoints, falling back to the single
+// ReactorBaseURL when no explicit list was provided.
+func (s *TernaryFissionAPIServer) reactorEndpoints() []string {
+	if len(s.config.ReactorEndpoints) > 0 {
+		return s.config.ReactorEndpoints
+	}
+	return []string{s.config.ReactorBaseURL}
+}
+
+// =============================================================================
+// REMOTE WRITE PUSH
+// =============================================================================
+
+// remoteWriteLabel and remoteWriteSample mirror prompb.Label / prompb.Sample
+// closely enough to round-trip through a real Prometheus remote_write receiver,
+// without requiring the prometheus/prometheus module purely for its generated types.
+type remoteWriteLabel struct {
+	Name  string
+	Value string
+}
+
+type remoteWriteSample struct {
+	Value     float64
+	Timestamp int64 // milliseconds since epoch, per the remote_write spec
+}
+
+type remoteWriteSeries struct {
+	Labels  []remoteWriteLabel
+	Samples []remoteWriteSample
+}
+
+// marshalLabel encodes a prompb.Label: field 1 = name, field 2 = value
+func marshalLabel(label remoteWriteLabel) []byte {
+	var buf []byte
+	buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+	buf = protowire.AppendString(buf, label.Name)
+	buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+	buf = protowire.AppendString(buf, label.Value)
+	return buf
+}
+
+// marshalSample encodes a prompb.Sample: field 1 = value (double), field 2 = timestamp (int64)
+func marshalSample(sample remoteWriteSample) []byte {
+	var buf []byte
+	buf = protowire.AppendTag(buf, 1, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(sample.Value))
+	buf = protowire.AppendTag(buf, 2, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, uint64(sample.Timestamp))
+	return buf
+}
+
+// marshalTimeSeries encodes a prompb.TimeSeries: field 1 = repeated labels, field 2 = repeated samples
+func marshalTimeSeries(series remoteWriteSeries) []byte {
+	var buf []byte
+	for _, label := range series.Labels {
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, marshalLabel(label))
+	}
+	for _, sample := range series.Samples {
+		buf = protowire.AppendTag(buf, 2, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, marshalSample(sample))
+	}
+	return buf
+}
+
+// marshalWriteRequest encodes a prompb.WriteRequest: field 1 = repeated timeseries
+func marshalWriteRequest(series []remoteWriteSeries) []byte {
+	var buf []byte
+	for _, s := range series {
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, marshalTimeSeries(s))
+	}
+	return buf
+}
+
+// We build one time series per tracked gauge, labeled the same way the
+// Prometheus /api/v1/metrics scrape would expose them, and push them to the
+// configured remote_write endpoint.
+func (s *TernaryFissionAPIServer) pushRemoteWrite(activeFields float64, totalEnergy float64) {
+	if s.config.RemoteWriteURL == "" {
+		return
+	}
+
+	now := time.Now().UnixMilli()
+	series := []remoteWriteSeries{
+		{
+			Labels: []remoteWriteLabel{
+				{Name: "__name__", Value: "reactor_active_fields"},
+				{Name: "job", Value: "ternary_fission_reactor"},
+			},
+			Samples: []remoteWriteSample{{Value: activeFields, Timestamp: now}},
+		},
+		{
+			Labels: []remoteWriteLabel{
+				{Name: "__name__", Value: "reactor_total_energy_mev"},
+				{Name: "job", Value: "ternary_fission_reactor"},
+			},
+			Samples: []remoteWriteSample{{Value: totalEnergy, Timestamp: now}},
+		},
+	}
+
+	payload := marshalWriteRequest(series)
+	compressed := snappy.Encode(nil, payload)
+
+	req, err := http.NewRequest(http.MethodPost, s.config.RemoteWriteURL, bytes.NewReader(compressed))
+	if err != nil {
+		s.logger.Error("remote_write: failed to build request", logging.Fields{"error": err.Error()})
+		return
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := s.remoteWriteClient.Do(req)
+	if err != nil {
+		s.logger.Error("remote_write: push failed", logging.Fields{"error": err.Error()})
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		s.logger.Error("remote_write: push rejected", logging.Fields{"status": resp.StatusCode})
+	}
+}
+
+// We accept a comma-separated list of reactor endpoints from the config file,
+// trimming whitespace around each entry (mirrors parseConfigFile's style).
+func parseReactorEndpoints(value string) []string {
+	var endpoints []string
+	for _, part := range strings.Split(value, ",") {
+		trimmed := strings.TrimSpace(part)
+		if trimmed != "" {
+			endpoints = append(endpoints, trimmed)
+		}
+	}
+	return endpoints
+}
@@ -0,0 +1,707 @@
+/*
+ * File: src/go/api.ternary.fission.monitor.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Multi-transport real-time monitor
+ * Purpose: Exposes a single reactor-status stream over three transports - native WebSocket,
+ *          Server-Sent Events, and HTTP long-polling - so the dashboard keeps working behind
+ *          proxies that block WebSocket upgrades
+ * Reason: handleWebSocketConnection used to poll the reactor itself; MonitorTransportNegotiator
+ *         centralizes that poll into one goroutine and fans ticks out to every connected
+ *         client regardless of transport
+ *
+ * Change Log:
+ * 2025-08-09: Initial MonitorTransportNegotiator (WebSocket, SSE at /api/v1/monitor/sse with
+ *             Last-Event-ID resume, long-poll at /api/v1/monitor/poll?cursor=N)
+ * 2025-08-09: Replaced the WebSocket transport's flattened wsStatusMessage/fragmentBurstMessage
+ *             push with a typed ternary-monitor-v1 subprotocol - connection_init/ack,
+ *             ping/pong with idle-timeout (4499), topic-filtered subscribe, and since-based
+ *             replay using a new SystemStatusResponse.TimestampUnix field, inspired by
+ *             graphql-ws's lifecycle (see api.ternary.fission.graphql.go)
+ * 2025-08-09: publish() now also republishes every tick onto the new events.BufferedSubscription
+ *             bus (events.KindReactorStatus) backing GET /api/v1/events
+ *             (api.ternary.fission.events.go), so that transport sees the same status stream
+ *
+ * Carry-over Context:
+ * - The negotiator retains a bounded in-memory history (monitorHistoryCapacity snapshots) for
+ *   resume; a client whose cursor has aged out of that window is resynced to the latest
+ *   snapshot rather than served a gap, mirroring how the decay tracker and TSDB store are
+ *   bounded in-memory stores elsewhere in this server
+ * - monitorCloseUnauthorized/monitorCloseForbidden are defined for parity with the dashboard
+ *   client's shouldRetry fatal-code list even though this handler has no path that emits them
+ *   yet, since /api/v1/ws/monitor's auth already happens pre-upgrade via requireRole
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"ternary-fission/events"
+	"ternary-fission/logging"
+)
+
+// monitorHistoryCapacity bounds how many past snapshots the negotiator retains for
+// SSE Last-Event-ID and long-poll cursor resume.
+const monitorHistoryCapacity = 64
+
+// monitorDefaultPollIntervalSeconds is used when Config.WebSocketPingInterval is unset
+// (e.g. a Config built directly in tests without defaultConfig()'s 30-second default).
+const monitorDefaultPollIntervalSeconds = 30
+
+// monitorSnapshot pairs a polled status with the monotonically increasing cursor the
+// SSE/long-poll transports use for resume.
+type monitorSnapshot struct {
+	Cursor uint64
+	Status SystemStatusResponse
+}
+
+// MonitorTransportNegotiator owns the single reactor-polling goroutine backing all three
+// monitor transports and fans its ticks out to every connected client.
+type MonitorTransportNegotiator struct {
+	server *TernaryFissionAPIServer
+
+	mu      sync.Mutex
+	nextID  uint64
+	clients map[uint64]chan monitorSnapshot
+
+	historyMu sync.Mutex
+	cursor    uint64
+	history   []monitorSnapshot
+}
+
+// NewMonitorTransportNegotiator starts the reactor-polling goroutine and returns the
+// negotiator; the goroutine runs for the server's lifetime, not just while clients are
+// connected, so a newly-subscribing client never has to wait out a cold start.
+func NewMonitorTransportNegotiator(server *TernaryFissionAPIServer) *MonitorTransportNegotiator {
+	n := &MonitorTransportNegotiator{
+		server:  server,
+		clients: make(map[uint64]chan monitorSnapshot),
+	}
+	go n.run()
+	return n
+}
+
+// run polls the reactor on WebSocketPingInterval and publishes each tick until the server
+// shuts down. When Config.ReactorTransport is "grpc", it instead lets the reactor push ticks
+// over the ReactorTransport service's SubscribeEvents stream (see grpcEventStreamer below).
+func (n *MonitorTransportNegotiator) run() {
+	if streamer, ok := n.server.reactorClient.(grpcEventStreamer); ok {
+		streamer.streamStatusEvents(n.server.ctx, n.publish)
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(monitorPollIntervalSeconds(n.server.config)) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			resp, err := n.server.reactorClient.Get(fmt.Sprintf("%s/api/v1/status", n.server.config.ReactorBaseURL))
+			if err != nil {
+				n.server.logger.Error("Monitor status fetch failed", logging.Fields{"error": err.Error()})
+				continue
+			}
+			var status SystemStatusResponse
+			decodeErr := json.NewDecoder(resp.Body).Decode(&status)
+			resp.Body.Close()
+			if decodeErr != nil {
+				n.server.logger.Error("Monitor status decode failed", logging.Fields{"error": decodeErr.Error()})
+				continue
+			}
+			status.TimestampUnix = time.Now().Unix()
+			n.publish(status)
+		case <-n.server.ctx.Done():
+			return
+		}
+	}
+}
+
+// grpcEventStreamer is implemented by *grpcReactorClient; run() type-asserts
+// server.reactorClient against it so the gRPC transport can push ticks instead of being polled,
+// without MonitorTransportNegotiator needing to know about pb.ReactorTransportClient at all.
+type grpcEventStreamer interface {
+	streamStatusEvents(ctx context.Context, onStatus func(SystemStatusResponse))
+}
+
+// publish appends status to the bounded history and fans it out to every subscribed client,
+// dropping the tick for any client whose buffer is full rather than blocking the poller.
+func (n *MonitorTransportNegotiator) publish(status SystemStatusResponse) {
+	n.historyMu.Lock()
+	n.cursor++
+	snapshot := monitorSnapshot{Cursor: n.cursor, Status: status}
+	n.history = append(n.history, snapshot)
+	if len(n.history) > monitorHistoryCapacity {
+		n.history = n.history[len(n.history)-monitorHistoryCapacity:]
+	}
+	n.historyMu.Unlock()
+
+	n.mu.Lock()
+	for _, ch := range n.clients {
+		select {
+		case ch <- snapshot:
+		default:
+		}
+	}
+	n.mu.Unlock()
+
+	// We also republish every tick onto the general event bus so GET /api/v1/events
+	// subscribers see the same reactor status stream as the WebSocket/SSE/long-poll
+	// transports, without the two buffers ever disagreeing on what a "status" event is.
+	n.server.events.Publish(events.KindReactorStatus, status)
+}
+
+// subscribe registers a new client feed and returns an unsubscribe func the caller must
+// defer immediately.
+func (n *MonitorTransportNegotiator) subscribe() (uint64, <-chan monitorSnapshot, func()) {
+	n.mu.Lock()
+	id := n.nextID
+	n.nextID++
+	ch := make(chan monitorSnapshot, 8)
+	n.clients[id] = ch
+	n.mu.Unlock()
+
+	unsubscribe := func() {
+		n.mu.Lock()
+		delete(n.clients, id)
+		n.mu.Unlock()
+	}
+	return id, ch, unsubscribe
+}
+
+// since returns every retained snapshot with a cursor greater than cursor, or ok=false if
+// cursor predates the retained history and the caller should resync to the latest instead.
+func (n *MonitorTransportNegotiator) since(cursor uint64) ([]monitorSnapshot, bool) {
+	n.historyMu.Lock()
+	defer n.historyMu.Unlock()
+
+	if len(n.history) == 0 {
+		return nil, cursor == 0
+	}
+	if cursor < n.history[0].Cursor-1 {
+		return nil, false
+	}
+
+	var out []monitorSnapshot
+	for _, snap := range n.history {
+		if snap.Cursor > cursor {
+			out = append(out, snap)
+		}
+	}
+	return out, true
+}
+
+// sinceTimestamp returns every retained snapshot whose status timestamp is strictly after
+// unixSeconds, or ok=false if unixSeconds predates the retained history and the caller should
+// resync to the latest instead; this mirrors since(cursor) for a ternary-monitor-v1 client,
+// which only knows the last status.timestamp_unix it received, not our internal cursor.
+func (n *MonitorTransportNegotiator) sinceTimestamp(unixSeconds int64) ([]monitorSnapshot, bool) {
+	n.historyMu.Lock()
+	defer n.historyMu.Unlock()
+
+	if len(n.history) == 0 {
+		return nil, unixSeconds == 0
+	}
+	if unixSeconds < n.history[0].Status.TimestampUnix {
+		return nil, false
+	}
+
+	var out []monitorSnapshot
+	for _, snap := range n.history {
+		if snap.Status.TimestampUnix > unixSeconds {
+			out = append(out, snap)
+		}
+	}
+	return out, true
+}
+
+// latestCursor returns the most recent published cursor, or 0 if nothing has been
+// published yet.
+func (n *MonitorTransportNegotiator) latestCursor() uint64 {
+	n.historyMu.Lock()
+	defer n.historyMu.Unlock()
+	return n.cursor
+}
+
+// monitorPollIntervalSeconds falls back to monitorDefaultPollIntervalSeconds when the
+// configured interval is non-positive, so a Config built without defaultConfig() (as in
+// tests) doesn't hand time.NewTicker an invalid duration.
+func monitorPollIntervalSeconds(config *Config) int {
+	if config.WebSocketPingInterval <= 0 {
+		return monitorDefaultPollIntervalSeconds
+	}
+	return config.WebSocketPingInterval
+}
+
+// =============================================================================
+// TRANSPORT 1: NATIVE WEBSOCKET (/ws/monitor), ternary-monitor-v1 subprotocol
+// =============================================================================
+
+// monitorWSSubprotocol is the Sec-WebSocket-Protocol this server negotiates for /ws/monitor,
+// a small typed connection lifecycle inspired by graphql-ws (see
+// api.ternary.fission.graphql.go's graphQLWSSubprotocol for the sibling transport this mirrors).
+const monitorWSSubprotocol = "ternary-monitor-v1"
+
+// ternary-monitor-v1 message types.
+const (
+	monitorMsgConnectionInit = "connection_init"
+	monitorMsgConnectionAck  = "connection_ack"
+	monitorMsgPing           = "ping"
+	monitorMsgPong           = "pong"
+	monitorMsgSubscribe      = "subscribe"
+	monitorMsgStatus         = "status"
+	monitorMsgFragmentBurst  = "fragment_burst"
+	monitorMsgComplete       = "complete"
+)
+
+// Server-side close codes. 4400/4401/4403/4408/4429 mirror the graphql-transport-ws codes
+// api.ternary.fission.graphql.go already uses for the sibling /graphql transport, so a client
+// implementing both can treat them the same way; 4499 is this transport's own, for a
+// connection that stopped answering pings.
+const (
+	monitorCloseBadRequest            = 4400
+	monitorCloseUnauthorized          = 4401
+	monitorCloseForbidden             = 4403
+	monitorCloseConnectionInitTimeout = 4408
+	monitorCloseTooManyRequests       = 4429
+	monitorCloseIdleTimeout           = 4499
+)
+
+// monitorCloseDeadline bounds how long we wait for a close frame to flush before giving up.
+const monitorCloseDeadline = 5 * time.Second
+
+// monitorWSMessage is the envelope every ternary-monitor-v1 frame uses.
+type monitorWSMessage struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// monitorConnectionInitPayload is a connection_init message's payload; Since lets a
+// reconnecting client request replay of any snapshots published after the last
+// status.timestamp_unix it received.
+type monitorConnectionInitPayload struct {
+	Since int64 `json:"since,omitempty"`
+}
+
+// monitorSubscribePayload is a subscribe message's payload, filtering which topics the
+// server pushes on this connection.
+type monitorSubscribePayload struct {
+	Topics []string `json:"topics"`
+}
+
+// monitorDefaultTopics is what a connection receives before any subscribe message narrows it;
+// "portal" is accepted for forward compatibility but nothing is pushed under it yet.
+var monitorDefaultTopics = []string{"status", "fields", "portal"}
+
+// monitorWSConnection holds the per-connection state for one /ws/monitor WebSocket.
+type monitorWSConnection struct {
+	server *TernaryFissionAPIServer
+	conn   *websocket.Conn
+
+	writeMu sync.Mutex
+
+	topicsMu sync.Mutex
+	topics   map[string]bool
+
+	pongMu   sync.Mutex
+	lastPong time.Time
+
+	// previousEvents/previousEnergy/haveBaseline are only ever touched by the single
+	// goroutine running pushLoop/replaySince, so they need no lock of their own.
+	previousEvents uint64
+	previousEnergy float64
+	haveBaseline   bool
+}
+
+func (c *monitorWSConnection) writeMessage(msgType string, payload interface{}) error {
+	var raw json.RawMessage
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		raw = encoded
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteJSON(monitorWSMessage{Type: msgType, Payload: raw})
+}
+
+func (c *monitorWSConnection) closeWithCode(code int, reason string) {
+	c.writeMu.Lock()
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(monitorCloseDeadline))
+	c.writeMu.Unlock()
+}
+
+// topicEnabled reports whether topic is currently subscribed.
+func (c *monitorWSConnection) topicEnabled(topic string) bool {
+	c.topicsMu.Lock()
+	defer c.topicsMu.Unlock()
+	return c.topics[topic]
+}
+
+func (c *monitorWSConnection) setTopics(topics []string) {
+	enabled := make(map[string]bool, len(topics))
+	for _, topic := range topics {
+		enabled[topic] = true
+	}
+	c.topicsMu.Lock()
+	c.topics = enabled
+	c.topicsMu.Unlock()
+}
+
+func (c *monitorWSConnection) markPong() {
+	c.pongMu.Lock()
+	c.lastPong = time.Now()
+	c.pongMu.Unlock()
+}
+
+func (c *monitorWSConnection) idleSince() time.Duration {
+	c.pongMu.Lock()
+	defer c.pongMu.Unlock()
+	return time.Since(c.lastPong)
+}
+
+// pushSnapshot sends a status message (if the "status" topic is enabled) and, when new fission
+// events have occurred since the last tick, a fragment_burst (if "fields" is enabled);
+// amortizing the interval's energy across them exactly as the prior unversioned handler did.
+func (c *monitorWSConnection) pushSnapshot(status SystemStatusResponse) error {
+	if c.topicEnabled("status") {
+		if err := c.writeMessage(monitorMsgStatus, status); err != nil {
+			return err
+		}
+	}
+
+	if c.haveBaseline && c.topicEnabled("fields") {
+		deltaEvents := status.TotalFissionEvents - c.previousEvents
+		deltaEnergy := status.TotalEnergySimulated - c.previousEnergy
+		if deltaEvents > 0 && deltaEnergy > 0 {
+			parentZ, parentMass := c.server.parentNucleusZA()
+			burst := computeFragmentBurst(status.TotalFissionEvents, deltaEnergy/float64(deltaEvents), parentMass, parentZ)
+			if err := c.writeMessage(monitorMsgFragmentBurst, burst); err != nil {
+				return err
+			}
+		}
+	}
+	c.previousEvents = status.TotalFissionEvents
+	c.previousEnergy = status.TotalEnergySimulated
+	c.haveBaseline = true
+	return nil
+}
+
+// runKeepalive sends a server-initiated ping on WebSocketPingInterval and closes the
+// connection with 4499 if no pong (client- or server-initiated) has been seen within
+// WebSocketTimeout, so a peer that stopped answering doesn't hold the socket open forever.
+func (c *monitorWSConnection) runKeepalive(ctx context.Context) {
+	interval := time.Duration(c.server.config.WebSocketPingInterval) * time.Second
+	if interval <= 0 {
+		return
+	}
+	idleTimeout := time.Duration(c.server.config.WebSocketTimeout) * time.Second
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if idleTimeout > 0 && c.idleSince() > idleTimeout {
+				c.closeWithCode(monitorCloseIdleTimeout, "Idle timeout: no pong received")
+				c.conn.Close()
+				return
+			}
+			if err := c.writeMessage(monitorMsgPing, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// replaySince pushes every retained snapshot published after sinceUnix, if any, before the
+// live push loop starts, so a reconnecting client doesn't miss what it was disconnected for.
+func (c *monitorWSConnection) replaySince(sinceUnix int64) error {
+	if sinceUnix <= 0 {
+		return nil
+	}
+	backlog, ok := c.server.monitor.sinceTimestamp(sinceUnix)
+	if !ok {
+		return nil
+	}
+	for _, snapshot := range backlog {
+		if err := c.pushSnapshot(snapshot.Status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForConnectionInit blocks, within the read deadline serve already set, for the
+// handshake's connection_init frame, closing with 4408 on timeout or 4400 on a malformed
+// first frame.
+func (c *monitorWSConnection) waitForConnectionInit() (monitorConnectionInitPayload, error) {
+	var msg monitorWSMessage
+	if err := c.conn.ReadJSON(&msg); err != nil {
+		c.closeWithCode(monitorCloseConnectionInitTimeout, "Connection initialisation timeout")
+		return monitorConnectionInitPayload{}, err
+	}
+	if msg.Type != monitorMsgConnectionInit {
+		c.closeWithCode(monitorCloseBadRequest, "Bad request: expected connection_init")
+		return monitorConnectionInitPayload{}, fmt.Errorf("unexpected first message type %q", msg.Type)
+	}
+
+	var payload monitorConnectionInitPayload
+	if len(msg.Payload) > 0 {
+		if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+			c.closeWithCode(monitorCloseBadRequest, "Bad request: invalid connection_init payload")
+			return monitorConnectionInitPayload{}, err
+		}
+	}
+	return payload, nil
+}
+
+// pushLoop feeds status/fragment_burst ticks from feed until ctx is cancelled or a write fails.
+func (c *monitorWSConnection) pushLoop(ctx context.Context, feed <-chan monitorSnapshot) {
+	for {
+		select {
+		case snapshot := <-feed:
+			if err := c.pushSnapshot(snapshot.Status); err != nil {
+				c.server.logger.Error("Monitor WebSocket write failed", logging.Fields{"error": err.Error()})
+				c.conn.Close()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// readLoop handles client-initiated ping/pong/subscribe/complete frames until the connection
+// closes; it owns the connection's only Read call, as gorilla/websocket requires.
+func (c *monitorWSConnection) readLoop() {
+	for {
+		var msg monitorWSMessage
+		if err := c.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case monitorMsgPing:
+			c.markPong()
+			if err := c.writeMessage(monitorMsgPong, nil); err != nil {
+				return
+			}
+		case monitorMsgPong:
+			c.markPong()
+		case monitorMsgSubscribe:
+			var payload monitorSubscribePayload
+			if err := json.Unmarshal(msg.Payload, &payload); err != nil || len(payload.Topics) == 0 {
+				c.closeWithCode(monitorCloseBadRequest, "Bad request: invalid subscribe payload")
+				return
+			}
+			c.setTopics(payload.Topics)
+		case monitorMsgComplete:
+			return
+		case monitorMsgConnectionInit:
+			c.closeWithCode(monitorCloseTooManyRequests, "Too many initialisation requests")
+			return
+		default:
+			c.closeWithCode(monitorCloseBadRequest, fmt.Sprintf("Bad request: unknown message type %q", msg.Type))
+			return
+		}
+	}
+}
+
+// serve runs the ternary-monitor-v1 state machine for one connection: it waits for
+// connection_init, acks, replays any requested backlog, then hands off to a push goroutine
+// (status/fragment_burst ticks plus keepalive pings) while reading control frames itself.
+func (c *monitorWSConnection) serve(ctx context.Context) {
+	connCtx, cancelConn := context.WithCancel(ctx)
+	defer cancelConn()
+
+	ackTimeout := time.Duration(c.server.config.MonitorConnectionAckWaitTimeoutSeconds) * time.Second
+	c.conn.SetReadDeadline(time.Now().Add(ackTimeout))
+
+	initPayload, err := c.waitForConnectionInit()
+	if err != nil {
+		return
+	}
+	c.conn.SetReadDeadline(time.Time{})
+	c.markPong()
+	c.setTopics(monitorDefaultTopics)
+
+	if err := c.writeMessage(monitorMsgConnectionAck, nil); err != nil {
+		return
+	}
+	if err := c.replaySince(initPayload.Since); err != nil {
+		return
+	}
+
+	_, feed, unsubscribe := c.server.monitor.subscribe()
+	defer unsubscribe()
+
+	go c.runKeepalive(connCtx)
+	go c.pushLoop(connCtx, feed)
+
+	c.readLoop()
+}
+
+// handleWebSocketConnection upgrades to the ternary-monitor-v1 subprotocol and runs the
+// connection's state machine.
+func (s *TernaryFissionAPIServer) handleWebSocketConnection(w http.ResponseWriter, r *http.Request) {
+	upgrader := s.websocketUpgrader
+	upgrader.Subprotocols = []string{monitorWSSubprotocol}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Error("WebSocket upgrade failed", logging.Fields{"error": err.Error()})
+		return
+	}
+	defer conn.Close()
+
+	if conn.Subprotocol() != monitorWSSubprotocol {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(monitorCloseBadRequest, "expected ternary-monitor-v1 subprotocol"), time.Now().Add(monitorCloseDeadline))
+		return
+	}
+
+	s.logger.Info("WebSocket client connected", logging.Fields{"remote_addr": r.RemoteAddr})
+
+	wsConn := &monitorWSConnection{server: s, conn: conn}
+	wsConn.serve(s.ctx)
+}
+
+// =============================================================================
+// TRANSPORT 2: SERVER-SENT EVENTS (/api/v1/monitor/sse)
+// =============================================================================
+
+// handleMonitorSSE streams status as "event: status" SSE frames, replaying any snapshots
+// since the client's Last-Event-ID before switching to live ticks.
+func (s *TernaryFissionAPIServer) handleMonitorSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeErrorResponse(w, http.StatusInternalServerError, "Streaming unsupported")
+		return
+	}
+
+	var resumeCursor uint64
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		if parsed, err := strconv.ParseUint(lastEventID, 10, 64); err == nil {
+			resumeCursor = parsed
+		}
+	}
+
+	// We subscribe before replaying the backlog so no tick published in between is lost.
+	_, feed, unsubscribe := s.monitor.subscribe()
+	defer unsubscribe()
+
+	backlog, ok := s.monitor.since(resumeCursor)
+	if !ok {
+		backlog = nil
+		resumeCursor = s.monitor.latestCursor()
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	lastSentCursor := resumeCursor
+	for _, snapshot := range backlog {
+		if err := writeMonitorSSEEvent(w, snapshot); err != nil {
+			return
+		}
+		lastSentCursor = snapshot.Cursor
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case snapshot := <-feed:
+			if snapshot.Cursor <= lastSentCursor {
+				continue
+			}
+			if err := writeMonitorSSEEvent(w, snapshot); err != nil {
+				return
+			}
+			lastSentCursor = snapshot.Cursor
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func writeMonitorSSEEvent(w http.ResponseWriter, snapshot monitorSnapshot) error {
+	payload, err := json.Marshal(snapshot.Status)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: status\ndata: %s\n\n", snapshot.Cursor, payload)
+	return err
+}
+
+// =============================================================================
+// TRANSPORT 3: HTTP LONG-POLLING (/api/v1/monitor/poll)
+// =============================================================================
+
+// monitorPollResponse is a long-poll response body: the cursor the caller should pass back
+// as ?cursor= on its next request, and the new status if one arrived before the timeout.
+type monitorPollResponse struct {
+	NextCursor uint64                `json:"next_cursor"`
+	Status     *SystemStatusResponse `json:"status,omitempty"`
+}
+
+// handleMonitorLongPoll blocks up to WebSocketPingInterval seconds for the next status
+// snapshot after ?cursor=N, then responds with that snapshot or an unchanged cursor.
+func (s *TernaryFissionAPIServer) handleMonitorLongPoll(w http.ResponseWriter, r *http.Request) {
+	var cursor uint64
+	if raw := r.URL.Query().Get("cursor"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		cursor = parsed
+	}
+
+	backlog, ok := s.monitor.since(cursor)
+	if !ok {
+		// The cursor predates our retained history; resync to the latest snapshot rather
+		// than replaying data we can no longer guarantee is complete.
+		cursor = s.monitor.latestCursor()
+		backlog = nil
+	}
+	if len(backlog) > 0 {
+		s.writeMonitorPollResponse(w, backlog[0])
+		return
+	}
+
+	_, feed, unsubscribe := s.monitor.subscribe()
+	defer unsubscribe()
+
+	timeout := time.Duration(monitorPollIntervalSeconds(s.config)) * time.Second
+	select {
+	case snapshot := <-feed:
+		s.writeMonitorPollResponse(w, snapshot)
+	case <-time.After(timeout):
+		s.writeJSONResponse(w, http.StatusOK, monitorPollResponse{NextCursor: cursor})
+	case <-r.Context().Done():
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *TernaryFissionAPIServer) writeMonitorPollResponse(w http.ResponseWriter, snapshot monitorSnapshot) {
+	status := snapshot.Status
+	s.writeJSONResponse(w, http.StatusOK, monitorPollResponse{NextCursor: snapshot.Cursor, Status: &status})
+}
@@ -0,0 +1,336 @@
+/*
+ * File: src/go/api.ternary.fission.montecarlo.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Variational Monte Carlo Local-Energy Estimator for Energy Fields
+ * Purpose: Replaces the purely phenomenological E(t) = E0*e^(-lambda*t) dissipation curve's
+ *          lack of any convergence signal with a real per-field statistical estimate: sample
+ *          walkers in a configurable potential under a pluggable trial wavefunction, evaluate
+ *          the VMC local energy E_L(r) = (H*psi)/psi, and accumulate its Metropolis-weighted
+ *          mean and variance
+ * Reason: POST /api/v1/energy-fields let an operator name a physics list but had no way to
+ *         attach a trial wavefunction or see how well it was converging; "dissipation rounds"
+ *         was an arbitrary knob with no statistical backing
+ *
+ * Change Log:
+ * 2025-08-09: Initial TrialWavefunction interface (hydrogenic_1s, slater_jastrow), a
+ *             Coulomb + Woods-Saxon default potential keyed off the active parent nucleus's
+ *             Z, a finite-difference local-energy estimator, and a per-field
+ *             LocalEnergyRegistry sampled on each POST .../dissipate round
+ *
+ * Carry-over Context:
+ * - The real field state (energy_mev, dissipation_rate, stability_factor, ...) lives in the
+ *   external reactor engine, which does not ship in this repo snapshot; this estimator is a
+ *   client-side statistical overlay keyed by field_id, not a replacement for the reactor's
+ *   own dissipation model. If the server restarts, a field created before the restart has no
+ *   registered estimator until its creator re-issues POST .../dissipate after the field is
+ *   recreated - Sample() reports ok=false rather than fabricating a result
+ * - variance is a real convergence indicator; a natural follow-up is letting
+ *   EnergyFieldRequest specify a target standard error and looping Sample() internally until
+ *   it is reached, instead of leaving "how many dissipation rounds" to the caller
+ */
+
+package main
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+)
+
+// =============================================================================
+// TRIAL WAVEFUNCTIONS
+// =============================================================================
+
+// TrialWavefunction is a pluggable psi_T(r) a POST /api/v1/energy-fields request can name via
+// trial_wavefunction; the estimator computes its local energy by finite difference, so adding
+// a new trial wavefunction only means implementing Psi.
+type TrialWavefunction interface {
+	Name() string
+	Psi(r float64) float64
+}
+
+// bohrRadiusFm is the trial wavefunctions' default length scale, expressed in femtometers
+// since the estimator otherwise works in nuclear (MeV, fm) units.
+const bohrRadiusFm = 1.2
+
+// hydrogenicS1 is the textbook exp(-r/a0) ground-state ansatz, the simplest non-trivial trial
+// wavefunction and the default when a request omits trial_wavefunction.
+type hydrogenicS1 struct {
+	bohrRadiusFm float64
+}
+
+func (w hydrogenicS1) Name() string { return "hydrogenic_1s" }
+
+func (w hydrogenicS1) Psi(r float64) float64 {
+	return math.Exp(-r / w.bohrRadiusFm)
+}
+
+// slaterJastrow layers a Jastrow correlation factor exp(f*r/(1+b*r)) over the hydrogenic
+// ansatz, the standard way VMC trial wavefunctions capture correlation a single Slater
+// determinant cannot.
+type slaterJastrow struct {
+	bohrRadiusFm float64
+	jastrowF     float64
+	jastrowB     float64
+}
+
+func (w slaterJastrow) Name() string { return "slater_jastrow" }
+
+func (w slaterJastrow) Psi(r float64) float64 {
+	correlation := w.jastrowF * r / (1 + w.jastrowB*r)
+	return math.Exp(-r/w.bohrRadiusFm) * math.Exp(correlation)
+}
+
+// defaultTrialWavefunctionName is used when a request's trial_wavefunction is empty or names
+// an unregistered wavefunction.
+const defaultTrialWavefunctionName = "hydrogenic_1s"
+
+// trialWavefunctionFactories maps the names a request can reference to constructors, mirroring
+// physicsProcessFactories in api.ternary.fission.physics.go.
+var trialWavefunctionFactories = map[string]func() TrialWavefunction{
+	"hydrogenic_1s": func() TrialWavefunction { return hydrogenicS1{bohrRadiusFm: bohrRadiusFm} },
+	"slater_jastrow": func() TrialWavefunction {
+		return slaterJastrow{bohrRadiusFm: bohrRadiusFm, jastrowF: 0.3, jastrowB: 0.5}
+	},
+}
+
+// resolveTrialWavefunction looks up name, falling back to defaultTrialWavefunctionName if name
+// is empty or unrecognized so a malformed request never blocks field creation.
+func resolveTrialWavefunction(name string) TrialWavefunction {
+	if factory, ok := trialWavefunctionFactories[name]; ok {
+		return factory()
+	}
+	return trialWavefunctionFactories[defaultTrialWavefunctionName]()
+}
+
+// =============================================================================
+// POTENTIAL
+// =============================================================================
+
+// PotentialFunc is the configurable V(r) (in MeV) the local energy is evaluated against.
+type PotentialFunc func(r float64) float64
+
+// Woods-Saxon well parameters for the default potential: depth in MeV, half-density radius
+// and surface diffuseness in fm, the conventional nuclear mean-field shape.
+const (
+	woodsSaxonDepthMeV  = 50.0
+	woodsSaxonRadiusFm  = 7.0
+	woodsSaxonDiffuseFm = 0.6
+	fineStructureConst  = 1.0 / 137.036
+	hbarCMeVFm          = 197.327
+)
+
+// defaultEnergyFieldPotential builds the Coulomb + Woods-Saxon potential this estimator uses
+// unless a future request-scoped override is added: an attractive nuclear well plus the
+// repulsive Coulomb term for a daughter charge of 1 relative to parentZ, evaluated at the
+// active parent nucleus's proton number so a heavier actinide sees a deeper Coulomb barrier.
+func defaultEnergyFieldPotential(parentZ float64) PotentialFunc {
+	return func(r float64) float64 {
+		if r <= 0 {
+			r = 1e-6
+		}
+		coulomb := fineStructureConst * hbarCMeVFm * parentZ / r
+		woodsSaxon := -woodsSaxonDepthMeV / (1 + math.Exp((r-woodsSaxonRadiusFm)/woodsSaxonDiffuseFm))
+		return coulomb + woodsSaxon
+	}
+}
+
+// =============================================================================
+// LOCAL-ENERGY ESTIMATOR
+// =============================================================================
+
+// localEnergyFiniteDiffStep is the radial step h used to finite-difference psi's first and
+// second derivatives; small enough to resolve the femtometer-scale potential without the
+// round-off blowup of an overly small h.
+const localEnergyFiniteDiffStep = 1e-3
+
+// hbarSqOverTwoNucleonMassMeVFm2 is hbar^2/(2m) for a nucleon (m c^2 = 939 MeV), in MeV*fm^2,
+// the kinetic-energy prefactor of the local-energy Laplacian term.
+const hbarSqOverTwoNucleonMassMeVFm2 = hbarCMeVFm * hbarCMeVFm / (2 * 939.0)
+
+// localEnergy evaluates E_L(r) = -[hbar^2/2m] * (d2psi/dr2 + 2/r * dpsi/dr) / psi + V(r) for a
+// spherically symmetric trial wavefunction, differentiating psi by central finite difference
+// rather than requiring each TrialWavefunction to supply an analytic Laplacian.
+func localEnergy(psi TrialWavefunction, potential PotentialFunc, r float64) float64 {
+	if r <= 0 {
+		r = localEnergyFiniteDiffStep
+	}
+	h := localEnergyFiniteDiffStep
+	psiHere := psi.Psi(r)
+	psiPlus := psi.Psi(r + h)
+	psiMinus := psi.Psi(r - h)
+
+	firstDeriv := (psiPlus - psiMinus) / (2 * h)
+	secondDeriv := (psiPlus - 2*psiHere + psiMinus) / (h * h)
+	laplacianOverPsi := (secondDeriv + (2/r)*firstDeriv) / psiHere
+
+	kinetic := -hbarSqOverTwoNucleonMassMeVFm2 * laplacianOverPsi
+	return kinetic + potential(r)
+}
+
+// LocalEnergyEstimate is the Metropolis-weighted mean/variance of E_L(r), reported on each
+// POST .../dissipate round.
+type LocalEnergyEstimate struct {
+	MeanMeV         float64
+	Variance        float64
+	AcceptanceRatio float64
+	NWalkers        int
+}
+
+// Default Metropolis-Hastings sampling parameters; overridable per estimator via
+// NewLocalEnergyEstimator for tests, but every production field uses these.
+const (
+	defaultWalkerCount       = 32
+	defaultBurnInSteps       = 200
+	defaultSamplesPerWalker  = 100
+	defaultMetropolisStepStd = 0.5 // fm
+)
+
+// LocalEnergyEstimator runs standard Metropolis-Hastings VMC sampling for one field: propose
+// r' = r + sigma*N(0,1), accept with probability min(1, |psi(r')/psi(r)|^2), discard burn-in,
+// then average E_L over the remaining samples.
+type LocalEnergyEstimator struct {
+	wavefunction     TrialWavefunction
+	potential        PotentialFunc
+	walkerCount      int
+	burnInSteps      int
+	samplesPerWalker int
+	stepStd          float64
+	rng              *rand.Rand
+}
+
+// NewLocalEnergyEstimator builds an estimator for wavefunction under potential, using this
+// repo's default walker/sample counts.
+func NewLocalEnergyEstimator(wavefunction TrialWavefunction, potential PotentialFunc) *LocalEnergyEstimator {
+	return &LocalEnergyEstimator{
+		wavefunction:     wavefunction,
+		potential:        potential,
+		walkerCount:      defaultWalkerCount,
+		burnInSteps:      defaultBurnInSteps,
+		samplesPerWalker: defaultSamplesPerWalker,
+		stepStd:          defaultMetropolisStepStd,
+		rng:              rand.New(rand.NewSource(7)),
+	}
+}
+
+// Sample runs one Metropolis-Hastings pass across every walker and returns the pooled local
+// energy mean, variance, and acceptance ratio - one "dissipation round" in VMC terms.
+func (e *LocalEnergyEstimator) Sample() LocalEnergyEstimate {
+	var samples []float64
+	var accepted, proposed int
+
+	for walker := 0; walker < e.walkerCount; walker++ {
+		// Each walker starts near the potential's characteristic radius rather than at the
+		// origin, where the 1/r Coulomb term and the r=0 Laplacian singularity both blow up.
+		r := woodsSaxonRadiusFm
+		psiR := e.wavefunction.Psi(r)
+
+		for step := 0; step < e.burnInSteps+e.samplesPerWalker; step++ {
+			proposed++
+			candidate := r + e.stepStd*e.rng.NormFloat64()
+			if candidate < 0 {
+				candidate = -candidate
+			}
+			psiCandidate := e.wavefunction.Psi(candidate)
+
+			acceptProb := 1.0
+			if psiR != 0 {
+				ratio := psiCandidate / psiR
+				acceptProb = math.Min(1.0, ratio*ratio)
+			}
+			if e.rng.Float64() < acceptProb {
+				r = candidate
+				psiR = psiCandidate
+				accepted++
+			}
+
+			if step >= e.burnInSteps {
+				samples = append(samples, localEnergy(e.wavefunction, e.potential, r))
+			}
+		}
+	}
+
+	return LocalEnergyEstimate{
+		MeanMeV:         mean(samples),
+		Variance:        variance(samples),
+		AcceptanceRatio: ratioOrZero(accepted, proposed),
+		NWalkers:        e.walkerCount,
+	}
+}
+
+func mean(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range samples {
+		sum += s
+	}
+	return sum / float64(len(samples))
+}
+
+func variance(samples []float64) float64 {
+	if len(samples) < 2 {
+		return 0
+	}
+	m := mean(samples)
+	var sumSq float64
+	for _, s := range samples {
+		sumSq += (s - m) * (s - m)
+	}
+	return sumSq / float64(len(samples)-1)
+}
+
+func ratioOrZero(numerator, denominator int) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
+
+// =============================================================================
+// PER-FIELD REGISTRY
+// =============================================================================
+
+// LocalEnergyRegistry tracks one LocalEnergyEstimator per active energy field, keyed by the
+// field_id the reactor assigns on creation.
+type LocalEnergyRegistry struct {
+	mu         sync.RWMutex
+	estimators map[string]*LocalEnergyEstimator
+}
+
+// NewLocalEnergyRegistry builds an empty registry; fields are added as they're created.
+func NewLocalEnergyRegistry() *LocalEnergyRegistry {
+	return &LocalEnergyRegistry{estimators: make(map[string]*LocalEnergyEstimator)}
+}
+
+// Register creates and stores an estimator for fieldID using the named trial wavefunction
+// (falling back to defaultTrialWavefunctionName) evaluated against potential, replacing any
+// estimator already registered for fieldID.
+func (r *LocalEnergyRegistry) Register(fieldID, trialWavefunctionName string, potential PotentialFunc) {
+	estimator := NewLocalEnergyEstimator(resolveTrialWavefunction(trialWavefunctionName), potential)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.estimators[fieldID] = estimator
+}
+
+// Sample runs one dissipation round's estimate for fieldID. It reports ok=false if fieldID was
+// never registered, e.g. the field predates this server process.
+func (r *LocalEnergyRegistry) Sample(fieldID string) (LocalEnergyEstimate, bool) {
+	r.mu.RLock()
+	estimator, ok := r.estimators[fieldID]
+	r.mu.RUnlock()
+	if !ok {
+		return LocalEnergyEstimate{}, false
+	}
+	return estimator.Sample(), true
+}
+
+// Forget drops fieldID's estimator, called once the field itself is deleted.
+func (r *LocalEnergyRegistry) Forget(fieldID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.estimators, fieldID)
+}
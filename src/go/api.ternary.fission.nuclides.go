@@ -0,0 +1,125 @@
+/*
+ * File: src/go/api.ternary.fission.nuclides.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Nuclide Lookup and Parent-Nucleus Selection Endpoints
+ * Purpose: Exposes the nucdata-backed nuclide data service over HTTP and lets an operator
+ *          switch which actinide the fragment synthesizer treats as the fissioning parent
+ * Reason: computeFragmentBurst previously anchored every parent to the U-235 Z/A ratio
+ *         regardless of Config.ParentMass; this wires a real per-parent lookup in and gives
+ *         the dashboard something to switch between (Th-232, U-233, U-235, U-238, Pu-239,
+ *         Cm-245, Cf-252)
+ *
+ * Change Log:
+ * 2025-08-09: Initial GET /api/v1/nuclides/{Z}/{A} and GET/PUT /api/v1/parent-nucleus
+ *             handlers, plus parentNucleusZA resolving the active parent to a (Z, mass) pair
+ *
+ * Carry-over Context:
+ * - The active parent nucleus is runtime-switchable state, not Config; it lives behind its
+ *   own RWMutex (parentNucleusMu) on TernaryFissionAPIServer rather than mutating *Config,
+ *   matching how physicsLists/decayTracker are separate guarded components rather than
+ *   fields flowing back into Config
+ * - parentNucleusZA falls back to the historical U-235-ratio scaling estimate for a parent
+ *   symbol that isn't in nucdata.ParentPresets, so an unrecognized config value degrades to
+ *   the old behavior instead of panicking
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ternary-fission/nucdata"
+)
+
+// parentNucleusZA resolves the currently active parent nucleus to its (Z, mass number)
+// pair, preferring the nucdata-indexed value and falling back to the legacy U-235-ratio
+// estimate if the configured symbol isn't one of nucdata.ParentPresets.
+func (s *TernaryFissionAPIServer) parentNucleusZA() (z, massAMU float64) {
+	s.parentNucleusMu.RLock()
+	symbol := s.activeParentNucleus
+	s.parentNucleusMu.RUnlock()
+
+	if preset, ok := nucdata.ParentPresets[symbol]; ok {
+		if nuclide, err := s.nuclides.LookupNuclide(preset.Z, preset.A); err == nil {
+			return float64(nuclide.Z), float64(nuclide.A)
+		}
+		return float64(preset.Z), float64(preset.A)
+	}
+	return s.config.ParentMass * (u235Z / u235MassNumber), s.config.ParentMass
+}
+
+// handleNuclideLookup serves GET /api/v1/nuclides/{Z}/{A}.
+func (s *TernaryFissionAPIServer) handleNuclideLookup(w http.ResponseWriter, r *http.Request) {
+	remainder := strings.TrimPrefix(r.URL.Path, "/api/v1/nuclides/")
+	parts := strings.Split(remainder, "/")
+	if len(parts) != 2 {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Expected /api/v1/nuclides/{Z}/{A}")
+		return
+	}
+
+	z, err := strconv.Atoi(parts[0])
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid Z")
+		return
+	}
+	a, err := strconv.Atoi(parts[1])
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid A")
+		return
+	}
+
+	nuclide, err := s.nuclides.LookupNuclide(z, a)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusNotFound, "Nuclide not found")
+		return
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, nuclide)
+}
+
+// parentNucleusResponse is the body of GET /api/v1/parent-nucleus: the active symbol plus
+// the dashboard's selectable presets.
+type parentNucleusResponse struct {
+	Active  string   `json:"active"`
+	Presets []string `json:"presets"`
+}
+
+// parentNucleusUpdateRequest is the body of PUT /api/v1/parent-nucleus.
+type parentNucleusUpdateRequest struct {
+	Symbol string `json:"symbol"`
+}
+
+// handleParentNucleus serves GET and PUT /api/v1/parent-nucleus: reading and switching the
+// actinide the fragment synthesizer and decay tracker treat as the fissioning parent.
+func (s *TernaryFissionAPIServer) handleParentNucleus(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "PUT" {
+		var req parentNucleusUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		if _, ok := nucdata.ParentPresets[req.Symbol]; !ok {
+			s.writeErrorResponse(w, http.StatusBadRequest, "Unknown parent nucleus symbol")
+			return
+		}
+
+		s.parentNucleusMu.Lock()
+		s.activeParentNucleus = req.Symbol
+		s.parentNucleusMu.Unlock()
+	}
+
+	s.parentNucleusMu.RLock()
+	active := s.activeParentNucleus
+	s.parentNucleusMu.RUnlock()
+
+	presets := make([]string, 0, len(nucdata.ParentPresets))
+	for symbol := range nucdata.ParentPresets {
+		presets = append(presets, symbol)
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, parentNucleusResponse{Active: active, Presets: presets})
+}
@@ -0,0 +1,320 @@
+/*
+ * File: src/go/api.ternary.fission.physics.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Modular Physics-List Registration API
+ * Purpose: Lets operators compose named, ordered sets of physics processes instead of
+ *          relying on fixed constants, borrowing Geant4's G4VModularPhysicsList pattern
+ * Reason: ParentMass/ExcitationEnergy/EventsPerSecond and the fragment synthesis constants
+ *         were the only knobs available; there was no way to name or compare a configuration
+ *
+ * Change Log:
+ * 2025-08-09: Initial PhysicsProcess/PhysicsList/PhysicsListRegistry types, a default
+ *             FTFP_TERNARY_DEFAULT list reproducing prior fixed behavior, and the
+ *             GET/POST /api/v1/physics-lists handlers
+ *
+ * Carry-over Context:
+ * - The real cross-section physics (Watt/Maxwell-Boltzmann spectra, ENDF cross sections)
+ *   lives in the external reactor engine, which does not ship in this repo snapshot; the
+ *   cross-section values here are simplified placeholders so a registered PhysicsList can
+ *   be inspected and compared, not a replacement for the reactor's own physics
+ * - We do not yet forward a selected list into POST /api/v1/energy-fields: that handler
+ *   proxies the request body to the reactor byte-for-byte (see createEnergyField), and the
+ *   reactor's contract is outside this repo's control. The dashboard still lets an operator
+ *   pick a list; EnergyFieldRequest.PhysicsListName documents the field for when the reactor
+ *   contract is extended to accept it
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// =============================================================================
+// PHYSICS PROCESSES
+// =============================================================================
+
+// PhysicsProcess is one composable unit of a PhysicsList, mirroring Geant4's G4VProcess:
+// it has a name and reports a cross-section for a given kinetic energy.
+type PhysicsProcess interface {
+	Name() string
+	CrossSectionBarns(energyMeV float64) float64
+}
+
+// baseProcess holds the bookkeeping every concrete process shares: its name, an RNG seed
+// (each process samples independently, mirroring Geant4's per-process RNG streams), and
+// arbitrary process-specific parameters taken from the recipe's JSON config.
+type baseProcess struct {
+	name   string
+	seed   int64
+	params map[string]float64
+}
+
+func (p baseProcess) Name() string { return p.name }
+
+func (p baseProcess) paramOrDefault(key string, fallback float64) float64 {
+	if value, ok := p.params[key]; ok {
+		return value
+	}
+	return fallback
+}
+
+// ternaryFissionProcess is the three-body split (two heavy fragments + alpha) that
+// computeFragmentBurst synthesizes.
+type ternaryFissionProcess struct{ baseProcess }
+
+func (p ternaryFissionProcess) CrossSectionBarns(energyMeV float64) float64 {
+	return p.paramOrDefault("cross_section_barns", 1.2)
+}
+
+// binaryFissionProcess is the simpler two-fragment split (no alpha), offered as an
+// alternative process a recipe can register instead of TernaryFission.
+type binaryFissionProcess struct{ baseProcess }
+
+func (p binaryFissionProcess) CrossSectionBarns(energyMeV float64) float64 {
+	return p.paramOrDefault("cross_section_barns", 1.0)
+}
+
+// neutronTransportProcess represents prompt neutron emission/transport between fission
+// events. Its cross-section follows a rough 1/v law, the classic qualitative shape for
+// thermal neutron absorption/scattering cross-sections.
+type neutronTransportProcess struct{ baseProcess }
+
+func (p neutronTransportProcess) CrossSectionBarns(energyMeV float64) float64 {
+	base := p.paramOrDefault("cross_section_barns", 0.5)
+	if energyMeV <= 0 {
+		return base
+	}
+	return base / math.Sqrt(energyMeV)
+}
+
+// radioactiveDecayProcess is the beta/gamma decay-chain walk DecayChainTracker performs.
+type radioactiveDecayProcess struct{ baseProcess }
+
+func (p radioactiveDecayProcess) CrossSectionBarns(energyMeV float64) float64 {
+	return p.paramOrDefault("cross_section_barns", 0.0)
+}
+
+// alphaEmissionProcess is the long-range alpha particle ternary fission is named for.
+type alphaEmissionProcess struct{ baseProcess }
+
+func (p alphaEmissionProcess) CrossSectionBarns(energyMeV float64) float64 {
+	return p.paramOrDefault("cross_section_barns", 0.2)
+}
+
+// physicsProcessFactories maps the process names a recipe can reference to constructors.
+// Adding a new process type only means adding an entry here.
+var physicsProcessFactories = map[string]func(seed int64, params map[string]float64) PhysicsProcess{
+	"TernaryFission": func(seed int64, params map[string]float64) PhysicsProcess {
+		return ternaryFissionProcess{baseProcess{name: "TernaryFission", seed: seed, params: params}}
+	},
+	"BinaryFission": func(seed int64, params map[string]float64) PhysicsProcess {
+		return binaryFissionProcess{baseProcess{name: "BinaryFission", seed: seed, params: params}}
+	},
+	"NeutronTransport": func(seed int64, params map[string]float64) PhysicsProcess {
+		return neutronTransportProcess{baseProcess{name: "NeutronTransport", seed: seed, params: params}}
+	},
+	"RadioactiveDecay": func(seed int64, params map[string]float64) PhysicsProcess {
+		return radioactiveDecayProcess{baseProcess{name: "RadioactiveDecay", seed: seed, params: params}}
+	},
+	"AlphaEmission": func(seed int64, params map[string]float64) PhysicsProcess {
+		return alphaEmissionProcess{baseProcess{name: "AlphaEmission", seed: seed, params: params}}
+	},
+}
+
+// =============================================================================
+// PHYSICS LIST
+// =============================================================================
+
+// PhysicsList is a named, ordered composition of physics processes, mirroring Geant4's
+// G4VModularPhysicsList: a run is assembled from independent process implementations
+// rather than a single monolithic physics model.
+type PhysicsList struct {
+	Name      string
+	processes []PhysicsProcess
+}
+
+// NewPhysicsList builds an empty list under name; processes are added with RegisterProcess.
+func NewPhysicsList(name string) *PhysicsList {
+	return &PhysicsList{Name: name}
+}
+
+// RegisterProcess appends proc to the list, or replaces an existing process of the same
+// name, so re-registering under a name already present overrides rather than duplicates.
+func (l *PhysicsList) RegisterProcess(name string, proc PhysicsProcess) {
+	for i, existing := range l.processes {
+		if existing.Name() == name {
+			l.processes[i] = proc
+			return
+		}
+	}
+	l.processes = append(l.processes, proc)
+}
+
+// Processes returns a defensive copy of the list's processes in registration order.
+func (l *PhysicsList) Processes() []PhysicsProcess {
+	return append([]PhysicsProcess(nil), l.processes...)
+}
+
+// defaultPhysicsListName is the list reproducing this server's historical fixed
+// behavior: ternary fission fragment synthesis feeding the decay-chain tracker, plus
+// the alpha emission the synthesis already accounts for.
+const defaultPhysicsListName = "FTFP_TERNARY_DEFAULT"
+
+// defaultPhysicsList builds defaultPhysicsListName so existing deployments see no change
+// in behavior unless they register and activate a different recipe.
+func defaultPhysicsList() *PhysicsList {
+	list := NewPhysicsList(defaultPhysicsListName)
+	list.RegisterProcess("TernaryFission", physicsProcessFactories["TernaryFission"](1, nil))
+	list.RegisterProcess("RadioactiveDecay", physicsProcessFactories["RadioactiveDecay"](2, nil))
+	list.RegisterProcess("AlphaEmission", physicsProcessFactories["AlphaEmission"](3, nil))
+	return list
+}
+
+// =============================================================================
+// REGISTRY
+// =============================================================================
+
+// PhysicsListRegistry tracks every PhysicsList assembled so far and which one is active.
+type PhysicsListRegistry struct {
+	mu     sync.RWMutex
+	lists  map[string]*PhysicsList
+	active string
+}
+
+// NewPhysicsListRegistry seeds the registry with defaultPhysicsList, active by default.
+func NewPhysicsListRegistry() *PhysicsListRegistry {
+	registry := &PhysicsListRegistry{lists: make(map[string]*PhysicsList)}
+	registry.lists[defaultPhysicsListName] = defaultPhysicsList()
+	registry.active = defaultPhysicsListName
+	return registry
+}
+
+// Register stores list under list.Name, replacing any existing list of that name.
+func (r *PhysicsListRegistry) Register(list *PhysicsList) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lists[list.Name] = list
+}
+
+// Activate marks name as the active list for new energy fields. It reports false if name
+// has not been registered.
+func (r *PhysicsListRegistry) Activate(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.lists[name]; !ok {
+		return false
+	}
+	r.active = name
+	return true
+}
+
+// Get returns the named list, if registered.
+func (r *PhysicsListRegistry) Get(name string) (*PhysicsList, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list, ok := r.lists[name]
+	return list, ok
+}
+
+// Snapshot returns a summary of every registered list, marking which is active.
+func (r *PhysicsListRegistry) Snapshot() []physicsListSummary {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summaries := make([]physicsListSummary, 0, len(r.lists))
+	for _, list := range r.lists {
+		summaries = append(summaries, summarize(list, list.Name == r.active))
+	}
+	return summaries
+}
+
+func summarize(list *PhysicsList, active bool) physicsListSummary {
+	names := make([]string, 0, len(list.processes))
+	for _, proc := range list.processes {
+		names = append(names, proc.Name())
+	}
+	return physicsListSummary{Name: list.Name, Processes: names, Active: active}
+}
+
+// =============================================================================
+// HTTP HANDLERS
+// =============================================================================
+
+// physicsListSummary is one list's entry in the GET /api/v1/physics-lists response.
+type physicsListSummary struct {
+	Name      string   `json:"name"`
+	Processes []string `json:"processes"`
+	Active    bool     `json:"active"`
+}
+
+// physicsListRecipeProcess is one process entry in a POST /api/v1/physics-lists recipe.
+type physicsListRecipeProcess struct {
+	Name   string             `json:"name"`
+	Seed   int64              `json:"seed,omitempty"`
+	Params map[string]float64 `json:"params,omitempty"`
+}
+
+// physicsListRecipe is the POST /api/v1/physics-lists request body: a named, ordered set
+// of processes, optionally activated immediately.
+type physicsListRecipe struct {
+	Name      string                     `json:"name"`
+	Processes []physicsListRecipeProcess `json:"processes"`
+	Activate  bool                       `json:"activate,omitempty"`
+}
+
+// handlePhysicsLists serves GET (list every registered PhysicsList) and POST (register a
+// new one from a JSON recipe) on /api/v1/physics-lists.
+func (s *TernaryFissionAPIServer) handlePhysicsLists(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSONResponse(w, http.StatusOK, s.physicsLists.Snapshot())
+	case http.MethodPost:
+		s.createPhysicsList(w, r)
+	default:
+		s.writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed - use GET or POST")
+	}
+}
+
+// createPhysicsList decodes a recipe, resolves each named process against
+// physicsProcessFactories, and registers the resulting PhysicsList.
+func (s *TernaryFissionAPIServer) createPhysicsList(w http.ResponseWriter, r *http.Request) {
+	var recipe physicsListRecipe
+	if err := json.NewDecoder(r.Body).Decode(&recipe); err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid physics list recipe")
+		return
+	}
+
+	recipe.Name = strings.TrimSpace(recipe.Name)
+	if recipe.Name == "" {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Physics list recipe requires a name")
+		return
+	}
+	if len(recipe.Processes) == 0 {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Physics list recipe requires at least one process")
+		return
+	}
+
+	list := NewPhysicsList(recipe.Name)
+	for _, entry := range recipe.Processes {
+		factory, ok := physicsProcessFactories[entry.Name]
+		if !ok {
+			s.writeErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Unknown physics process %q", entry.Name))
+			return
+		}
+		list.RegisterProcess(entry.Name, factory(entry.Seed, entry.Params))
+	}
+
+	s.physicsLists.Register(list)
+	if recipe.Activate {
+		s.physicsLists.Activate(recipe.Name)
+	}
+
+	s.writeJSONResponse(w, http.StatusCreated, summarize(list, recipe.Activate))
+}
@@ -0,0 +1,559 @@
+/*
+ * File: src/go/api.ternary.fission.push.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: VAPID Web Push Notifications for Reactor Thresholds
+ * Purpose: Lets operators subscribe a browser to push notifications fired when active field
+ *          count, total energy, CPU/memory, or portal state cross configured thresholds,
+ *          without keeping the dashboard open
+ * Reason: The dashboard's existing transports (WebSocket/SSE/long-poll) all require an open
+ *         tab; this gives the reactor a way to reach an operator who has navigated away
+ *
+ * Change Log:
+ * 2025-08-09: Initial PushStore/PushDispatcher, RFC 8291 aes128gcm payload encryption,
+ *             RFC 8292 VAPID JWT signing, and the /api/v1/push/{subscribe,vapid-public-key}
+ *             routes
+ *
+ * Carry-over Context:
+ * - PushStore is an interface with only an in-memory implementation here (subscriptions do
+ *   not survive a restart); a bolt/sqlite-backed implementation can be dropped in later
+ *   without touching the HTTP handlers or PushDispatcher, the same pluggable-store shape as
+ *   AuthStore and DecayDataSource
+ * - SystemStatusResponse has no portal-state enum, so the portal rule treats
+ *   PortalDurationRemain > 0 as "portal active" - an approximation of the existing field,
+ *   not a new one
+ * - PushDispatcher is edge-triggered: a threshold fires once on the tick it's first crossed,
+ *   not once per poll interval for the duration of the breach
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+
+	"ternary-fission/logging"
+)
+
+// =============================================================================
+// SUBSCRIPTIONS AND STORAGE
+// =============================================================================
+
+// PushSubscription is the browser PushSubscription JSON posted by the dashboard's service
+// worker registration code.
+type PushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// storedPushSubscription pairs a subscription with the endpoint hash it is keyed and
+// rate-limited under, so callers never need to recompute the hash.
+type storedPushSubscription struct {
+	EndpointHash string
+	Subscription PushSubscription
+}
+
+// PushStore persists push subscriptions. We only ship memoryPushStore in this tree; a
+// bolt/sqlite-backed implementation can be substituted later without touching the HTTP
+// handlers or PushDispatcher.
+type PushStore interface {
+	Put(endpointHash string, sub PushSubscription) error
+	Delete(endpointHash string) error
+	List() ([]storedPushSubscription, error)
+}
+
+// memoryPushStore is an in-memory PushStore; subscriptions do not survive a restart, the
+// same scope limitation TimeSeriesStore documents for retained metrics.
+type memoryPushStore struct {
+	mu   sync.RWMutex
+	subs map[string]PushSubscription
+}
+
+func newMemoryPushStore() *memoryPushStore {
+	return &memoryPushStore{subs: make(map[string]PushSubscription)}
+}
+
+func (m *memoryPushStore) Put(endpointHash string, sub PushSubscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subs[endpointHash] = sub
+	return nil
+}
+
+func (m *memoryPushStore) Delete(endpointHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.subs, endpointHash)
+	return nil
+}
+
+func (m *memoryPushStore) List() ([]storedPushSubscription, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]storedPushSubscription, 0, len(m.subs))
+	for hash, sub := range m.subs {
+		out = append(out, storedPushSubscription{EndpointHash: hash, Subscription: sub})
+	}
+	return out, nil
+}
+
+// pushEndpointHash derives the stable ID subscriptions are keyed, deleted, and rate-limited
+// by, so the push endpoint URL itself (which can be long and service-specific) never has to
+// round-trip through client code.
+func pushEndpointHash(endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return hex.EncodeToString(sum[:])
+}
+
+// =============================================================================
+// VAPID (RFC 8292) KEY HANDLING AND JWT SIGNING
+// =============================================================================
+
+// vapidKeyPair holds the application server's ES256 key pair used to sign push JWTs, plus
+// its base64url encodings for config persistence and the public-key HTTP response.
+type vapidKeyPair struct {
+	privateKey    *ecdsa.PrivateKey
+	publicKeyB64  string
+	privateKeyB64 string
+}
+
+func newVAPIDKeyPair(priv *ecdsa.PrivateKey) *vapidKeyPair {
+	publicKeyBytes := elliptic.Marshal(elliptic.P256(), priv.PublicKey.X, priv.PublicKey.Y)
+
+	var privateKeyBytes [32]byte
+	priv.D.FillBytes(privateKeyBytes[:])
+
+	return &vapidKeyPair{
+		privateKey:    priv,
+		publicKeyB64:  base64.RawURLEncoding.EncodeToString(publicKeyBytes),
+		privateKeyB64: base64.RawURLEncoding.EncodeToString(privateKeyBytes[:]),
+	}
+}
+
+// parseVAPIDPrivateKey reconstructs a key pair from Config.PushVAPIDPrivateKey's raw
+// base64url-encoded scalar.
+func parseVAPIDPrivateKey(raw string) (*vapidKeyPair, error) {
+	scalar, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid push_vapid_private_key: %w", err)
+	}
+
+	curve := elliptic.P256()
+	x, y := curve.ScalarBaseMult(scalar)
+	priv := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+		D:         new(big.Int).SetBytes(scalar),
+	}
+	return newVAPIDKeyPair(priv), nil
+}
+
+// loadOrGenerateVAPIDKeys loads the configured VAPID key pair, or generates and logs a fresh
+// one when none is configured - mirroring generateSecureToken's crypto/rand-backed,
+// log-and-continue approach to first-run secrets.
+func loadOrGenerateVAPIDKeys(config *Config, logger *logging.Logger) (*vapidKeyPair, error) {
+	if config.PushVAPIDPrivateKey != "" {
+		return parseVAPIDPrivateKey(config.PushVAPIDPrivateKey)
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate VAPID key pair: %w", err)
+	}
+
+	keys := newVAPIDKeyPair(priv)
+	logger.Warn("No push_vapid_private_key configured; generated an ephemeral VAPID key pair for this run - set push_vapid_public_key/push_vapid_private_key to keep existing browser subscriptions valid across restarts", logging.Fields{
+		"push_vapid_public_key":  keys.publicKeyB64,
+		"push_vapid_private_key": keys.privateKeyB64,
+	})
+	return keys, nil
+}
+
+// vapidJWTLifetime is well under the one-day ceiling push services enforce on the VAPID
+// JWT's exp claim.
+const vapidJWTLifetime = 12 * time.Hour
+
+// vapidAudience is the scheme+host of a push endpoint, the aud claim push services require.
+func vapidAudience(endpoint string) (string, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid push endpoint: %w", err)
+	}
+	return fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host), nil
+}
+
+// buildVAPIDAuthorizationHeader signs an ES256 JWT over {aud, exp, sub} and returns the
+// complete Authorization header value, including the raw uncompressed public key push
+// services use to verify it.
+func buildVAPIDAuthorizationHeader(keys *vapidKeyPair, audience, subject string) (string, error) {
+	headerJSON, err := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256"})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(map[string]interface{}{
+		"aud": audience,
+		"exp": time.Now().Add(vapidJWTLifetime).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, keys.privateKey, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID JWT: %w", err)
+	}
+
+	// JWS ES256 wants the raw 32+32 byte r||s encoding (IEEE P1363), not ASN.1 DER.
+	var signature [64]byte
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	jwt := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature[:])
+	return fmt.Sprintf("vapid t=%s, k=%s", jwt, keys.publicKeyB64), nil
+}
+
+// =============================================================================
+// RFC 8291 PAYLOAD ENCRYPTION
+// =============================================================================
+
+// encryptWebPushPayload encrypts plaintext for sub per RFC 8291 (aes128gcm content coding,
+// RFC 8188), returning the complete request body: a 16-byte record salt, the 4-byte
+// ciphertext length, our ephemeral public key, and the sealed ciphertext.
+func encryptWebPushPayload(sub PushSubscription, plaintext []byte) ([]byte, error) {
+	uaPublicKeyBytes, err := base64.RawURLEncoding.DecodeString(sub.Keys.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription p256dh key: %w", err)
+	}
+	authSecret, err := base64.RawURLEncoding.DecodeString(sub.Keys.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription auth secret: %w", err)
+	}
+
+	curve := ecdh.P256()
+	uaPublicKey, err := curve.NewPublicKey(uaPublicKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription p256dh key: %w", err)
+	}
+
+	asPrivateKey, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral ECDH key: %w", err)
+	}
+	asPublicKeyBytes := asPrivateKey.PublicKey().Bytes()
+
+	sharedSecret, err := asPrivateKey.ECDH(uaPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("ECDH key agreement failed: %w", err)
+	}
+
+	ikm, err := webPushIKM(sharedSecret, authSecret, uaPublicKeyBytes, asPublicKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	recordSalt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, recordSalt); err != nil {
+		return nil, fmt.Errorf("failed to generate record salt: %w", err)
+	}
+
+	prk := hkdf.Extract(sha256.New, ikm, recordSalt)
+
+	cek := make([]byte, 16)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: aes128gcm\x00")), cek); err != nil {
+		return nil, fmt.Errorf("failed to derive content encryption key: %w", err)
+	}
+	nonce := make([]byte, 12)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: nonce\x00")), nonce); err != nil {
+		return nil, fmt.Errorf("failed to derive nonce: %w", err)
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build AES-GCM: %w", err)
+	}
+
+	// RFC 8188's single-record delimiter byte: 0x02 marks this as the last (and only) record.
+	padded := append(append([]byte{}, plaintext...), 0x02)
+	ciphertext := gcm.Seal(nil, nonce, padded, nil)
+
+	record := new(bytes.Buffer)
+	record.Write(recordSalt)
+	binary.Write(record, binary.BigEndian, uint32(len(ciphertext)))
+	record.WriteByte(byte(len(asPublicKeyBytes)))
+	record.Write(asPublicKeyBytes)
+	record.Write(ciphertext)
+	return record.Bytes(), nil
+}
+
+// webPushIKM derives RFC 8291's "input keying material" from the ECDH shared secret, the
+// subscription's auth secret, and both parties' public keys.
+func webPushIKM(sharedSecret, authSecret, uaPublicKeyBytes, asPublicKeyBytes []byte) ([]byte, error) {
+	prk := hkdf.Extract(sha256.New, sharedSecret, authSecret)
+
+	info := append([]byte("WebPush: info\x00"), uaPublicKeyBytes...)
+	info = append(info, asPublicKeyBytes...)
+
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), ikm); err != nil {
+		return nil, fmt.Errorf("failed to derive input keying material: %w", err)
+	}
+	return ikm, nil
+}
+
+// =============================================================================
+// DISPATCHER
+// =============================================================================
+
+const (
+	pushMessageTTLSeconds = 60
+	pushRequestTimeout    = 10 * time.Second
+	pushRateLimitRPS      = 1.0
+	pushRateLimitBurst    = 1
+)
+
+// pushNotificationPayload is the JSON the dashboard's service worker decodes in its "push"
+// event handler to build the displayed notification.
+type pushNotificationPayload struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// PushDispatcher watches the same monitor feed the WebSocket/SSE/long-poll transports use
+// and fires a push notification the moment a configured threshold is crossed.
+type PushDispatcher struct {
+	server  *TernaryFissionAPIServer
+	store   PushStore
+	vapid   *vapidKeyPair
+	limiter *RateLimiter
+	client  *http.Client
+
+	prevActiveFieldsOverThreshold bool
+	prevTotalEnergyOverThreshold  bool
+	prevCPUOverThreshold          bool
+	prevMemoryOverThreshold       bool
+	prevPortalActive              bool
+}
+
+// NewPushDispatcher starts the dispatcher's monitor-subscribing goroutine and returns it;
+// the goroutine runs for the server's lifetime.
+func NewPushDispatcher(server *TernaryFissionAPIServer, store PushStore, vapid *vapidKeyPair) *PushDispatcher {
+	d := &PushDispatcher{
+		server:  server,
+		store:   store,
+		vapid:   vapid,
+		limiter: NewRateLimiter(pushRateLimitRPS, pushRateLimitBurst),
+		client:  &http.Client{Timeout: pushRequestTimeout},
+	}
+	go d.run()
+	return d
+}
+
+func (d *PushDispatcher) run() {
+	_, feed, unsubscribe := d.server.monitor.subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case snapshot := <-feed:
+			d.evaluate(snapshot.Status)
+		case <-d.server.ctx.Done():
+			return
+		}
+	}
+}
+
+// evaluate checks each configured threshold against status and broadcasts a notification
+// the moment a rule transitions from not-firing to firing, so a sustained breach sends one
+// notification rather than one per poll tick.
+func (d *PushDispatcher) evaluate(status SystemStatusResponse) {
+	config := d.server.config
+
+	if config.PushActiveFieldsThreshold > 0 {
+		over := status.ActiveEnergyFields > config.PushActiveFieldsThreshold
+		if over && !d.prevActiveFieldsOverThreshold {
+			d.broadcast(fmt.Sprintf("Active energy fields (%d) exceeded threshold (%d)", status.ActiveEnergyFields, config.PushActiveFieldsThreshold))
+		}
+		d.prevActiveFieldsOverThreshold = over
+	}
+
+	if config.PushTotalEnergyThresholdMeV > 0 {
+		over := status.TotalEnergySimulated > config.PushTotalEnergyThresholdMeV
+		if over && !d.prevTotalEnergyOverThreshold {
+			d.broadcast(fmt.Sprintf("Total simulated energy (%.1f MeV) exceeded threshold (%.1f MeV)", status.TotalEnergySimulated, config.PushTotalEnergyThresholdMeV))
+		}
+		d.prevTotalEnergyOverThreshold = over
+	}
+
+	if config.PushCPUPercentThreshold > 0 {
+		over := status.CPUUsagePercent > config.PushCPUPercentThreshold
+		if over && !d.prevCPUOverThreshold {
+			d.broadcast(fmt.Sprintf("CPU usage (%.1f%%) exceeded threshold (%.1f%%)", status.CPUUsagePercent, config.PushCPUPercentThreshold))
+		}
+		d.prevCPUOverThreshold = over
+	}
+
+	if config.PushMemoryPercentThreshold > 0 {
+		over := status.MemoryUsagePercent > config.PushMemoryPercentThreshold
+		if over && !d.prevMemoryOverThreshold {
+			d.broadcast(fmt.Sprintf("Memory usage (%.1f%%) exceeded threshold (%.1f%%)", status.MemoryUsagePercent, config.PushMemoryPercentThreshold))
+		}
+		d.prevMemoryOverThreshold = over
+	}
+
+	if config.PushPortalActiveAlert {
+		active := status.PortalDurationRemain > 0
+		if active && !d.prevPortalActive {
+			d.broadcast("Portal simulation is now active")
+		}
+		d.prevPortalActive = active
+	}
+}
+
+func (d *PushDispatcher) broadcast(message string) {
+	subs, err := d.store.List()
+	if err != nil {
+		d.server.logger.Error("Push subscription list failed", logging.Fields{"error": err.Error()})
+		return
+	}
+
+	payload, err := json.Marshal(pushNotificationPayload{Title: "Ternary Fission Reactor", Body: message})
+	if err != nil {
+		d.server.logger.Error("Push payload marshal failed", logging.Fields{"error": err.Error()})
+		return
+	}
+
+	for _, stored := range subs {
+		go d.send(stored, payload)
+	}
+}
+
+// send delivers payload to a single subscription, rate-limited per endpoint hash, pruning
+// the subscription on a 404/410 response (RFC 8030 Section 7.2's "subscription is gone").
+func (d *PushDispatcher) send(stored storedPushSubscription, payload []byte) {
+	if allowed, _, _ := d.limiter.Allow(stored.EndpointHash); !allowed {
+		return
+	}
+
+	body, err := encryptWebPushPayload(stored.Subscription, payload)
+	if err != nil {
+		d.server.logger.Error("Push payload encryption failed", logging.Fields{"error": err.Error(), "endpoint_hash": stored.EndpointHash})
+		return
+	}
+
+	audience, err := vapidAudience(stored.Subscription.Endpoint)
+	if err != nil {
+		d.server.logger.Error("Push endpoint audience parse failed", logging.Fields{"error": err.Error(), "endpoint_hash": stored.EndpointHash})
+		return
+	}
+	authHeader, err := buildVAPIDAuthorizationHeader(d.vapid, audience, d.server.config.PushVAPIDSubject)
+	if err != nil {
+		d.server.logger.Error("Push VAPID signing failed", logging.Fields{"error": err.Error(), "endpoint_hash": stored.EndpointHash})
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, stored.Subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		d.server.logger.Error("Push request build failed", logging.Fields{"error": err.Error(), "endpoint_hash": stored.EndpointHash})
+		return
+	}
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(pushMessageTTLSeconds))
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		d.server.logger.Error("Push send failed", logging.Fields{"error": err.Error(), "endpoint_hash": stored.EndpointHash})
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		if err := d.store.Delete(stored.EndpointHash); err != nil {
+			d.server.logger.Error("Push subscription prune failed", logging.Fields{"error": err.Error(), "endpoint_hash": stored.EndpointHash})
+		}
+		return
+	}
+	if resp.StatusCode >= 300 {
+		d.server.logger.Error("Push service rejected message", logging.Fields{"status": resp.StatusCode, "endpoint_hash": stored.EndpointHash})
+	}
+}
+
+// =============================================================================
+// HTTP HANDLERS
+// =============================================================================
+
+// handlePushVAPIDPublicKey returns the raw base64url-encoded uncompressed public key browsers
+// pass to PushManager.subscribe() as applicationServerKey.
+func (s *TernaryFissionAPIServer) handlePushVAPIDPublicKey(w http.ResponseWriter, r *http.Request) {
+	s.writeJSONResponse(w, http.StatusOK, map[string]string{"public_key": s.pushVAPIDKeys.publicKeyB64})
+}
+
+// handlePushSubscribe stores a browser PushSubscription, keyed by a hash of its endpoint so
+// the caller never has to echo the (long, service-specific) endpoint URL back to delete it.
+func (s *TernaryFissionAPIServer) handlePushSubscribe(w http.ResponseWriter, r *http.Request) {
+	var sub PushSubscription
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if sub.Endpoint == "" || sub.Keys.P256dh == "" || sub.Keys.Auth == "" {
+		s.writeErrorResponse(w, http.StatusBadRequest, "endpoint, keys.p256dh, and keys.auth are required")
+		return
+	}
+
+	endpointHash := pushEndpointHash(sub.Endpoint)
+	if err := s.pushStore.Put(endpointHash, sub); err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to persist subscription")
+		return
+	}
+	s.writeJSONResponse(w, http.StatusCreated, map[string]string{"endpoint_hash": endpointHash})
+}
+
+// handlePushUnsubscribe removes a subscription by the endpoint hash handlePushSubscribe
+// returned for it.
+func (s *TernaryFissionAPIServer) handlePushUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 4 {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid endpoint_hash")
+		return
+	}
+	endpointHash := parts[len(parts)-1]
+
+	if err := s.pushStore.Delete(endpointHash); err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete subscription")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
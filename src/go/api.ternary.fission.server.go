@@ -13,6 +13,96 @@
  *             Enhanced visual design with modern responsive interface and interactive elements
  *             Fixed config parsing to properly handle inline comments and whitespace
  *             Added real-time energy field visualization and advanced monitoring capabilities
+ * 2025-08-04: Wired the new session/token auth subsystem (api.ternary.fission.auth.go) into
+ *             setupRoutes and handleAPIRoutes, gating energy-field mutations, the portal
+ *             trigger, and the WebSocket monitor behind viewer/operator/admin roles
+ * 2025-08-05: Added multi-endpoint reactor config and wired remote_write push plus the
+ *             /api/v1/targets HTTP SD responder (api.ternary.fission.metrics.go) into the
+ *             existing reactor status polling loop
+ * 2025-08-06: Wired the in-process time-series store (api.ternary.fission.tsdb.go) into
+ *             the reactor status polling loop and registered the /api/v1/tsdb/{search,
+ *             query,annotations} Grafana SimpleJSON datasource routes
+ * 2025-08-07: Replaced the embedded enhancedDashboardHTML constant with the new frontend
+ *             package (frontend/), which renders dashboard/login/portal-trigger/energy-field
+ *             pages from base+content templates and serves /static/ assets with cache-busting
+ * 2025-08-08: Wired the token-bucket rate limiter (api.ternary.fission.ratelimit.go) into
+ *             setupRoutes as general per-IP/per-token middleware, with tighter per-endpoint
+ *             overrides on POST /energy-fields and PUT /portal/trigger
+ * 2025-08-09: Replaced ad-hoc log.Printf calls with the structured logger (logging/),
+ *             added a Logger field plus the sink config fields it reads, and reworked
+ *             loggingMiddleware to attach request_id/remote_addr/endpoint/status/
+ *             latency_ms/user to every request line
+ * 2025-08-09: Wrapped the WebSocket monitor's status push in a typed wsStatusMessage
+ *             envelope and added a fragment_burst message (api.ternary.fission.fragments.go)
+ *             whenever new fission events occur, feeding the dashboard's 3D trajectory viewer
+ * 2025-08-09: Wired the decay-chain tracker (api.ternary.fission.decay.go) into the reactor
+ *             status polling loop and getSystemStatus, added the decay_heat_mev_per_second
+ *             status field, and registered the /api/v1/decay-chains{,/{nuclide}} routes
+ * 2025-08-09: Added the physics-list registry (api.ternary.fission.physics.go), its
+ *             FTFP_TERNARY_DEFAULT default list, and the GET/POST /api/v1/physics-lists
+ *             routes; documented the (for now informational) physics_list_name field on
+ *             EnergyFieldRequest
+ * 2025-08-09: Added the nuclide data service (nucdata/), replacing the fragment
+ *             synthesizer's hard-coded U-235 Z/A ratio with a real per-parent lookup;
+ *             registered GET /api/v1/nuclides/{Z}/{A} and GET/PUT /api/v1/parent-nucleus
+ *             (api.ternary.fission.nuclides.go)
+ * 2025-08-09: Added the Monte Carlo local-energy estimator (api.ternary.fission.montecarlo.go);
+ *             POST /api/v1/energy-fields accepts trial_wavefunction, and POST
+ *             .../dissipate now merges local_energy_mean/local_energy_variance/
+ *             acceptance_ratio/n_walkers into the reactor's response
+ * 2025-08-09: Extracted getSystemStatus's reactor-fetch/decay-heat enrichment into
+ *             fetchSystemStatus() so the new GraphQL transport (api.ternary.fission.graphql.go)
+ *             can share it; registered /graphql as a graphql-transport-ws WebSocket endpoint
+ *             and gave statusRecordingWriter a Hijack method so the logging middleware no
+ *             longer blocks WebSocket upgrades on it
+ * 2025-08-09: Moved handleWebSocketConnection's reactor poll into the shared
+ *             MonitorTransportNegotiator (api.ternary.fission.monitor.go); registered
+ *             GET /api/v1/monitor/sse and GET /api/v1/monitor/poll as fallback transports
+ *             for the same status stream
+ * 2025-08-09: Added the Web Push subsystem (api.ternary.fission.push.go), gated behind the
+ *             new Config.PushEnabled; registered POST /api/v1/push/subscribe, DELETE
+ *             /api/v1/push/subscribe/{endpoint_hash}, and GET /api/v1/push/vapid-public-key
+ * 2025-08-09: Changed reactorClient's type from *http.Client to *ReactorDispatcher
+ *             (api.ternary.fission.reactorclient.go), a configurable retry/redirect/
+ *             circuit-breaker/cache/metrics/auth interceptor chain around the reactor's
+ *             HTTP API; existing .Do/.Get call sites are unchanged
+ * 2025-08-09: Added HTTP Basic Auth, static API keys (Config.AuthAPIKeysFile), and an auth
+ *             failures counter to the auth subsystem (api.ternary.fission.auth.go)
+ * 2025-08-09: Added the ring-buffered events.BufferedSubscription event bus (events/) and
+ *             GET /api/v1/events (api.ternary.fission.events.go); createEnergyField,
+ *             dissipateEnergyField, and triggerPortalSimulation now publish to it, and
+ *             MonitorTransportNegotiator republishes every status tick into it as well
+ * 2025-08-09: Wired timeoutMiddleware (api.ternary.fission.timeout.go) into setupRoutes as
+ *             the outermost per-request deadline, configurable via RequestTimeoutSeconds and
+ *             per-route RequestTimeoutOverrides; dissipateEnergyField, triggerPortalSimulation,
+ *             and healthCheck now build their reactor request with NewRequestWithContext so the
+ *             deadline cancels the outbound call too
+ * 2025-08-09: Added syncthing-style CSRF protection (api.ternary.fission.csrf.go), wired as
+ *             csrfMiddleware in setupRoutes ahead of rate limiting; serveLogin now embeds a
+ *             hidden-field token for the plain HTML login form, and frontend/static/js/csrf.js
+ *             attaches the header to dashboard.js's existing fetch() calls
+ * 2025-08-09: Added the admin-only debug subsystem (api.ternary.fission.debug.go), gated
+ *             behind the new Config.DebugEnabled (itself AND-ed with main's new
+ *             -allow-profiling flag): pprof heap/goroutine/profile/trace handlers, a zipped
+ *             support-bundle endpoint, and a reactor-passthrough proxy, all under
+ *             /api/v1/debug/; logging.Logger grew a ring-buffer sink so the bundle can
+ *             include recent log lines
+ * 2025-08-09: Reworked the reactor client's retry/circuit-breaker interceptors
+ *             (api.ternary.fission.reactorclient.go) around idempotency-aware retries and a
+ *             rolling failure-ratio window; added forwardReactorResponseHeaders so every
+ *             reactor-proxying handler forwards Retry-After, and healthCheck now recognizes
+ *             the breaker's open-circuit sentinel instead of failing the JSON decode
+ * 2025-08-09: Added OpenTelemetry tracing (api.ternary.fission.tracing.go): setupRoutes wraps
+ *             the router in otelhttp when Config.TracingEnabled, and the reactor dispatcher
+ *             gains an automatic "tracing" interceptor so outgoing requests carry a W3C
+ *             traceparent header; sampling is reloadable via SIGHUP without a restart
+ * 2025-08-10: loggingMiddleware now attaches its generated request ID to the request's
+ *             context and echoes it back as an X-Request-ID response header; added the
+ *             reactorGet helper (and the request_id reactor interceptor in
+ *             api.ternary.fission.reactorclient.go) so listEnergyFields/getEnergyField/
+ *             serveEnergyFieldDetailPage forward that same ID to the reactor and log each
+ *             hop's reactor_upstream_id; logging/'s FormatColor adds a colorized text
+ *             handler (log_format=color/console/dev) alongside the existing text and json ones
  *
  * Carry-over Context:
  * - We fixed the critical routing bug preventing dashboard access at root URL
@@ -20,6 +110,9 @@
  * - We provide complete physics background, usage instructions, and API documentation
  * - We use modern responsive design with proper font sizing and spacing as specified
  * - We maintain production-grade error handling and comprehensive logging throughout
+ * - Auth is opt-in via Config.AuthEnabled so existing deployments keep working unchanged
+ * - Page markup now lives in frontend/templates/*.gotemplate.html; this file only wires
+ *   routes to s.frontend.RenderPage, it does not embed any HTML
  * - Next: Integration with C++ simulation engine via CGO for real physics calculations
  */
 
@@ -29,12 +122,14 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
-	"html/template"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -48,6 +143,12 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"ternary-fission/events"
+	"ternary-fission/frontend"
+	"ternary-fission/logging"
+	"ternary-fission/nucdata"
 )
 
 var (
@@ -69,7 +170,49 @@ type Config struct {
 	MaxRequestSize           int64  `config:"max_request_size"`
 	MaxConcurrentConnections int    `config:"max_concurrent_connections"`
 	ReactorBaseURL           string `config:"reactor_base_url"`
+	ReactorEndpoints         []string
 	StatusPollInterval       int    `config:"status_poll_interval"`
+	RemoteWriteURL           string `config:"remote_write_url"`
+
+	// ReactorInterceptors selects and orders the interceptor chain ReactorDispatcher builds
+	// around every request to the reactor (api.ternary.fission.reactorclient.go); comma-
+	// separated, outermost first, mirroring ReactorEndpoints' list format
+	ReactorInterceptors     string `config:"reactor_interceptors"`
+	ReactorRetryMaxAttempts int    `config:"reactor_retry_max_attempts"`
+	ReactorRetryBaseDelayMS int    `config:"reactor_retry_base_delay_ms"`
+	// ReactorRetryMaxDelayMS caps the exponential backoff between retry attempts
+	ReactorRetryMaxDelayMS int `config:"reactor_retry_max_delay_ms"`
+	ReactorMaxRedirections int `config:"reactor_max_redirections"`
+	// ReactorCircuitBreakerThreshold is also the rolling window's minimum request volume
+	// before a failure ratio can trip the breaker, so a single unlucky request can't open it
+	ReactorCircuitBreakerThreshold    int     `config:"reactor_circuit_breaker_threshold"`
+	ReactorCircuitBreakerOpenSeconds  int     `config:"reactor_circuit_breaker_open_seconds"`
+	ReactorCircuitBreakerWindowSize   int     `config:"reactor_circuit_breaker_window_size"`
+	ReactorCircuitBreakerFailureRatio float64 `config:"reactor_circuit_breaker_failure_ratio"`
+	ReactorCacheTTLSeconds            int     `config:"reactor_cache_ttl_seconds"`
+	ReactorAuthToken                  string  `config:"reactor_auth_token"`
+
+	// ReactorTransport selects how the server talks to the reactor: "http" (default) sends
+	// JSON over ReactorBaseURL through ReactorDispatcher; "grpc" dials ReactorGRPCTarget
+	// instead and speaks the ReactorTransport service from src/go/pb/reactor.proto. Every
+	// reactor-forwarding handler keeps calling s.reactorClient.Do/Get either way - see
+	// grpcReactorClient in api.ternary.fission.grpcclient.go for the translation layer
+	ReactorTransport    string `config:"reactor_transport"`
+	ReactorGRPCTarget   string `config:"reactor_grpc_target"`
+	ReactorGRPCInsecure bool   `config:"reactor_grpc_insecure"`
+
+	// JSONCodec selects the JSON implementation the reactor proxy path encodes/decodes with:
+	// "stdlib" (default, also the zero-value behavior for Config literals built without
+	// defaultConfig) uses encoding/json; "jsoniter" uses jsoniter.ConfigCompatibleWithStandardLibrary
+	// (api.ternary.fission.jsoncodec.go) for faster marshaling on the /api/v1/status and
+	// /api/v1/energy-fields hot paths without changing wire output
+	JSONCodec string `config:"json_codec"`
+
+	// K8sAPIEnabled mounts the Kubernetes-shaped EnergyField/FissionEvent/status read surface
+	// (api.ternary.fission.k8sapi.go) under /apis/ternary.fission.io/v1 alongside the existing
+	// /api/v1 routes; see that file's header for why it's a mux-mounted compatibility surface
+	// rather than a real k8s.io/apiserver aggregation
+	K8sAPIEnabled bool `config:"k8s_api_enabled"`
 
 	// WebSocket settings
 	WebSocketEnabled      bool `config:"websocket_enabled"`
@@ -77,45 +220,208 @@ type Config struct {
 	WebSocketTimeout      int  `config:"websocket_timeout"`
 	WebSocketPingInterval int  `config:"websocket_ping_interval"`
 
+	// GraphQL settings: the graphql-transport-ws subprotocol's connection_init handshake
+	// window before the server closes with 4408 Connection initialisation timeout
+	GraphQLConnectionAckWaitTimeoutSeconds int `config:"graphql_connection_ack_wait_timeout_seconds"`
+
+	// Monitor settings: the ternary-monitor-v1 subprotocol's own connection_init handshake
+	// window, kept separate from GraphQLConnectionAckWaitTimeoutSeconds so the two transports
+	// can be tuned independently
+	MonitorConnectionAckWaitTimeoutSeconds int `config:"monitor_connection_ack_wait_timeout_seconds"`
+
+	// Event bus settings: the ring-buffered FieldCreated/FieldDissipated/PortalTriggered/
+	// ReactorStatus event log backing GET /api/v1/events
+	EventsBufferCapacity     int `config:"events_buffer_capacity"`
+	EventsIdleTimeoutSeconds int `config:"events_idle_timeout_seconds"`
+
+	// Request timeout settings: the deadline timeoutMiddleware enforces on every
+	// non-streaming route, with optional per-route overrides
+	RequestTimeoutSeconds   int    `config:"request_timeout_seconds"`
+	RequestTimeoutOverrides string `config:"request_timeout_overrides"`
+
 	// Physics simulation settings
 	ParentMass       float64 `config:"parent_mass"`
 	ExcitationEnergy float64 `config:"excitation_energy"`
 	EventsPerSecond  float64 `config:"events_per_second"`
 	MaxEnergyField   float64 `config:"max_energy_field"`
 
-	// Logging settings
+	// ParentNucleusSymbol selects which actinide (see nucdata.ParentPresets) the fragment
+	// synthesizer and decay tracker treat as the fissioning parent; switchable at runtime
+	// via PUT /api/v1/parent-nucleus
+	ParentNucleusSymbol string `config:"parent_nucleus"`
+
+	// NucdataDir points at a directory holding an official AME2020 mass_1.mas20 table
+	// (and optional <Symbol>.ensdf level files); empty uses the embedded fallback table
+	NucdataDir string `config:"nucdata_dir"`
+
+	// Logging settings: LogLevel/VerboseOutput gate what gets logged; the rest select
+	// and configure the structured logger's sinks. LogFormat is "text" (plain), "color"
+	// (alias "console"/"dev", ANSI-colorized for a development terminal), or "json"
+	// (for a production log aggregator)
 	LogLevel      string `config:"log_level"`
 	VerboseOutput bool   `config:"verbose_output"`
 
+	LogFormat         string `config:"log_format"`
+	LogFilePath       string `config:"log_file_path"`
+	LogFileMaxSizeMB  int    `config:"log_file_max_size_mb"`
+	LogFileMaxAgeDays int    `config:"log_file_max_age_days"`
+
+	LogSyslogEnabled bool   `config:"log_syslog_enabled"`
+	LogSyslogNetwork string `config:"log_syslog_network"`
+	LogSyslogAddress string `config:"log_syslog_address"`
+
+	LogLokiEnabled              bool   `config:"log_loki_enabled"`
+	LogLokiURL                  string `config:"log_loki_url"`
+	LogLokiLabels               string `config:"log_loki_labels"`
+	LogLokiBatchSize            int    `config:"log_loki_batch_size"`
+	LogLokiBatchIntervalSeconds int    `config:"log_loki_batch_interval_seconds"`
+
 	// Feature flags
 	PrometheusEnabled   bool `config:"prometheus_enabled"`
 	CORSEnabled         bool `config:"cors_enabled"`
 	RateLimitingEnabled bool `config:"rate_limiting_enabled"`
+
+	// Rate limiting settings: a general per-IP/per-token quota, plus tighter
+	// per-endpoint overrides for expensive mutating operations
+	RateLimitRPS                float64 `config:"rate_limit_rps"`
+	RateLimitBurst              int     `config:"rate_limit_burst"`
+	RateLimitEnergyFieldRPS     float64 `config:"rate_limit_energy_field_rps"`
+	RateLimitEnergyFieldBurst   int     `config:"rate_limit_energy_field_burst"`
+	RateLimitPortalTriggerRPS   float64 `config:"rate_limit_portal_trigger_rps"`
+	RateLimitPortalTriggerBurst int     `config:"rate_limit_portal_trigger_burst"`
+
+	// Authentication settings
+	AuthEnabled       bool   `config:"auth_enabled"`
+	AuthUsersFile     string `config:"auth_users_file"`
+	AuthAPIKeysFile   string `config:"auth_api_keys_file"`
+	SessionCookieName string `config:"session_cookie_name"`
+	SessionTTLMinutes int    `config:"session_ttl_minutes"`
+
+	// CSRF settings: enforced on non-safe methods for browser (session-cookie) callers;
+	// bearer/API-key/Basic callers and CSRFTrustedOrigins are exempt since they aren't
+	// exposed to the cross-site form/fetch attacks CSRF tokens defend against
+	CSRFEnabled        bool   `config:"csrf_enabled"`
+	CSRFTrustedOrigins string `config:"csrf_trusted_origins"`
+
+	// DebugEnabled mounts the pprof/support-bundle/reactor-passthrough endpoints under
+	// /api/v1/debug/, admin-role-gated. It is also AND-ed with main's -allow-profiling flag,
+	// so a config file left over from a dev box can't quietly expose profiling in production
+	DebugEnabled bool `config:"debug_enabled"`
+
+	// Tracing settings (api.ternary.fission.tracing.go): router handlers are wrapped with
+	// otelhttp and outgoing reactor requests carry a W3C traceparent header so Go API spans
+	// line up with reactor-side spans. OTLPSamplerType is one of always_on/always_off/ratio/
+	// parentbased_ratio; OTLPSamplingRatio only applies to the two ratio-based samplers. The
+	// sampler is swapped in place on SIGHUP (reloadTracingSampler), so sampling can be tuned
+	// without restarting the process
+	TracingEnabled    bool    `config:"tracing_enabled"`
+	OTLPEndpoint      string  `config:"otlp_endpoint"`
+	OTLPInsecure      bool    `config:"otlp_insecure"`
+	OTLPSamplerType   string  `config:"otlp_sampler_type"`
+	OTLPSamplingRatio float64 `config:"otlp_sampling_ratio"`
+	OTLPRootCAFile    string  `config:"otlp_root_ca_file"`
+
+	// Time-series retention settings for the Grafana SimpleJSON datasource
+	TSDBFineResolutionSeconds   int `config:"tsdb_fine_resolution_seconds"`
+	TSDBFineRetentionSeconds    int `config:"tsdb_fine_retention_seconds"`
+	TSDBCoarseResolutionSeconds int `config:"tsdb_coarse_resolution_seconds"`
+	TSDBCoarseRetentionSeconds  int `config:"tsdb_coarse_retention_seconds"`
+
+	// Decay-chain settings: which evaluated nuclear data library backs nuclide
+	// half-life/branching lookups, and the averaging window for the decay heat rate
+	DecayChainDataSource   string `config:"decay_chain_data_source"`
+	DecayHeatWindowSeconds int    `config:"decay_heat_window_seconds"`
+
+	// Web Push settings (api.ternary.fission.push.go): VAPID keys are loaded from config if
+	// present, generated and logged on first run otherwise. Each threshold is disabled by
+	// leaving it at its zero value.
+	PushEnabled                 bool    `config:"push_enabled"`
+	PushVAPIDPublicKey          string  `config:"push_vapid_public_key"`
+	PushVAPIDPrivateKey         string  `config:"push_vapid_private_key"`
+	PushVAPIDSubject            string  `config:"push_vapid_subject"`
+	PushActiveFieldsThreshold   int     `config:"push_active_fields_threshold"`
+	PushTotalEnergyThresholdMeV float64 `config:"push_total_energy_threshold_mev"`
+	PushCPUPercentThreshold     float64 `config:"push_cpu_percent_threshold"`
+	PushMemoryPercentThreshold  float64 `config:"push_memory_percent_threshold"`
+	PushPortalActiveAlert       bool    `config:"push_portal_active_alert"`
 }
 
 // We provide default configuration values with port 8238
 func defaultConfig() *Config {
 	return &Config{
-		APIPort:                  8238,
-		APIHost:                  "0.0.0.0",
-		APITimeout:               30,
-		MaxRequestSize:           10485760,
-		MaxConcurrentConnections: 1000,
-		ReactorBaseURL:           "http://127.0.0.1:8333",
-		StatusPollInterval:       15,
-		WebSocketEnabled:         true,
-		WebSocketBufferSize:      4096,
-		WebSocketTimeout:         300,
-		WebSocketPingInterval:    30,
-		ParentMass:               235.0,
-		ExcitationEnergy:         6.5,
-		EventsPerSecond:          5.0,
-		MaxEnergyField:           1000.0,
-		LogLevel:                 "info",
-		VerboseOutput:            false,
-		PrometheusEnabled:        true,
-		CORSEnabled:              true,
-		RateLimitingEnabled:      true,
+		APIPort:                                8238,
+		APIHost:                                "0.0.0.0",
+		APITimeout:                             30,
+		MaxRequestSize:                         10485760,
+		MaxConcurrentConnections:               1000,
+		ReactorBaseURL:                         "http://127.0.0.1:8333",
+		StatusPollInterval:                     15,
+		ReactorInterceptors:                    "retry,redirect,circuit_breaker,cache,metrics,auth",
+		ReactorRetryMaxAttempts:                3,
+		ReactorRetryBaseDelayMS:                100,
+		ReactorRetryMaxDelayMS:                 5000,
+		ReactorMaxRedirections:                 5,
+		ReactorCircuitBreakerThreshold:         5,
+		ReactorCircuitBreakerOpenSeconds:       30,
+		ReactorCircuitBreakerWindowSize:        20,
+		ReactorCircuitBreakerFailureRatio:      0.5,
+		ReactorCacheTTLSeconds:                 2,
+		ReactorTransport:                       "http",
+		ReactorGRPCTarget:                      "127.0.0.1:8334",
+		JSONCodec:                              "stdlib",
+		K8sAPIEnabled:                          false,
+		WebSocketEnabled:                       true,
+		WebSocketBufferSize:                    4096,
+		WebSocketTimeout:                       300,
+		WebSocketPingInterval:                  30,
+		GraphQLConnectionAckWaitTimeoutSeconds: 10,
+		MonitorConnectionAckWaitTimeoutSeconds: 10,
+		EventsBufferCapacity:                   1024,
+		EventsIdleTimeoutSeconds:               300,
+		RequestTimeoutSeconds:                  30,
+		ParentMass:                             235.0,
+		ExcitationEnergy:                       6.5,
+		EventsPerSecond:                        5.0,
+		MaxEnergyField:                         1000.0,
+		ParentNucleusSymbol:                    "U-235",
+		NucdataDir:                             "",
+		LogLevel:                               "info",
+		VerboseOutput:                          false,
+		LogFormat:                              "text",
+		LogFileMaxSizeMB:                       100,
+		LogFileMaxAgeDays:                      14,
+		LogSyslogNetwork:                       "",
+		LogSyslogAddress:                       "",
+		LogLokiLabels:                          "job=ternary-fission-api",
+		LogLokiBatchSize:                       100,
+		LogLokiBatchIntervalSeconds:            5,
+		PrometheusEnabled:                      true,
+		CORSEnabled:                            true,
+		RateLimitingEnabled:                    true,
+		RateLimitRPS:                           10.0,
+		RateLimitBurst:                         20,
+		RateLimitEnergyFieldRPS:                2.0,
+		RateLimitEnergyFieldBurst:              5,
+		RateLimitPortalTriggerRPS:              1.0,
+		RateLimitPortalTriggerBurst:            2,
+		AuthEnabled:                            false,
+		AuthUsersFile:                          "configs/users.conf",
+		AuthAPIKeysFile:                        "configs/api_keys.conf",
+		SessionCookieName:                      "tfs_session",
+		SessionTTLMinutes:                      60,
+		CSRFEnabled:                            true,
+		DebugEnabled:                           false,
+		TracingEnabled:                         false,
+		OTLPSamplerType:                        "parentbased_ratio",
+		OTLPSamplingRatio:                      1.0,
+		TSDBFineResolutionSeconds:              1,
+		TSDBFineRetentionSeconds:               3600,
+		TSDBCoarseResolutionSeconds:            60,
+		TSDBCoarseRetentionSeconds:             86400,
+		DecayChainDataSource:                   "jeff-3.3",
+		DecayHeatWindowSeconds:                 60,
+		PushEnabled:                            false,
+		PushVAPIDSubject:                       "mailto:admin@example.com",
 	}
 }
 
@@ -171,6 +477,65 @@ func parseConfigFile(filename string) (*Config, error) {
 		case "reactor_base_url":
 			// Base URL for the backing reactor service
 			config.ReactorBaseURL = value
+		case "reactor_endpoints":
+			// Comma-separated list of additional reactor instances for scrape
+			// target discovery and remote_write sample collection
+			config.ReactorEndpoints = parseReactorEndpoints(value)
+		case "remote_write_url":
+			config.RemoteWriteURL = value
+		case "reactor_interceptors":
+			// Comma-separated, outermost first; see interceptorFactories
+			config.ReactorInterceptors = value
+		case "reactor_retry_max_attempts":
+			if n, err := strconv.Atoi(value); err == nil {
+				config.ReactorRetryMaxAttempts = n
+			}
+		case "reactor_retry_base_delay_ms":
+			if ms, err := strconv.Atoi(value); err == nil {
+				config.ReactorRetryBaseDelayMS = ms
+			}
+		case "reactor_retry_max_delay_ms":
+			if ms, err := strconv.Atoi(value); err == nil {
+				config.ReactorRetryMaxDelayMS = ms
+			}
+		case "reactor_max_redirections":
+			if n, err := strconv.Atoi(value); err == nil {
+				config.ReactorMaxRedirections = n
+			}
+		case "reactor_circuit_breaker_threshold":
+			if n, err := strconv.Atoi(value); err == nil {
+				config.ReactorCircuitBreakerThreshold = n
+			}
+		case "reactor_circuit_breaker_open_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.ReactorCircuitBreakerOpenSeconds = seconds
+			}
+		case "reactor_circuit_breaker_window_size":
+			if n, err := strconv.Atoi(value); err == nil {
+				config.ReactorCircuitBreakerWindowSize = n
+			}
+		case "reactor_circuit_breaker_failure_ratio":
+			if ratio, err := strconv.ParseFloat(value, 64); err == nil {
+				config.ReactorCircuitBreakerFailureRatio = ratio
+			}
+		case "reactor_cache_ttl_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.ReactorCacheTTLSeconds = seconds
+			}
+		case "reactor_auth_token":
+			config.ReactorAuthToken = value
+		case "reactor_transport":
+			// "http" (default) or "grpc"; see NewTernaryFissionAPIServer
+			config.ReactorTransport = value
+		case "reactor_grpc_target":
+			config.ReactorGRPCTarget = value
+		case "reactor_grpc_insecure":
+			config.ReactorGRPCInsecure = (strings.ToLower(value) == "true")
+		case "json_codec":
+			// "stdlib" (default) or "jsoniter"; see jsonCodecFor in api.ternary.fission.jsoncodec.go
+			config.JSONCodec = value
+		case "k8s_api_enabled":
+			config.K8sAPIEnabled = (strings.ToLower(value) == "true")
 		case "status_poll_interval":
 			if interval, err := strconv.Atoi(value); err == nil {
 				config.StatusPollInterval = interval
@@ -183,6 +548,10 @@ func parseConfigFile(filename string) (*Config, error) {
 			if mass, err := strconv.ParseFloat(value, 64); err == nil {
 				config.ParentMass = mass
 			}
+		case "parent_nucleus":
+			config.ParentNucleusSymbol = value
+		case "nucdata_dir":
+			config.NucdataDir = value
 		case "excitation_energy":
 			if energy, err := strconv.ParseFloat(value, 64); err == nil {
 				config.ExcitationEnergy = energy
@@ -195,14 +564,172 @@ func parseConfigFile(filename string) (*Config, error) {
 			config.LogLevel = value
 		case "verbose_output":
 			config.VerboseOutput = (strings.ToLower(value) == "true")
+		case "log_format":
+			config.LogFormat = value
+		case "log_file_path":
+			config.LogFilePath = value
+		case "log_file_max_size_mb":
+			if size, err := strconv.Atoi(value); err == nil {
+				config.LogFileMaxSizeMB = size
+			}
+		case "log_file_max_age_days":
+			if days, err := strconv.Atoi(value); err == nil {
+				config.LogFileMaxAgeDays = days
+			}
+		case "log_syslog_enabled":
+			config.LogSyslogEnabled = (strings.ToLower(value) == "true")
+		case "log_syslog_network":
+			config.LogSyslogNetwork = value
+		case "log_syslog_address":
+			config.LogSyslogAddress = value
+		case "log_loki_enabled":
+			config.LogLokiEnabled = (strings.ToLower(value) == "true")
+		case "log_loki_url":
+			config.LogLokiURL = value
+		case "log_loki_labels":
+			config.LogLokiLabels = value
+		case "log_loki_batch_size":
+			if size, err := strconv.Atoi(value); err == nil {
+				config.LogLokiBatchSize = size
+			}
+		case "log_loki_batch_interval_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.LogLokiBatchIntervalSeconds = seconds
+			}
 		case "websocket_enabled":
 			config.WebSocketEnabled = (strings.ToLower(value) == "true")
+		case "graphql_connection_ack_wait_timeout_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.GraphQLConnectionAckWaitTimeoutSeconds = seconds
+			}
+		case "monitor_connection_ack_wait_timeout_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.MonitorConnectionAckWaitTimeoutSeconds = seconds
+			}
+		case "events_buffer_capacity":
+			if capacity, err := strconv.Atoi(value); err == nil {
+				config.EventsBufferCapacity = capacity
+			}
+		case "events_idle_timeout_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.EventsIdleTimeoutSeconds = seconds
+			}
+		case "request_timeout_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.RequestTimeoutSeconds = seconds
+			}
+		case "request_timeout_overrides":
+			config.RequestTimeoutOverrides = value
 		case "prometheus_enabled":
 			config.PrometheusEnabled = (strings.ToLower(value) == "true")
 		case "cors_enabled":
 			config.CORSEnabled = (strings.ToLower(value) == "true")
 		case "rate_limiting_enabled":
 			config.RateLimitingEnabled = (strings.ToLower(value) == "true")
+		case "rate_limit_rps":
+			if rps, err := strconv.ParseFloat(value, 64); err == nil {
+				config.RateLimitRPS = rps
+			}
+		case "rate_limit_burst":
+			if burst, err := strconv.Atoi(value); err == nil {
+				config.RateLimitBurst = burst
+			}
+		case "rate_limit_energy_field_rps":
+			if rps, err := strconv.ParseFloat(value, 64); err == nil {
+				config.RateLimitEnergyFieldRPS = rps
+			}
+		case "rate_limit_energy_field_burst":
+			if burst, err := strconv.Atoi(value); err == nil {
+				config.RateLimitEnergyFieldBurst = burst
+			}
+		case "rate_limit_portal_trigger_rps":
+			if rps, err := strconv.ParseFloat(value, 64); err == nil {
+				config.RateLimitPortalTriggerRPS = rps
+			}
+		case "rate_limit_portal_trigger_burst":
+			if burst, err := strconv.Atoi(value); err == nil {
+				config.RateLimitPortalTriggerBurst = burst
+			}
+		case "auth_enabled":
+			config.AuthEnabled = (strings.ToLower(value) == "true")
+		case "auth_users_file":
+			config.AuthUsersFile = value
+		case "auth_api_keys_file":
+			config.AuthAPIKeysFile = value
+		case "session_cookie_name":
+			config.SessionCookieName = value
+		case "session_ttl_minutes":
+			if minutes, err := strconv.Atoi(value); err == nil {
+				config.SessionTTLMinutes = minutes
+			}
+		case "csrf_enabled":
+			config.CSRFEnabled = (strings.ToLower(value) == "true")
+		case "csrf_trusted_origins":
+			config.CSRFTrustedOrigins = value
+		case "debug_enabled":
+			config.DebugEnabled = (strings.ToLower(value) == "true")
+		case "tracing_enabled":
+			config.TracingEnabled = (strings.ToLower(value) == "true")
+		case "otlp_endpoint":
+			config.OTLPEndpoint = value
+		case "otlp_insecure":
+			config.OTLPInsecure = (strings.ToLower(value) == "true")
+		case "otlp_sampler_type":
+			config.OTLPSamplerType = value
+		case "otlp_sampling_ratio":
+			if ratio, err := strconv.ParseFloat(value, 64); err == nil {
+				config.OTLPSamplingRatio = ratio
+			}
+		case "otlp_root_ca_file":
+			config.OTLPRootCAFile = value
+		case "tsdb_fine_resolution_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.TSDBFineResolutionSeconds = seconds
+			}
+		case "tsdb_fine_retention_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.TSDBFineRetentionSeconds = seconds
+			}
+		case "tsdb_coarse_resolution_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.TSDBCoarseResolutionSeconds = seconds
+			}
+		case "tsdb_coarse_retention_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.TSDBCoarseRetentionSeconds = seconds
+			}
+		case "decay_chain_data_source":
+			config.DecayChainDataSource = value
+		case "decay_heat_window_seconds":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				config.DecayHeatWindowSeconds = seconds
+			}
+		case "push_enabled":
+			config.PushEnabled = (strings.ToLower(value) == "true")
+		case "push_vapid_public_key":
+			config.PushVAPIDPublicKey = value
+		case "push_vapid_private_key":
+			config.PushVAPIDPrivateKey = value
+		case "push_vapid_subject":
+			config.PushVAPIDSubject = value
+		case "push_active_fields_threshold":
+			if n, err := strconv.Atoi(value); err == nil {
+				config.PushActiveFieldsThreshold = n
+			}
+		case "push_total_energy_threshold_mev":
+			if mev, err := strconv.ParseFloat(value, 64); err == nil {
+				config.PushTotalEnergyThresholdMeV = mev
+			}
+		case "push_cpu_percent_threshold":
+			if percent, err := strconv.ParseFloat(value, 64); err == nil {
+				config.PushCPUPercentThreshold = percent
+			}
+		case "push_memory_percent_threshold":
+			if percent, err := strconv.ParseFloat(value, 64); err == nil {
+				config.PushMemoryPercentThreshold = percent
+			}
+		case "push_portal_active_alert":
+			config.PushPortalActiveAlert = (strings.ToLower(value) == "true")
 		}
 	}
 
@@ -219,6 +746,12 @@ type EnergyFieldRequest struct {
 	DissipationRounds int     `json:"dissipation_rounds,omitempty"`
 	FieldName         string  `json:"field_name,omitempty"`
 	AutoDissipate     bool    `json:"auto_dissipate,omitempty"`
+	PhysicsListName   string  `json:"physics_list_name,omitempty"`
+
+	// TrialWavefunctionName selects a registered psi_T (see trialWavefunctionFactories in
+	// api.ternary.fission.montecarlo.go, e.g. "hydrogenic_1s", "slater_jastrow") for this
+	// field's local-energy estimator; empty uses defaultTrialWavefunctionName
+	TrialWavefunctionName string `json:"trial_wavefunction,omitempty"`
 }
 
 // We define the API response structure for energy field status
@@ -236,22 +769,35 @@ type EnergyFieldResponse struct {
 	CreatedAt           time.Time `json:"created_at"`
 	LastUpdated         time.Time `json:"last_updated"`
 	Status              string    `json:"status"`
+
+	// Local-energy VMC estimate (see api.ternary.fission.montecarlo.go), populated once the
+	// field has completed at least one POST .../dissipate round
+	LocalEnergyMeanMeV  float64 `json:"local_energy_mean,omitempty"`
+	LocalEnergyVariance float64 `json:"local_energy_variance,omitempty"`
+	AcceptanceRatio     float64 `json:"acceptance_ratio,omitempty"`
+	NWalkers            int     `json:"n_walkers,omitempty"`
 }
 
 // We define system status response
 type SystemStatusResponse struct {
-	UptimeSeconds        int64   `json:"uptime_seconds"`
-	TotalFissionEvents   uint64  `json:"total_fission_events"`
-	TotalEnergySimulated float64 `json:"total_energy_simulated_mev"`
-	ActiveEnergyFields   int     `json:"active_energy_fields"`
-        PeakMemoryUsage      uint64  `json:"peak_memory_usage_bytes"`
-        AverageCalcTime      float64 `json:"average_calculation_time_microseconds"`
-        TotalCalculations    uint64  `json:"total_calculations"`
-        SimulationRunning    bool    `json:"simulation_running"`
-        CPUUsagePercent      float64 `json:"cpu_usage_percent"`
-        MemoryUsagePercent   float64 `json:"memory_usage_percent"`
-        EstimatedPower       float64 `json:"estimated_power_mev"`
-        PortalDurationRemain int     `json:"portal_duration_remaining_seconds"`
+	UptimeSeconds         int64   `json:"uptime_seconds"`
+	TotalFissionEvents    uint64  `json:"total_fission_events"`
+	TotalEnergySimulated  float64 `json:"total_energy_simulated_mev"`
+	ActiveEnergyFields    int     `json:"active_energy_fields"`
+	PeakMemoryUsage       uint64  `json:"peak_memory_usage_bytes"`
+	AverageCalcTime       float64 `json:"average_calculation_time_microseconds"`
+	TotalCalculations     uint64  `json:"total_calculations"`
+	SimulationRunning     bool    `json:"simulation_running"`
+	CPUUsagePercent       float64 `json:"cpu_usage_percent"`
+	MemoryUsagePercent    float64 `json:"memory_usage_percent"`
+	EstimatedPower        float64 `json:"estimated_power_mev"`
+	PortalDurationRemain  int     `json:"portal_duration_remaining_seconds"`
+	DecayHeatMeVPerSecond float64 `json:"decay_heat_mev_per_second"`
+
+	// TimestampUnix is stamped locally (the reactor's response carries no timestamp of its
+	// own) when the status is fetched, so a reconnecting ternary-monitor-v1 client can send
+	// it back as connection_init.payload.since to request replay of anything it missed
+	TimestampUnix int64 `json:"timestamp_unix"`
 }
 
 // =============================================================================
@@ -273,9 +819,95 @@ type TernaryFissionAPIServer struct {
 	responseTime        *prometheus.HistogramVec
 	reactorActiveFields prometheus.Gauge
 	reactorTotalEnergy  prometheus.Gauge
-
-	// Reactor communication
-	reactorClient *http.Client
+	rateLimitHits       *prometheus.CounterVec
+	reactorTimeouts     *prometheus.CounterVec
+
+	// requestTimeoutOverrides parses Config.RequestTimeoutOverrides (path=seconds pairs);
+	// a path absent here falls back to Config.RequestTimeoutSeconds
+	requestTimeoutOverrides map[string]int
+
+	// Reactor communication: reactorClient is a ReactorClient (api.ternary.fission.reactorclient.go)
+	// so it can be either a *ReactorDispatcher - wrapping the configurable retry/redirect/
+	// circuit-breaker/cache/metrics/auth interceptor chain over HTTP - or, when
+	// Config.ReactorTransport is "grpc", a *grpcReactorClient
+	// (api.ternary.fission.grpcclient.go) translating the same Do/Get calls onto the
+	// ReactorTransport gRPC service instead
+	reactorClient ReactorClient
+
+	// remoteWriteClient is a plain *http.Client used only for pushRemoteWrite
+	// (api.ternary.fission.metrics.go). Config.RemoteWriteURL is an arbitrary
+	// third-party Prometheus endpoint, not the reactor, so this deliberately
+	// bypasses reactorClient's auth-injection, retry, and circuit-breaker
+	// interceptors rather than reusing it
+	remoteWriteClient *http.Client
+
+	// jsonCodec encodes/decodes the reactor proxy path's JSON bodies (fetchSystemStatus's
+	// decode, writeJSONResponse's encode, and the gRPC reactor client's jsonResponse marshal);
+	// resolved from Config.JSONCodec by jsonCodecFor (api.ternary.fission.jsoncodec.go),
+	// defaulting to stdlibJSONCodec for "", "stdlib", or any unrecognized value
+	jsonCodec JSONCodec
+
+	// Authentication
+	auth *AuthManager
+
+	// CSRF protection for session-cookie (browser) callers on non-safe methods
+	csrf *CSRFGuard
+
+	// Time-series retention for the Grafana SimpleJSON datasource
+	tsdb *TimeSeriesStore
+
+	// Decay-chain tracking: queues synthesized fragments as nuclides and walks
+	// them through exponentially-sampled beta/gamma decays to a stable isotope
+	decayTracker *DecayChainTracker
+
+	// Physics-list registry: named, operator-composable sets of physics processes
+	physicsLists *PhysicsListRegistry
+
+	// Local-energy registry: one Metropolis-Hastings VMC estimator per active energy
+	// field, sampled on each POST .../dissipate round
+	localEnergy *LocalEnergyRegistry
+
+	// Monitor transport negotiator: the single reactor-polling goroutine backing
+	// /ws/monitor, /api/v1/monitor/sse, and /api/v1/monitor/poll
+	monitor *MonitorTransportNegotiator
+
+	// Buffered event bus backing GET /api/v1/events: FieldCreated/FieldDissipated/
+	// PortalTriggered are published by the corresponding reactor-forwarding handlers,
+	// and ReactorStatus is republished by the monitor negotiator on every poll tick
+	events *events.BufferedSubscription
+
+	// Web Push: subscriptions plus the VAPID key pair and threshold-watching dispatcher
+	// that sends to them; all nil when Config.PushEnabled is false
+	pushStore      PushStore
+	pushVAPIDKeys  *vapidKeyPair
+	pushDispatcher *PushDispatcher
+
+	// Nuclide data service backed by AME2020/ENSDF (or the embedded fallback table);
+	// the active parent nucleus is switchable at runtime via PUT /api/v1/parent-nucleus
+	nuclides            *nucdata.Index
+	parentNucleusMu     sync.RWMutex
+	activeParentNucleus string
+
+	// Page rendering and static assets
+	frontend *frontend.FrontendService
+
+	// Structured logging; request-scoped fields are attached via logger.With()
+	logger *logging.Logger
+
+	// Rate limiting: a general per-IP/per-token limiter plus tighter overrides
+	// for expensive mutating endpoints
+	rateLimiter          *RateLimiter
+	energyFieldLimiter   *RateLimiter
+	portalTriggerLimiter *RateLimiter
+
+	// OpenTelemetry tracing: traceSampler is swapped in place on SIGHUP so sampling
+	// configuration can be retuned without restarting the process; tracingShutdown flushes
+	// and closes the OTLP exporter during Start()'s shutdown sequence. Both are nil when
+	// Config.TracingEnabled is false. configPath is the file main() loaded at startup, kept
+	// around so the SIGHUP handler can re-parse it
+	traceSampler    *reloadableSampler
+	tracingShutdown func(context.Context) error
+	configPath      string
 
 	// System control
 	shutdownChan chan os.Signal
@@ -291,17 +923,122 @@ func NewTernaryFissionAPIServer(config *Config) *TernaryFissionAPIServer {
 		config:            config,
 		router:            mux.NewRouter(),
 		activeConnections: make(map[string]*websocket.Conn),
-		reactorClient:     &http.Client{Timeout: time.Duration(config.APITimeout) * time.Second},
 		shutdownChan:      make(chan os.Signal, 1),
 		ctx:               ctx,
 		cancelFunc:        cancel,
 		startTime:         time.Now(),
 	}
 
-	// We configure WebSocket upgrader with proper settings
+	// We stand up structured logging first so every subsequent initialization step
+	// can log through it instead of the standard log package
+	server.initializeLogging()
+
+	// We stand up tracing before the reactor dispatcher, since the dispatcher's "tracing"
+	// interceptor (added automatically when enabled) needs the global TracerProvider and
+	// propagator already configured
+	shutdownTracing, err := server.initTracing()
+	if err != nil {
+		server.logger.Error("Failed to initialize tracing; continuing without it", logging.Fields{"error": err.Error()})
+	} else {
+		server.tracingShutdown = shutdownTracing
+	}
+
+	// jsonCodecFor treats "" the same as "stdlib", so a *Config built as a raw struct literal
+	// (bypassing defaultConfig, as several existing tests do) still gets stdlib encoding/json
+	server.jsonCodec = jsonCodecFor(config.JSONCodec)
+
+	// We build the reactor dispatcher right after logging so its interceptor chain
+	// (api.ternary.fission.reactorclient.go) can log an unknown-interceptor-name warning.
+	// Config.ReactorTransport == "grpc" swaps in grpcReactorClient instead; a dial failure
+	// falls back to the HTTP dispatcher rather than failing startup, the same pattern
+	// initTracing's failure path above uses
+	server.reactorClient = NewReactorDispatcher(server)
+	if strings.EqualFold(config.ReactorTransport, "grpc") {
+		grpcClient, err := newGRPCReactorClient(server)
+		if err != nil {
+			server.logger.Error("Failed to dial reactor over gRPC; falling back to HTTP", logging.Fields{
+				"reactor_grpc_target": config.ReactorGRPCTarget,
+				"error":               err.Error(),
+			})
+		} else {
+			server.reactorClient = grpcClient
+		}
+	}
+
+	// remoteWriteClient deliberately does not share reactorClient's interceptor chain (see
+	// the field's doc comment); it gets its own plain timeout derived from Config.APITimeout
+	server.remoteWriteClient = &http.Client{Timeout: time.Duration(config.APITimeout) * time.Second}
+
+	// We initialize the auth subsystem before routes are wired so middleware can reference it
+	server.auth = NewAuthManager(config)
+	server.csrf = NewCSRFGuard(config)
+
+	// We initialize the in-process time-series store used by the Grafana SimpleJSON datasource
+	server.tsdb = NewTimeSeriesStore(config)
+
+	// We initialize the decay-chain tracker that turns synthesized fragments into
+	// decaying nuclides, feeding the "decay heat" metric and /api/v1/decay-chains
+	server.decayTracker = NewDecayChainTracker(config, selectDecayDataSource(config))
+
+	// We initialize the physics-list registry with its FTFP_TERNARY_DEFAULT list active
+	server.physicsLists = NewPhysicsListRegistry()
+
+	// We initialize the local-energy registry; estimators are added as fields are created
+	server.localEnergy = NewLocalEnergyRegistry()
+
+	// We build the buffered event bus before the monitor negotiator, which republishes
+	// every reactor-status poll tick into it as a ReactorStatus event
+	server.events = events.New(config.EventsBufferCapacity)
+
+	// We start the monitor transport negotiator's reactor-polling goroutine; it runs for
+	// the server's lifetime regardless of whether any client is currently connected
+	server.monitor = NewMonitorTransportNegotiator(server)
+
+	// We stand up Web Push last among the monitor-feeding subsystems, since
+	// PushDispatcher subscribes to the negotiator we just started
+	if config.PushEnabled {
+		server.pushStore = newMemoryPushStore()
+		vapidKeys, err := loadOrGenerateVAPIDKeys(config, server.logger)
+		if err != nil {
+			server.logger.Error("Failed to initialize VAPID keys; push notifications disabled", logging.Fields{"error": err.Error()})
+		} else {
+			server.pushVAPIDKeys = vapidKeys
+			server.pushDispatcher = NewPushDispatcher(server, server.pushStore, vapidKeys)
+		}
+	}
+
+	// We load the nuclide data service: an operator-supplied AME2020/ENSDF directory if
+	// configured, otherwise the checksum-verified embedded fallback table
+	nuclides, err := nucdata.LoadIndex(config.NucdataDir)
+	if err != nil {
+		server.logger.Error("Failed to load nuclide data", logging.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
+	server.nuclides = nuclides
+	server.activeParentNucleus = config.ParentNucleusSymbol
+
+	// We build the frontend service from embedded templates/static assets; a failure here
+	// means the embedded templates are malformed, which is a build-time bug, not a runtime one
+	frontendService, err := frontend.New()
+	if err != nil {
+		server.logger.Error("Failed to initialize frontend service", logging.Fields{"error": err.Error()})
+		os.Exit(1)
+	}
+	server.frontend = frontendService
+
+	// We always build the rate limiter; RateLimitingEnabled is checked per-request
+	// so enforcement can be toggled without restructuring the middleware chain
+	server.initializeRateLimiting()
+	server.initializeRequestTimeouts()
+
+	// We configure WebSocket upgrader, restricting upgrades to same-host origins
 	server.websocketUpgrader = websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
-			return true
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				return true
+			}
+			return strings.Contains(origin, r.Host)
 		},
 		ReadBufferSize:  config.WebSocketBufferSize,
 		WriteBufferSize: config.WebSocketBufferSize,
@@ -328,11 +1065,58 @@ func NewTernaryFissionAPIServer(config *Config) *TernaryFissionAPIServer {
 	// We set up graceful shutdown handling
 	signal.Notify(server.shutdownChan, syscall.SIGINT, syscall.SIGTERM)
 
-	log.Printf("Ternary Fission API Server initialized on port %d", config.APIPort)
+	server.logger.Info("Ternary Fission API Server initialized", logging.Fields{"port": config.APIPort})
 	return server
 }
 
 // We initialize Prometheus metrics for performance monitoring
+// parseLokiLabels accepts a comma-separated list of key=value pairs from the config
+// file, mirroring parseReactorEndpoints's comma-split-and-trim style.
+func parseLokiLabels(value string) map[string]string {
+	labels := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return labels
+}
+
+// initializeLogging builds the structured logger from the configured sinks (stdout is
+// always on; file/syslog/Loki are opt-in), so every later initialization step and every
+// request can log through it instead of the standard log package.
+func (s *TernaryFissionAPIServer) initializeLogging() {
+	logger, err := logging.New(logging.Config{
+		Level:                    s.config.LogLevel,
+		Format:                   s.config.LogFormat,
+		Stdout:                   true,
+		FilePath:                 s.config.LogFilePath,
+		FileMaxSizeMB:            s.config.LogFileMaxSizeMB,
+		FileMaxAgeDays:           s.config.LogFileMaxAgeDays,
+		SyslogEnabled:            s.config.LogSyslogEnabled,
+		SyslogNetwork:            s.config.LogSyslogNetwork,
+		SyslogAddress:            s.config.LogSyslogAddress,
+		SyslogTag:                "ternary-fission-api",
+		LokiEnabled:              s.config.LogLokiEnabled,
+		LokiURL:                  s.config.LogLokiURL,
+		LokiLabels:               parseLokiLabels(s.config.LogLokiLabels),
+		LokiBatchSize:            s.config.LogLokiBatchSize,
+		LokiBatchIntervalSeconds: s.config.LogLokiBatchIntervalSeconds,
+	})
+	if err != nil {
+		// A misconfigured sink (e.g. an unwritable log file path) is a startup-time
+		// bug in the operator's config, not something we can run degraded through
+		log.Fatalf("Failed to initialize structured logging: %v", err)
+	}
+	s.logger = logger
+}
+
 func (s *TernaryFissionAPIServer) initializeMetrics() {
 	s.requestCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -404,10 +1188,23 @@ func (s *TernaryFissionAPIServer) updateReactorMetrics() {
 	}
 	s.reactorActiveFields.Set(float64(status.ActiveEnergyFields))
 	s.reactorTotalEnergy.Set(status.TotalEnergySimulated)
+
+	s.pushRemoteWrite(float64(status.ActiveEnergyFields), status.TotalEnergySimulated)
+	s.recordTSDBSample(status, time.Now())
+	parentZ, parentMass := s.parentNucleusZA()
+	s.decayTracker.IngestStatus(status, parentMass, parentZ, time.Now())
 }
 
 // We set up all HTTP routes and middleware with proper error handling
 func (s *TernaryFissionAPIServer) setupRoutes() {
+	// Tracing wraps everything else so a span covers the full request, including the
+	// logging/metrics/rate-limit middleware below it
+	if s.config.TracingEnabled {
+		s.router.Use(func(next http.Handler) http.Handler {
+			return otelhttp.NewHandler(next, "ternary-fission-api")
+		})
+	}
+
 	// We add middleware for logging and metrics
 	s.router.Use(s.loggingMiddleware)
 	if s.config.PrometheusEnabled {
@@ -416,13 +1213,47 @@ func (s *TernaryFissionAPIServer) setupRoutes() {
 	if s.config.CORSEnabled {
 		s.router.Use(s.corsMiddleware)
 	}
+	if s.config.CSRFEnabled {
+		s.router.Use(s.csrfMiddleware)
+	}
+	s.router.Use(s.rateLimitMiddleware)
+	s.router.Use(s.timeoutMiddleware)
+
+	s.router.HandleFunc("/api/v1/portal/trigger", s.rateLimitEndpoint(s.portalTriggerLimiter, "portal_trigger", s.requireRole(RoleOperator, s.triggerPortalSimulation))).Methods("PUT")
+	s.router.HandleFunc("/graphql", s.requireRole(RoleViewer, s.handleGraphQLWebSocket))
+	s.router.HandleFunc("/login", s.serveLogin).Methods("GET")
+	s.router.HandleFunc("/login", s.handleLoginSubmit).Methods("POST")
+	s.router.HandleFunc("/logout", s.handleLogout).Methods("GET", "POST")
+
+	// We serve the frontend's embedded CSS/JS under /static/, cache-busted by content hash
+	s.router.PathPrefix("/static/").Handler(s.frontend.StaticHandler()).Methods("GET")
+
+	// We serve the push-notification service worker at root scope, outside /static/, so it
+	// can intercept push events for the whole site
+	if s.config.PushEnabled {
+		s.router.HandleFunc("/sw.js", s.frontend.ServiceWorkerHandler().ServeHTTP).Methods("GET")
+	}
 
-    s.router.HandleFunc("/api/v1/portal/trigger", s.triggerPortalSimulation).Methods("PUT")
-
-        // We serve the enhanced web dashboard at root - FIXED routing
-	s.router.PathPrefix("/").HandlerFunc(s.routeHandler).Methods("GET")
+	// We serve the enhanced web dashboard at root - FIXED routing
+	// We intentionally do not restrict Methods() here: routeHandler/handleAPIRoutes
+	// dispatch on r.Method themselves (POST/DELETE energy-fields, etc.), and a
+	// GET-only catch-all previously made those mutating routes unreachable.
+	s.router.PathPrefix("/").HandlerFunc(s.routeHandler)
+
+	// K8sAPIEnabled mounts api.ternary.fission.k8sapi.go's mux-routed compatibility surface,
+	// not a real k8s.io/apiserver aggregation (see that file's Scope note) - kubectl and real
+	// APIService aggregation will not work against it. We log this at startup, not just in a
+	// source comment, so an operator who enables it sees the gap without reading the Go source.
+	if s.config.K8sAPIEnabled {
+		s.logger.Warn("Kubernetes-shaped API surface enabled; this is a mux-routed read-only "+
+			"compatibility shim (no TLS, no registry.Store, no APIGroupList/APIGroupDiscovery), "+
+			"not a real k8s.io/apiserver aggregated API server - kubectl and APIService "+
+			"aggregation will not work against it", logging.Fields{
+			"group_version": k8sGroupVersion,
+		})
+	}
 
-	log.Println("API routes configured successfully with enhanced dashboard")
+	s.logger.Info("API routes configured successfully with enhanced dashboard", nil)
 }
 
 // We handle all routing with proper dashboard serving - FIXED
@@ -435,9 +1266,27 @@ func (s *TernaryFissionAPIServer) routeHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// We route the Kubernetes-shaped read surface (api.ternary.fission.k8sapi.go) when enabled
+	if s.config.K8sAPIEnabled && strings.HasPrefix(path, "/apis/"+k8sAPIGroup+"/") {
+		s.handleK8sAPIRoutes(w, r)
+		return
+	}
+
 	// We serve the dashboard for root and dashboard paths
 	if path == "/" || path == "/dashboard" {
-		s.serveDashboard(w, r)
+		s.requireViewerPage(s.serveDashboard)(w, r)
+		return
+	}
+
+	// We serve the portal-trigger page
+	if path == "/dashboard/portal" {
+		s.requireViewerPage(s.servePortalTriggerPage)(w, r)
+		return
+	}
+
+	// We serve the energy-field detail page
+	if strings.HasPrefix(path, "/dashboard/energy-fields/") {
+		s.requireViewerPage(s.serveEnergyFieldDetailPage)(w, r)
 		return
 	}
 
@@ -453,1249 +1302,161 @@ func (s *TernaryFissionAPIServer) handleAPIRoutes(w http.ResponseWriter, r *http
 	case path == "/health":
 		s.healthCheck(w, r)
 	case path == "/status":
-		s.getSystemStatus(w, r)
+		s.requireRole(RoleViewer, s.getSystemStatus)(w, r)
 	case path == "/energy-fields" && r.Method == "GET":
-		s.listEnergyFields(w, r)
+		s.requireRole(RoleViewer, s.listEnergyFields)(w, r)
 	case path == "/energy-fields" && r.Method == "POST":
-		s.createEnergyField(w, r)
+		s.rateLimitEndpoint(s.energyFieldLimiter, "energy_fields_create", s.requireRole(RoleOperator, s.createEnergyField))(w, r)
 	case strings.HasPrefix(path, "/energy-fields/") && r.Method == "GET":
-		s.getEnergyField(w, r)
+		s.requireRole(RoleViewer, s.getEnergyField)(w, r)
 	case strings.HasPrefix(path, "/energy-fields/") && r.Method == "DELETE":
-		s.deleteEnergyField(w, r)
+		s.requireRole(RoleOperator, s.deleteEnergyField)(w, r)
 	case strings.HasPrefix(path, "/energy-fields/") && strings.HasSuffix(path, "/dissipate"):
-		s.dissipateEnergyField(w, r)
+		s.requireRole(RoleOperator, s.dissipateEnergyField)(w, r)
+	case path == "/tokens" && r.Method == "GET":
+		s.requireRole(RoleAdmin, s.listAPITokens)(w, r)
+	case path == "/tokens" && r.Method == "POST":
+		s.requireRole(RoleAdmin, s.createAPIToken)(w, r)
+	case strings.HasPrefix(path, "/tokens/") && r.Method == "DELETE":
+		s.requireRole(RoleAdmin, s.deleteAPIToken)(w, r)
 	case path == "/metrics" && s.config.PrometheusEnabled:
 		promhttp.Handler().ServeHTTP(w, r)
+	case path == "/targets" && s.config.PrometheusEnabled:
+		s.handleScrapeTargets(w, r)
+	case path == "/tsdb/search":
+		s.requireRole(RoleViewer, s.handleTSDBSearch)(w, r)
+	case path == "/tsdb/query":
+		s.requireRole(RoleViewer, s.handleTSDBQuery)(w, r)
+	case path == "/tsdb/annotations":
+		s.requireRole(RoleViewer, s.handleTSDBAnnotations)(w, r)
+	case path == "/decay-chains":
+		s.requireRole(RoleViewer, s.handleDecayChains)(w, r)
+	case strings.HasPrefix(path, "/decay-chains/"):
+		s.requireRole(RoleViewer, s.handleDecayChainDetail)(w, r)
+	case path == "/physics-lists" && r.Method == "GET":
+		s.requireRole(RoleViewer, s.handlePhysicsLists)(w, r)
+	case path == "/physics-lists" && r.Method == "POST":
+		s.requireRole(RoleOperator, s.handlePhysicsLists)(w, r)
+	case strings.HasPrefix(path, "/nuclides/"):
+		s.requireRole(RoleViewer, s.handleNuclideLookup)(w, r)
+	case path == "/parent-nucleus" && r.Method == "GET":
+		s.requireRole(RoleViewer, s.handleParentNucleus)(w, r)
+	case path == "/parent-nucleus" && r.Method == "PUT":
+		s.requireRole(RoleOperator, s.handleParentNucleus)(w, r)
 	case path == "/ws/monitor" && s.config.WebSocketEnabled:
-		s.handleWebSocketConnection(w, r)
+		s.requireRole(RoleViewer, s.handleWebSocketConnection)(w, r)
+	case path == "/monitor/sse" && s.config.WebSocketEnabled:
+		s.requireRole(RoleViewer, s.handleMonitorSSE)(w, r)
+	case path == "/monitor/poll" && s.config.WebSocketEnabled:
+		s.requireRole(RoleViewer, s.handleMonitorLongPoll)(w, r)
+	case path == "/events":
+		s.requireRole(RoleViewer, s.handleEventsSSE)(w, r)
+	case path == "/push/vapid-public-key" && s.config.PushEnabled:
+		s.requireRole(RoleViewer, s.handlePushVAPIDPublicKey)(w, r)
+	case path == "/push/subscribe" && s.config.PushEnabled && r.Method == "POST":
+		s.requireRole(RoleViewer, s.handlePushSubscribe)(w, r)
+	case strings.HasPrefix(path, "/push/subscribe/") && s.config.PushEnabled && r.Method == "DELETE":
+		s.requireRole(RoleViewer, s.handlePushUnsubscribe)(w, r)
+	case strings.HasPrefix(path, "/debug/") && s.config.DebugEnabled:
+		s.requireRole(RoleAdmin, s.handleDebugRoutes)(w, r)
 	default:
 		s.writeErrorResponse(w, http.StatusNotFound, "API endpoint not found")
 	}
 }
 
 // =============================================================================
-// ENHANCED WEB DASHBOARD IMPLEMENTATION
+// WEB DASHBOARD PAGES
 // =============================================================================
-
-// We define the comprehensive dashboard template with immersive content
-const enhancedDashboardHTML = `<!DOCTYPE html>
-<html lang="en">
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>Ternary Fission Energy Emulation System - Beyond The Horizon Labs</title>
-    <script src="https://cdn.jsdelivr.net/npm/chart.js"></script>
-    <style>
-        * {
-            margin: 0;
-            padding: 1px;
-            box-sizing: border-box;
-        }
-
-        body {
-            font-family: Verdana, Arial, sans-serif;
-            font-size: 12px;
-            background: linear-gradient(135deg, #0f0f23 0%, #1a1a2e 50%, #16213e 100%);
-            min-height: 100vh;
-            color: #e8e8e8;
-            line-height: 1.4;
-        }
-
-        .container {
-            max-width: 1400px;
-            margin: 0 auto;
-            padding: 20px;
-        }
-
-        .hero-header {
-            background: linear-gradient(135deg, rgba(0,150,255,0.1) 0%, rgba(150,0,255,0.1) 100%);
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 12px;
-            padding: 30px;
-            margin-bottom: 30px;
-            box-shadow: 0 8px 32px rgba(0,0,0,0.3);
-            backdrop-filter: blur(10px);
-            text-align: center;
-        }
-
-        .hero-header h1 {
-            font-size: 16px;
-            color: #00d4ff;
-            margin-bottom: 10px;
-            text-shadow: 0 0 10px rgba(0,212,255,0.5);
-        }
-
-        .hero-header .subtitle {
-            font-size: 14px;
-            color: #64b5f6;
-            margin-bottom: 15px;
-        }
-
-        .hero-header .description {
-            font-size: 12px;
-            color: #90caf9;
-            max-width: 800px;
-            margin: 0 auto 20px;
-        }
-
-        .hero-header .author-info {
-            font-size: 12px;
-            color: #81c784;
-            border-top: 1px solid rgba(255,255,255,0.1);
-            padding-top: 15px;
-            margin-top: 15px;
-        }
-
-        .physics-info {
-            display: grid;
-            grid-template-columns: repeat(auto-fit, minmax(400px, 1fr));
-            gap: 20px;
-            margin-bottom: 30px;
-        }
-
-        .info-card {
-            background: rgba(255,255,255,0.05);
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 8px;
-            padding: 20px;
-            box-shadow: 0 4px 16px rgba(0,0,0,0.2);
-            backdrop-filter: blur(5px);
-        }
-
-        .info-card h2 {
-            font-size: 14px;
-            color: #ffd54f;
-            margin-bottom: 15px;
-            border-bottom: 2px solid rgba(255,213,79,0.3);
-            padding-bottom: 8px;
-        }
-
-        .info-card p {
-            margin-bottom: 10px;
-            color: #e0e0e0;
-        }
-
-        .info-card .formula {
-            font-family: 'Courier New', monospace;
-            background: rgba(0,0,0,0.3);
-            padding: 8px;
-            border-radius: 4px;
-            margin: 10px 0;
-            color: #81c784;
-            border-left: 3px solid #4caf50;
-        }
-
-        .status-grid {
-            display: grid;
-            grid-template-columns: repeat(auto-fit, minmax(300px, 1fr));
-            gap: 20px;
-            margin-bottom: 30px;
-        }
-
-        .status-card {
-            background: rgba(255,255,255,0.05);
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 8px;
-            padding: 20px;
-            box-shadow: 0 4px 16px rgba(0,0,0,0.2);
-            backdrop-filter: blur(5px);
-        }
-
-        .status-card h2 {
-            font-size: 14px;
-            color: #ff6b6b;
-            margin-bottom: 15px;
-            border-bottom: 2px solid rgba(255,107,107,0.3);
-            padding-bottom: 8px;
-        }
-
-        .metric {
-            display: flex;
-            justify-content: space-between;
-            margin: 10px 0;
-            padding: 8px;
-            background: rgba(255,255,255,0.02);
-            border-radius: 4px;
-            border-left: 3px solid transparent;
-        }
-
-        .metric.active { border-left-color: #4caf50; }
-        .metric.warning { border-left-color: #ff9800; }
-        .metric.critical { border-left-color: #f44336; }
-
-        .metric-label {
-            font-weight: bold;
-            color: #b0bec5;
-        }
-
-        .metric-value {
-            color: #64b5f6;
-            font-weight: bold;
-        }
-
-        .energy-visualization {
-            background: rgba(255,255,255,0.05);
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 8px;
-            padding: 20px;
-            margin-bottom: 30px;
-            box-shadow: 0 4px 16px rgba(0,0,0,0.2);
-        }
-
-        .energy-visualization h2 {
-            font-size: 14px;
-            color: #9c27b0;
-            margin-bottom: 15px;
-            text-align: center;
-        }
-
-        .energy-bar-container {
-            background: rgba(0,0,0,0.3);
-            height: 30px;
-            border-radius: 15px;
-            margin: 15px 0;
-            overflow: hidden;
-            position: relative;
-        }
-
-        .energy-bar {
-            height: 100%;
-            background: linear-gradient(90deg, #4caf50, #8bc34a, #cddc39);
-            border-radius: 15px;
-            transition: width 0.5s ease;
-            box-shadow: 0 0 20px rgba(76,175,80,0.4);
-        }
-
-        .energy-bar-label {
-            position: absolute;
-            top: 50%;
-            left: 50%;
-            transform: translate(-50%, -50%);
-            font-size: 11px;
-            font-weight: bold;
-            color: #fff;
-            text-shadow: 1px 1px 2px rgba(0,0,0,0.7);
-        }
-
-        .field-creator {
-            background: rgba(255,255,255,0.05);
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 8px;
-            padding: 20px;
-            margin-bottom: 30px;
-            box-shadow: 0 4px 16px rgba(0,0,0,0.2);
-        }
-
-        .field-creator h2 {
-            font-size: 14px;
-            color: #e91e63;
-            margin-bottom: 15px;
-            text-align: center;
-        }
-
-        .portal-trigger {
-            background: rgba(255,255,255,0.05);
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 8px;
-            padding: 20px;
-            margin-bottom: 30px;
-            box-shadow: 0 4px 16px rgba(0,0,0,0.2);
-        }
-
-        .portal-trigger h2 {
-            font-size: 14px;
-            color: #2196f3;
-            margin-bottom: 15px;
-            text-align: center;
-        }
-
-        .form-grid {
-            display: grid;
-            grid-template-columns: repeat(auto-fit, minmax(200px, 1fr));
-            gap: 15px;
-            margin-bottom: 20px;
-        }
-
-        .form-group label {
-            display: block;
-            margin-bottom: 5px;
-            font-weight: bold;
-            color: #90caf9;
-            font-size: 12px;
-        }
-
-        .form-group input, .form-group select {
-            width: 100%;
-            padding: 8px;
-            border: 1px solid rgba(255,255,255,0.2);
-            border-radius: 4px;
-            font-size: 12px;
-            background: rgba(0,0,0,0.3);
-            color: #e8e8e8;
-        }
-
-        .form-group input:focus, .form-group select:focus {
-            border-color: #64b5f6;
-            outline: none;
-            box-shadow: 0 0 10px rgba(100,181,246,0.3);
-        }
-
-        .submit-btn {
-            background: linear-gradient(135deg, #e91e63, #ad1457);
-            color: white;
-            border: none;
-            padding: 12px 30px;
-            border-radius: 6px;
-            cursor: pointer;
-            font-size: 12px;
-            font-weight: bold;
-            transition: all 0.3s ease;
-            width: 100%;
-        }
-
-        .submit-btn:hover {
-            background: linear-gradient(135deg, #ad1457, #880e4f);
-            box-shadow: 0 4px 16px rgba(233,30,99,0.4);
-        }
-
-        .api-documentation {
-            background: rgba(255,255,255,0.05);
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 8px;
-            padding: 20px;
-            margin-bottom: 30px;
-            box-shadow: 0 4px 16px rgba(0,0,0,0.2);
-        }
-
-        .api-documentation h2 {
-            font-size: 14px;
-            color: #03dac6;
-            margin-bottom: 15px;
-            text-align: center;
-        }
-
-        .endpoints-grid {
-            display: grid;
-            grid-template-columns: repeat(auto-fit, minmax(350px, 1fr));
-            gap: 15px;
-        }
-
-        .endpoint {
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 6px;
-            padding: 15px;
-            background: rgba(0,0,0,0.2);
-        }
-
-        .endpoint-method {
-            display: inline-block;
-            padding: 4px 8px;
-            border-radius: 3px;
-            font-size: 10px;
-            font-weight: bold;
-            margin-right: 10px;
-        }
-
-        .method-get { background: #4caf50; color: white; }
-        .method-post { background: #2196f3; color: white; }
-        .method-delete { background: #f44336; color: white; }
-
-        .endpoint-url {
-            font-family: 'Courier New', monospace;
-            font-size: 11px;
-            color: #81c784;
-        }
-
-        .endpoint-desc {
-            margin-top: 8px;
-            font-size: 11px;
-            color: #b0bec5;
-        }
-
-        .test-button {
-            background: linear-gradient(135deg, #9c27b0, #673ab7);
-            color: white;
-            border: none;
-            padding: 6px 12px;
-            border-radius: 4px;
-            cursor: pointer;
-            font-size: 11px;
-            margin-top: 8px;
-            transition: all 0.3s ease;
-        }
-
-        .test-button:hover {
-            background: linear-gradient(135deg, #673ab7, #3f51b5);
-            box-shadow: 0 2px 8px rgba(156,39,176,0.4);
-        }
-
-        .response-area {
-            margin-top: 15px;
-            padding: 15px;
-            background: rgba(0,0,0,0.4);
-            color: #e8e8e8;
-            border-radius: 6px;
-            font-family: 'Courier New', monospace;
-            font-size: 11px;
-            max-height: 300px;
-            overflow-y: auto;
-            border: 1px solid rgba(255,255,255,0.1);
-            display: none;
-        }
-
-        .chart-grid {
-            display: grid;
-            grid-template-columns: repeat(auto-fit, minmax(300px, 1fr));
-            gap: 20px;
-            margin: 30px 0;
-        }
-
-        .chart-card {
-            background: rgba(255,255,255,0.05);
-            border: 1px solid rgba(255,255,255,0.1);
-            border-radius: 8px;
-            padding: 20px;
-            box-shadow: 0 4px 16px rgba(0,0,0,0.2);
-            backdrop-filter: blur(5px);
-        }
-
-        .footer {
-            text-align: center;
-            padding: 20px;
-            margin-top: 40px;
-            border-top: 1px solid rgba(255,255,255,0.1);
-            color: #757575;
-            font-size: 11px;
-        }
-
-        @media (max-width: 768px) {
-            .container { padding: 10px; }
-            .physics-info { grid-template-columns: 1fr; }
-            .status-grid { grid-template-columns: 1fr; }
-            .endpoints-grid { grid-template-columns: 1fr; }
-            .form-grid { grid-template-columns: 1fr; }
-        }
-
-        /* We add pulsing animation for active elements */
-        @keyframes pulse {
-            0% { box-shadow: 0 0 0 0 rgba(100,181,246,0.4); }
-            70% { box-shadow: 0 0 0 10px rgba(100,181,246,0); }
-            100% { box-shadow: 0 0 0 0 rgba(100,181,246,0); }
-        }
-
-        .pulse { animation: pulse 2s infinite; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="hero-header">
-            <h1>🔬 Ternary Fission Energy Emulation System</h1>
-            <div class="subtitle">Advanced Nuclear Physics Simulation Platform</div>
-            <div class="description">
-                We have developed a revolutionary system that simulates ternary nuclear fission events using computational energy field mapping.
-                Our system represents nuclear energy through memory allocation and CPU cycles, creating a unique approach to energy simulation
-                that bridges theoretical physics with practical computing resources. We use base-3 mathematics for energy generation,
-                base-8 electromagnetic field stabilization, and encryption-based energy dissipation modeling.
-            </div>
-            <div class="author-info">
-                <strong>Beyond The Horizon Labs</strong> | <strong>Author:</strong> bthlops (David StJ) |
-                <strong>Server:</strong> {{.ServerHost}}:{{.ServerPort}} |
-                <strong>Status:</strong> <span id="server-status" class="pulse">Connected</span>
-            </div>
-        </div>
-
-        <div class="physics-info">
-            <div class="info-card">
-                <h2>🧬 Ternary Fission Physics</h2>
-                <p>Traditional nuclear fission splits atomic nuclei into two fragments. We simulate <strong>ternary fission</strong> - a rare process where nuclei split into three fragments, typically producing two fission fragments plus an alpha particle.</p>
-                <div class="formula">E = Q₀ + Eₓ = (M_parent - M_frag1 - M_frag2 - M_α) × c²</div>
-                <p>Our simulation uses realistic U-235 parameters with 6.5 MeV excitation energy, generating statistically accurate fragment mass distributions and energy releases of ~200 MeV per event.</p>
-            </div>
-
-            <div class="info-card">
-                <h2>💾 Energy Field Mapping</h2>
-                <p>We innovatively represent nuclear energy as computational resources:</p>
-                <p><strong>Memory Mapping:</strong> 1 MeV = 1 MB allocated memory</p>
-                <p><strong>CPU Mapping:</strong> 1 MeV = 1 billion CPU cycles consumed</p>
-                <div class="formula">S = k × ln(W) - Entropy calculated from system microstates</div>
-                <p>Energy dissipation occurs through encryption rounds, modeling exponential decay: E(t) = E₀ × e^(-λt)</p>
-            </div>
-
-            <div class="info-card">
-                <h2>🔬 Research Applications</h2>
-                <p><strong>Nuclear Physics Education:</strong> Interactive learning tool for understanding fission processes</p>
-                <p><strong>Safety Analysis:</strong> Reactor safety simulations and risk assessment</p>
-                <p><strong>Waste Management:</strong> Modeling fission product behavior and decay chains</p>
-                <p><strong>Research Validation:</strong> Cross-checking experimental results with theoretical predictions</p>
-            </div>
-
-            <div class="info-card">
-                <h2>🚀 Technical Innovation</h2>
-                <p>Our system implements conservation laws (energy, momentum, mass, charge) with realistic tolerances. We use:</p>
-                <p><strong>Watt Spectrum:</strong> For neutron energy distributions</p>
-                <p><strong>Maxwell-Boltzmann:</strong> For thermal velocity modeling</p>
-                <p><strong>Monte Carlo Methods:</strong> For statistical simulation accuracy</p>
-                <div class="formula">p = √(E² - (mc²)²)/c - Relativistic momentum calculation</div>
-            </div>
-        </div>
-
-        <div class="status-grid">
-            <div class="status-card">
-                <h2>🖥️ System Status</h2>
-                <div class="metric active">
-                    <span class="metric-label">System Uptime:</span>
-                    <span class="metric-value" id="uptime">Loading...</span>
-                </div>
-                <div class="metric active">
-                    <span class="metric-label">Active Energy Fields:</span>
-                    <span class="metric-value" id="active-fields">Loading...</span>
-                </div>
-                <div class="metric active">
-                    <span class="metric-label">Total Energy Simulated:</span>
-                    <span class="metric-value" id="total-energy">Loading...</span>
-                </div>
-                <div class="metric active">
-                    <span class="metric-label">Simulation Running:</span>
-                    <span class="metric-value" id="simulation-running">Loading...</span>
-                </div>
-            </div>
-
-            <div class="status-card">
-                <h2>📊 Performance Metrics</h2>
-                <div class="metric">
-                    <span class="metric-label">CPU Usage:</span>
-                    <span class="metric-value" id="cpu-usage">Loading...</span>
-                </div>
-                <div class="metric">
-                    <span class="metric-label">Memory Usage:</span>
-                    <span class="metric-value" id="memory-usage">Loading...</span>
-                </div>
-                <div class="metric">
-                    <span class="metric-label">Peak Memory Usage:</span>
-                    <span class="metric-value" id="peak-memory">Loading...</span>
-                </div>
-                <div class="metric">
-                    <span class="metric-label">Avg Calculation Time:</span>
-                    <span class="metric-value" id="avg-calc-time">Loading...</span>
-                </div>
-                <div class="metric">
-                    <span class="metric-label">Event Rate:</span>
-                    <span class="metric-value" id="event-rate">Loading...</span>
-                </div>
-            </div>
-
-            <div class="status-card">
-                <h2>🔬 Physics Parameters</h2>
-                <div class="metric">
-                    <span class="metric-label">Parent Nucleus:</span>
-                    <span class="metric-value">U-{{.Config.ParentMass}}</span>
-                </div>
-                <div class="metric">
-                    <span class="metric-label">Excitation Energy:</span>
-                    <span class="metric-value">{{.Config.ExcitationEnergy}} MeV</span>
-                </div>
-                <div class="metric">
-                    <span class="metric-label">Events Per Second:</span>
-                    <span class="metric-value">{{.Config.EventsPerSecond}}</span>
-                </div>
-                <div class="metric">
-                    <span class="metric-label">Max Energy Field:</span>
-                    <span class="metric-value">{{.Config.MaxEnergyField}} MeV</span>
-                </div>
-            </div>
-        </div>
-
-        <div class="chart-grid">
-            <div class="chart-card">
-                <canvas id="energyChart"></canvas>
-            </div>
-            <div class="chart-card">
-                <canvas id="resourceChart"></canvas>
-            </div>
-            <div class="chart-card">
-                <canvas id="eventChart"></canvas>
-            </div>
-        </div>
-
-        <div class="energy-visualization">
-            <h2>⚡ Real-Time Energy Field Visualization</h2>
-            <div class="energy-bar-container">
-                <div class="energy-bar" id="energy-bar" style="width: 0%"></div>
-                <div class="energy-bar-label" id="energy-bar-label">0 MeV</div>
-            </div>
-            <p style="text-align: center; color: #90caf9; font-size: 11px; margin-top: 10px;">
-                Energy field intensity visualization - updates in real-time based on active simulations
-            </p>
-        </div>
-
-        <div class="field-creator">
-            <h2>🎛️ Create Energy Field</h2>
-            <p style="text-align: center; color: #b0bec5; margin-bottom: 20px; font-size: 11px;">
-                Generate a new energy field for nuclear physics simulation. We allocate computational resources
-                proportional to the energy level and simulate realistic dissipation through encryption operations.
-            </p>
-            <form id="energy-field-form">
-                <div class="form-grid">
-                    <div class="form-group">
-                        <label for="energy-level">Initial Energy (MeV):</label>
-                        <input type="number" id="energy-level" min="0.1" max="{{.Config.MaxEnergyField}}" step="0.1" value="100" required>
-                    </div>
-                    <div class="form-group">
-                        <label for="field-name">Field Name:</label>
-                        <input type="text" id="field-name" placeholder="Energy Field #1">
-                    </div>
-                    <div class="form-group">
-                        <label for="auto-dissipate">Auto Dissipate:</label>
-                        <select id="auto-dissipate">
-                            <option value="true">Yes (Recommended)</option>
-                            <option value="false">No</option>
-                        </select>
-                    </div>
-                    <div class="form-group">
-                        <label for="dissipation-rounds">Dissipation Rounds:</label>
-                        <input type="number" id="dissipation-rounds" min="1" max="256" value="15">
-                    </div>
-                </div>
-                <button type="submit" class="submit-btn">🚀 Create Energy Field</button>
-            </form>
-            <div id="field-response" class="response-area"></div>
-        </div>
-
-        <div class="portal-trigger">
-            <h2>🌀 Trigger Portal</h2>
-            <p style="text-align: center; color: #b0bec5; margin-bottom: 20px; font-size: 11px;">
-                Initiate a transient portal event with specified duration and power level.
-            </p>
-            <form id="portal-trigger-form">
-                <div class="form-grid">
-                    <div class="form-group">
-                        <label for="portal-duration">Duration (minutes):</label>
-                        <input type="number" id="portal-duration" min="1" value="15" required>
-                    </div>
-                    <div class="form-group">
-                        <label for="portal-power">Power Level:</label>
-                        <input type="number" id="portal-power" min="0.1" step="0.1" value="1" required>
-                    </div>
-                </div>
-                <button type="submit" class="submit-btn">🌀 Trigger Portal</button>
-            </form>
-            <div id="portal-response" class="response-area"></div>
-        </div>
-
-        <div class="api-documentation">
-            <h2>📡 API Documentation & Testing Interface</h2>
-            <p style="text-align: center; color: #b0bec5; margin-bottom: 20px; font-size: 11px;">
-                Complete RESTful API for programmatic access to the Ternary Fission simulation system.
-                We provide endpoints for energy field management, system monitoring, and real-time WebSocket updates.
-            </p>
-            <div class="endpoints-grid">
-                <div class="endpoint">
-                    <span class="endpoint-method method-get">GET</span>
-                    <span class="endpoint-url">/api/v1/status</span>
-                    <div class="endpoint-desc">Comprehensive system status including performance metrics, active fields, and simulation statistics</div>
-                    <button class="test-button" onclick="testEndpoint('/api/v1/status', 'GET')">🧪 Test</button>
-                </div>
-
-                <div class="endpoint">
-                    <span class="endpoint-method method-get">GET</span>
-                    <span class="endpoint-url">/api/v1/health</span>
-                    <div class="endpoint-desc">Basic health check endpoint for monitoring system availability</div>
-                    <button class="test-button" onclick="testEndpoint('/api/v1/health', 'GET')">🧪 Test</button>
-                </div>
-
-                <div class="endpoint">
-                    <span class="endpoint-method method-get">GET</span>
-                    <span class="endpoint-url">/api/v1/energy-fields</span>
-                    <div class="endpoint-desc">List all active energy fields with detailed status information</div>
-                    <button class="test-button" onclick="testEndpoint('/api/v1/energy-fields', 'GET')">🧪 Test</button>
-                </div>
-
-                <div class="endpoint">
-                    <span class="endpoint-method method-post">POST</span>
-                    <span class="endpoint-url">/api/v1/energy-fields</span>
-                    <div class="endpoint-desc">Create new energy field with specified parameters (energy level, dissipation settings)</div>
-                    <button class="test-button" onclick="showCreateForm()">🧪 Test</button>
-                </div>
-
-                <div class="endpoint">
-                    <span class="endpoint-method method-get">GET</span>
-                    <span class="endpoint-url">/api/v1/energy-fields/{id}</span>
-                    <div class="endpoint-desc">Retrieve specific energy field details by field ID</div>
-                    <button class="test-button" onclick="testWithId('energy-fields')">🧪 Test</button>
-                </div>
-
-                <div class="endpoint">
-                    <span class="endpoint-method method-delete">DELETE</span>
-                    <span class="endpoint-url">/api/v1/energy-fields/{id}</span>
-                    <div class="endpoint-desc">Safely terminate and cleanup specified energy field</div>
-                    <button class="test-button" onclick="deleteWithId('energy-fields')">🧪 Test</button>
-                </div>
-
-                <div class="endpoint">
-                    <span class="endpoint-method method-post">POST</span>
-                    <span class="endpoint-url">/api/v1/energy-fields/{id}/dissipate</span>
-                    <div class="endpoint-desc">Dissipate existing energy field through encryption rounds</div>
-                    <button class="test-button" onclick="dissipateWithId()">🧪 Test</button>
-                </div>
-
-                <div class="endpoint">
-                    <span class="endpoint-method method-get">GET</span>
-                    <span class="endpoint-url">/api/v1/metrics</span>
-                    <div class="endpoint-desc">Prometheus-compatible metrics for monitoring and alerting systems</div>
-                    <button class="test-button" onclick="testEndpoint('/api/v1/metrics', 'GET')">🧪 Test</button>
-                </div>
-
-                <div class="endpoint">
-                    <span class="endpoint-method method-get">GET</span>
-                    <span class="endpoint-url">/api/v1/ws/monitor</span>
-                    <div class="endpoint-desc">WebSocket endpoint for real-time monitoring and live updates</div>
-                    <button class="test-button" onclick="testWebSocket()">🧪 Test</button>
-                </div>
-            </div>
-        </div>
-
-        <div class="footer">
-            <p><strong>Ternary Fission Energy Emulation System</strong> | Beyond The Horizon Labs</p>
-            <p>Advanced nuclear physics simulation with computational energy field mapping</p>
-            <p><strong>Author:</strong> bthlops (David StJ) | <strong>Contact:</strong> davestj@gmail.com</p>
-            <p><strong>Research Focus:</strong> Ternary nuclear fission, energy field theory, computational physics simulation</p>
-        </div>
-    </div>
-
-    <script>
-        // We implement comprehensive dashboard functionality
-        let statusUpdateInterval;
-        let energyBarAnimation;
-        let energyChart;
-        let resourceChart;
-        let eventChart;
-        let lastEventCount = 0;
-        let lastEventTime = Date.now();
-
-        // We initialize the dashboard when page loads
-        document.addEventListener('DOMContentLoaded', function() {
-            console.log('🚀 Ternary Fission Dashboard Loading...');
-            initializeCharts();
-            updateSystemStatus();
-            updateEnergyFields();
-            startStatusUpdates();
-            setupEnergyFieldForm();
-            setupPortalTriggerForm();
-            initializeEnergyVisualization();
-            console.log('✅ Dashboard fully loaded and operational');
-        });
-
-        // We fetch and update system status with enhanced error handling
-        function updateSystemStatus() {
-            fetch('/api/v1/status')
-                .then(response => {
-                    if (!response.ok) {
-                        throw new Error('Network response was not ok: ' + response.status);
-                    }
-                    return response.json();
-                })
-                .then(data => {
-                    console.log('📊 Status update received:', data);
-
-                    // We update all status displays
-                    document.getElementById('uptime').textContent = formatUptime(data.uptime_seconds);
-                    document.getElementById('total-energy').textContent = data.total_energy_simulated.toFixed(2) + ' MeV';
-                    document.getElementById('simulation-running').textContent = data.simulation_running ? 'Active' : 'Idle';
-                    document.getElementById('cpu-usage').textContent = data.cpu_usage_percent.toFixed(1) + '%';
-                    document.getElementById('memory-usage').textContent = data.memory_usage_percent.toFixed(1) + '%';
-                    document.getElementById('peak-memory').textContent = formatBytes(data.peak_memory_usage_bytes);
-                    document.getElementById('avg-calc-time').textContent = data.average_calculation_time_microseconds.toFixed(1) + ' μs';
-                    const now = new Date();
-                    const label = now.toLocaleTimeString();
-                    const deltaEvents = data.total_fission_events - lastEventCount;
-                    const deltaTime = (now.getTime() - lastEventTime) / 1000;
-                    const eventRate = deltaTime > 0 ? deltaEvents / deltaTime : 0;
-                    lastEventCount = data.total_fission_events;
-                    lastEventTime = now.getTime();
-                    document.getElementById('event-rate').textContent = eventRate.toFixed(2) + ' events/s';
-
-                    energyChart.data.labels.push(label);
-                    energyChart.data.datasets[0].data.push(data.total_energy_simulated);
-                    energyChart.update();
-
-                    resourceChart.data.labels.push(label);
-                    resourceChart.data.datasets[0].data.push(data.cpu_usage_percent);
-                    resourceChart.data.datasets[1].data.push(data.memory_usage_percent);
-                    resourceChart.update();
-
-                    eventChart.data.labels.push(label);
-                    eventChart.data.datasets[1].data.push(eventRate);
-
-                    // We update connection status
-                    const statusElement = document.getElementById('server-status');
-                    statusElement.textContent = 'Connected';
-                    statusElement.style.color = '#4caf50';
-                    statusElement.classList.add('pulse');
-
-                    // We update energy visualization
-                    updateEnergyVisualization(data.total_energy_simulated);
-
-                    // We update metric styling based on values
-                    updateMetricStyling(data);
-                })
-                .catch(error => {
-                    console.error('❌ Failed to fetch status:', error);
-                    const statusElement = document.getElementById('server-status');
-                    statusElement.textContent = 'Disconnected';
-                    statusElement.style.color = '#f44336';
-                    statusElement.classList.remove('pulse');
-                });
-        }
-
-        // We start periodic status updates with configurable interval
-        function startStatusUpdates() {
-            statusUpdateInterval = setInterval(function() {
-                updateSystemStatus();
-                updateEnergyFields();
-            }, 3000); // Update every 3 seconds
-            console.log('⏰ Status updates started (3s interval)');
-        }
-
-        // We fetch energy field information and update charts
-        function updateEnergyFields() {
-            fetch('/api/v1/energy-fields')
-                .then(response => {
-                    if (!response.ok) {
-                        throw new Error('Network response was not ok: ' + response.status);
-                    }
-                    return response.json();
-                })
-                .then(fields => {
-                    const count = Array.isArray(fields) ? fields.length : 0;
-                    document.getElementById('active-fields').textContent = count;
-                    eventChart.data.datasets[0].data.push(count);
-                    eventChart.update();
-                })
-                .catch(error => {
-                    console.error('❌ Failed to fetch energy fields:', error);
-                });
-        }
-
-        // We initialize Chart.js visualizations
-        function initializeCharts() {
-            const ctxEnergy = document.getElementById('energyChart').getContext('2d');
-            energyChart = new Chart(ctxEnergy, {
-                type: 'line',
-                data: {
-                    labels: [],
-                    datasets: [{
-                        label: 'Total Energy (MeV)',
-                        borderColor: '#ffd54f',
-                        backgroundColor: 'rgba(255,213,79,0.2)',
-                        data: [],
-                        tension: 0.3
-                    }]
-                },
-                options: {
-                    scales: { y: { beginAtZero: true } }
-                }
-            });
-
-            const ctxResource = document.getElementById('resourceChart').getContext('2d');
-            resourceChart = new Chart(ctxResource, {
-                type: 'line',
-                data: {
-                    labels: [],
-                    datasets: [
-                        {
-                            label: 'CPU Usage %',
-                            borderColor: '#64b5f6',
-                            backgroundColor: 'rgba(100,181,246,0.2)',
-                            data: [],
-                            tension: 0.3
-                        },
-                        {
-                            label: 'Memory Usage %',
-                            borderColor: '#81c784',
-                            backgroundColor: 'rgba(129,199,132,0.2)',
-                            data: [],
-                            tension: 0.3
-                        }
-                    ]
-                },
-                options: {
-                    scales: { y: { beginAtZero: true, max: 100 } }
-                }
-            });
-
-            const ctxEvent = document.getElementById('eventChart').getContext('2d');
-            eventChart = new Chart(ctxEvent, {
-                type: 'line',
-                data: {
-                    labels: [],
-                    datasets: [
-                        {
-                            label: 'Active Fields',
-                            borderColor: '#f06292',
-                            backgroundColor: 'rgba(240,98,146,0.2)',
-                            data: [],
-                            tension: 0.3
-                        },
-                        {
-                            label: 'Event Rate (events/s)',
-                            borderColor: '#ba68c8',
-                            backgroundColor: 'rgba(186,104,200,0.2)',
-                            data: [],
-                            tension: 0.3,
-                            yAxisID: 'y1'
-                        }
-                    ]
-                },
-                options: {
-                    scales: {
-                        y: { beginAtZero: true, position: 'left' },
-                        y1: { beginAtZero: true, position: 'right', grid: { drawOnChartArea: false } }
-                    }
-                }
-            });
-        }
-
-        // We set up the energy field creation form with validation
-        function setupEnergyFieldForm() {
-            const form = document.getElementById('energy-field-form');
-            form.addEventListener('submit', function(e) {
-                e.preventDefault();
-                console.log('🔬 Creating new energy field...');
-
-                const formData = {
-                    initial_energy_mev: parseFloat(document.getElementById('energy-level').value),
-                    field_name: document.getElementById('field-name').value || '',
-                    auto_dissipate: document.getElementById('auto-dissipate').value === 'true',
-                    dissipation_rounds: parseInt(document.getElementById('dissipation-rounds').value)
-                };
-
-                console.log('📝 Form data:', formData);
-
-                fetch('/api/v1/energy-fields', {
-                    method: 'POST',
-                    headers: {
-                        'Content-Type': 'application/json'
-                    },
-                    body: JSON.stringify(formData)
-                })
-                .then(response => {
-                    if (!response.ok) {
-                        throw new Error('Failed to create energy field: ' + response.status);
-                    }
-                    return response.json();
-                })
-                .then(data => {
-                    console.log('✅ Energy field created:', data);
-                    const responseArea = document.getElementById('field-response');
-                    responseArea.innerHTML = '<strong>✅ Energy Field Created Successfully!</strong><br><pre>' +
-                        JSON.stringify(data, null, 2) + '</pre>';
-                    responseArea.style.display = 'block';
-                    updateSystemStatus(); // Refresh status after creation
-                })
-                .catch(error => {
-                    console.error('❌ Error creating energy field:', error);
-                    const responseArea = document.getElementById('field-response');
-                    responseArea.innerHTML = '<strong>❌ Error:</strong> ' + error.message;
-                    responseArea.style.display = 'block';
-                });
-            });
-        }
-
-        // We set up the portal trigger form
-        function setupPortalTriggerForm() {
-            const form = document.getElementById('portal-trigger-form');
-            form.addEventListener('submit', function(e) {
-                e.preventDefault();
-                console.log('🌌 Triggering portal...');
-
-                const formData = {
-                    duration_minutes: parseInt(document.getElementById('portal-duration').value),
-                    power_level: parseFloat(document.getElementById('portal-power').value)
-                };
-
-                fetch('/api/v1/portal/trigger', {
-                    method: 'PUT',
-                    headers: {
-                        'Content-Type': 'application/json'
-                    },
-                    body: JSON.stringify(formData)
-                })
-                .then(response => {
-                    if (!response.ok) {
-                        throw new Error('Failed to trigger portal: ' + response.status);
-                    }
-                    return response.json();
-                })
-                .then(data => {
-                    console.log('🌠 Portal triggered:', data);
-                    const responseArea = document.getElementById('portal-response');
-                    responseArea.innerHTML = '<strong>✅ Portal triggered successfully!</strong><br><pre>' +
-                        JSON.stringify(data, null, 2) + '</pre>';
-                    responseArea.style.display = 'block';
-                })
-                .catch(error => {
-                    console.error('❌ Error triggering portal:', error);
-                    const responseArea = document.getElementById('portal-response');
-                    responseArea.innerHTML = '<strong>❌ Error:</strong> ' + error.message;
-                    responseArea.style.display = 'block';
-                });
-            });
-        }
-
-        // We initialize energy visualization with animation
-        function initializeEnergyVisualization() {
-            console.log('⚡ Initializing energy visualization...');
-            updateEnergyVisualization(0);
-        }
-
-        // We update energy bar visualization
-        function updateEnergyVisualization(totalEnergy) {
-            const maxEnergy = {{.Config.MaxEnergyField}};
-            const percentage = Math.min((totalEnergy / maxEnergy) * 100, 100);
-
-            const energyBar = document.getElementById('energy-bar');
-            const energyLabel = document.getElementById('energy-bar-label');
-
-            if (energyBar && energyLabel) {
-                energyBar.style.width = percentage + '%';
-                energyLabel.textContent = totalEnergy.toFixed(1) + ' MeV';
-
-                // We add visual effects based on energy level
-                if (percentage > 80) {
-                    energyBar.style.background = 'linear-gradient(90deg, #ff5722, #ff9800, #ffc107)';
-                } else if (percentage > 50) {
-                    energyBar.style.background = 'linear-gradient(90deg, #ff9800, #ffc107, #ffeb3b)';
-                } else {
-                    energyBar.style.background = 'linear-gradient(90deg, #4caf50, #8bc34a, #cddc39)';
-                }
-            }
-        }
-
-        // We update metric styling based on values
-        function updateMetricStyling(data) {
-            const metrics = document.querySelectorAll('.metric');
-            metrics.forEach(metric => {
-                metric.classList.remove('active', 'warning', 'critical');
-
-                const valueText = metric.querySelector('.metric-value').textContent;
-                if (valueText.includes('%')) {
-                    const value = parseFloat(valueText);
-                    if (value > 80) metric.classList.add('critical');
-                    else if (value > 60) metric.classList.add('warning');
-                    else metric.classList.add('active');
-                } else {
-                    metric.classList.add('active');
-                }
-            });
-        }
-
-        // We test API endpoints with enhanced feedback
-        function testEndpoint(url, method) {
-            console.log('🧪 Testing endpoint:', method, url);
-
-            fetch(url, { method: method })
-                .then(response => {
-                    if (!response.ok) {
-                        throw new Error('HTTP ' + response.status + ': ' + response.statusText);
-                    }
-                    return response.text();
-                })
-                .then(data => {
-                    console.log('✅ Test successful:', url);
-                    alert('🎉 Test Successful!\n\nEndpoint: ' + method + ' ' + url + '\n\nResponse:\n' +
-                          (data.length > 500 ? data.substring(0, 500) + '...\n[Response truncated]' : data));
-                })
-                .catch(error => {
-                    console.error('❌ Test failed:', error);
-                    alert('❌ Test Failed!\n\nEndpoint: ' + method + ' ' + url + '\n\nError: ' + error.message);
-                });
-        }
-
-        // We test endpoints that require an ID
-        function testWithId(endpoint) {
-            const id = prompt('Enter ' + endpoint + ' ID:');
-            if (id) {
-                testEndpoint('/api/v1/' + endpoint + '/' + id, 'GET');
-            }
-        }
-
-        // We delete resources by ID with confirmation
-        function deleteWithId(endpoint) {
-            const id = prompt('Enter ' + endpoint + ' ID to delete:');
-            if (id && confirm('⚠️  Are you sure you want to delete this ' + endpoint + '?\n\nThis action cannot be undone!')) {
-                console.log('🗑️ Deleting:', endpoint, id);
-
-                fetch('/api/v1/' + endpoint + '/' + id, { method: 'DELETE' })
-                    .then(response => {
-                        if (!response.ok) {
-                            throw new Error('HTTP ' + response.status + ': ' + response.statusText);
-                        }
-                        return response.json();
-                    })
-                    .then(data => {
-                        console.log('✅ Delete successful:', data);
-                        alert('✅ Delete Successful!\n\n' + JSON.stringify(data, null, 2));
-                        updateSystemStatus();
-                    })
-                    .catch(error => {
-                        console.error('❌ Delete failed:', error);
-                        alert('❌ Delete Failed!\n\nError: ' + error.message);
-                    });
-            }
-        }
-
-        // We dissipate energy fields by ID with configurable rounds
-        function dissipateWithId() {
-            const id = prompt('Enter energy-fields ID to dissipate:');
-            if (id) {
-                const roundsInput = prompt('Enter dissipation rounds:', '1');
-                const rounds = roundsInput ? parseInt(roundsInput) : 0;
-                const payload = rounds > 0 ? { dissipation_rounds: rounds } : {};
-
-                fetch('/api/v1/energy-fields/' + id + '/dissipate', {
-                    method: 'POST',
-                    headers: { 'Content-Type': 'application/json' },
-                    body: JSON.stringify(payload)
-                })
-                    .then(response => {
-                        if (!response.ok) {
-                            throw new Error('HTTP ' + response.status + ': ' + response.statusText);
-                        }
-                        return response.json();
-                    })
-                    .then(data => {
-                        console.log('✅ Dissipation successful:', data);
-                        alert('✅ Dissipation Successful!\n\n' + JSON.stringify(data, null, 2));
-                        updateSystemStatus();
-                    })
-                    .catch(error => {
-                        console.error('❌ Dissipation failed:', error);
-                        alert('❌ Dissipation Failed!\n\nError: ' + error.message);
-                    });
-            }
-        }
-
-        // We show create form
-        function showCreateForm() {
-            document.querySelector('.field-creator').scrollIntoView({ behavior: 'smooth' });
-            document.getElementById('energy-level').focus();
-        }
-
-        // We test WebSocket connection
-        function testWebSocket() {
-            console.log('🔌 Testing WebSocket connection...');
-
-            const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-            const wsUrl = protocol + '//' + window.location.host + '/api/v1/ws/monitor';
-
-            try {
-                const ws = new WebSocket(wsUrl);
-
-                ws.onopen = function() {
-                    console.log('✅ WebSocket connected');
-                    alert('🎉 WebSocket Test Successful!\n\nConnected to: ' + wsUrl + '\n\nListening for real-time updates...');
-                };
-
-                ws.onmessage = function(event) {
-                    console.log('📨 WebSocket message:', event.data);
-                };
-
-                ws.onerror = function(error) {
-                    console.error('❌ WebSocket error:', error);
-                    alert('❌ WebSocket Test Failed!\n\nError connecting to: ' + wsUrl);
-                };
-
-                // We close connection after 5 seconds for testing
-                setTimeout(() => {
-                    ws.close();
-                    console.log('🔌 WebSocket test connection closed');
-                }, 5000);
-
-            } catch (error) {
-                console.error('❌ WebSocket test failed:', error);
-                alert('❌ WebSocket Test Failed!\n\nError: ' + error.message);
-            }
-        }
-
-        // We format uptime in human readable format
-        function formatUptime(seconds) {
-            const days = Math.floor(seconds / 86400);
-            const hours = Math.floor((seconds % 86400) / 3600);
-            const minutes = Math.floor((seconds % 3600) / 60);
-            const remainingSeconds = seconds % 60;
-
-            if (days > 0) {
-                return days + 'd ' + hours + 'h ' + minutes + 'm';
-            } else if (hours > 0) {
-                return hours + 'h ' + minutes + 'm';
-            } else if (minutes > 0) {
-                return minutes + 'm ' + remainingSeconds.toFixed(0) + 's';
-            } else {
-                return remainingSeconds.toFixed(1) + 's';
-            }
-        }
-
-        // We format bytes in human readable format
-        function formatBytes(bytes) {
-            const sizes = ['B', 'KB', 'MB', 'GB', 'TB'];
-            if (bytes === 0) return '0 B';
-            const i = Math.floor(Math.log(bytes) / Math.log(1024));
-            return (bytes / Math.pow(1024, i)).toFixed(1) + ' ' + sizes[i];
-        }
-
-        // We add keyboard shortcuts for power users
-        document.addEventListener('keydown', function(e) {
-            if (e.ctrlKey || e.metaKey) {
-                switch(e.key) {
-                    case 'r':
-                        e.preventDefault();
-                        updateSystemStatus();
-                        console.log('🔄 Manual status refresh triggered');
-                        break;
-                    case 'e':
-                        e.preventDefault();
-                        showCreateForm();
-                        break;
-                }
-            }
-        });
-
-        console.log('🎛️ Dashboard keyboard shortcuts enabled: Ctrl+R (refresh), Ctrl+E (create field)');
-    </script>
-</body>
-</html>`
+//
+// Page markup lives in frontend/templates/*.gotemplate.html, composed with the
+// shared frontend/templates/base.gotemplate.html layout by s.frontend.RenderPage.
+// A new page only needs a new template file and a route here - no HTML is
+// embedded in this file.
+
+// dashboardPageData is the data passed to templates/dashboard.gotemplate.html
+type dashboardPageData struct {
+	Title      string
+	ServerHost string
+	ServerPort int
+	Config     *Config
+}
 
 // We serve the enhanced web dashboard with proper error handling
 func (s *TernaryFissionAPIServer) serveDashboard(w http.ResponseWriter, r *http.Request) {
-	tmpl, err := template.New("dashboard").Parse(enhancedDashboardHTML)
-	if err != nil {
-		log.Printf("Template parsing error: %v", err)
-		http.Error(w, "Template parsing error", http.StatusInternalServerError)
-		return
-	}
-
-	data := struct {
-		ServerHost string
-		ServerPort int
-		Config     *Config
-	}{
+	data := dashboardPageData{
+		Title:      "Ternary Fission Energy Emulation System - Beyond The Horizon Labs",
 		ServerHost: s.config.APIHost,
 		ServerPort: s.config.APIPort,
 		Config:     s.config,
 	}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
 	w.Header().Set("Pragma", "no-cache")
 	w.Header().Set("Expires", "0")
 
-	if err := tmpl.Execute(w, data); err != nil {
-		log.Printf("Template execution error: %v", err)
+	if err := s.frontend.RenderPage(w, "dashboard", data); err != nil {
+		s.logger.Error("Dashboard render error", logging.Fields{"error": err.Error()})
 		http.Error(w, "Template execution error", http.StatusInternalServerError)
 		return
 	}
 
-	log.Printf("Dashboard served successfully to %s", r.RemoteAddr)
+	s.logger.Debug("Dashboard served successfully", logging.Fields{"remote_addr": r.RemoteAddr})
+}
+
+// portalTriggerPageData is the data passed to templates/portal-trigger.gotemplate.html
+type portalTriggerPageData struct {
+	Title string
+}
+
+// We serve the portal-trigger page; the form itself calls the existing
+// /api/v1/portal/trigger endpoint via fetch rather than a plain form POST
+func (s *TernaryFissionAPIServer) servePortalTriggerPage(w http.ResponseWriter, r *http.Request) {
+	data := portalTriggerPageData{Title: "Trigger Portal Simulation"}
+
+	if err := s.frontend.RenderPage(w, "portal-trigger", data); err != nil {
+		s.logger.Error("Portal trigger page render error", logging.Fields{"error": err.Error()})
+		http.Error(w, "Template execution error", http.StatusInternalServerError)
+	}
+}
+
+// energyFieldDetailPageData is the data passed to templates/energy-field-detail.gotemplate.html
+type energyFieldDetailPageData struct {
+	Title   string
+	FieldID string
+	Field   *EnergyFieldResponse
+	Error   string
+}
+
+// We serve the energy-field detail page by fetching the field from the reactor
+// and rendering it; the field ID comes from the trailing path segment
+func (s *TernaryFissionAPIServer) serveEnergyFieldDetailPage(w http.ResponseWriter, r *http.Request) {
+	fieldID := strings.TrimPrefix(r.URL.Path, "/dashboard/energy-fields/")
+	data := energyFieldDetailPageData{
+		Title:   fmt.Sprintf("Energy Field %s", fieldID),
+		FieldID: fieldID,
+	}
+
+	resp, err := s.reactorGet(r, fmt.Sprintf("%s/api/v1/energy-fields/%s", s.config.ReactorBaseURL, fieldID))
+	if err != nil {
+		data.Error = "Failed to contact reactor"
+	} else {
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			data.Error = "Energy field not found"
+		} else {
+			var field EnergyFieldResponse
+			if err := json.NewDecoder(resp.Body).Decode(&field); err != nil {
+				data.Error = "Failed to decode reactor response"
+			} else {
+				data.Field = &field
+			}
+		}
+	}
+
+	if err := s.frontend.RenderPage(w, "energy-field-detail", data); err != nil {
+		s.logger.Error("Energy field detail render error", logging.Fields{"error": err.Error()})
+		http.Error(w, "Template execution error", http.StatusInternalServerError)
+	}
 }
 
 // =============================================================================
@@ -1710,6 +1471,11 @@ func (s *TernaryFissionAPIServer) createEnergyField(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// We peek at trial_wavefunction so we can register a local-energy estimator once the
+	// reactor assigns a field_id; the reactor still receives the request body unmodified.
+	var fieldReq EnergyFieldRequest
+	_ = json.Unmarshal(body, &fieldReq)
+
 	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/v1/energy-fields", s.config.ReactorBaseURL), bytes.NewReader(body))
 	if err != nil {
 		s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to build reactor request")
@@ -1724,50 +1490,101 @@ func (s *TernaryFissionAPIServer) createEnergyField(w http.ResponseWriter, r *ht
 	}
 	defer resp.Body.Close()
 
-	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to read reactor response")
+		return
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		var created EnergyFieldResponse
+		if err := json.Unmarshal(respBody, &created); err == nil && created.FieldID != "" {
+			parentZ, _ := s.parentNucleusZA()
+			s.localEnergy.Register(created.FieldID, fieldReq.TrialWavefunctionName, defaultEnergyFieldPotential(parentZ))
+			s.events.Publish(events.KindFieldCreated, created)
+		}
+	}
+
+	s.forwardReactorResponseHeaders(w, resp)
 	w.WriteHeader(resp.StatusCode)
-	io.Copy(w, resp.Body)
+	w.Write(respBody)
 }
 
 // We implement the system status endpoint
 func (s *TernaryFissionAPIServer) getSystemStatus(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.reactorClient.Get(fmt.Sprintf("%s/api/v1/status", s.config.ReactorBaseURL))
+	status, statusCode, err := s.fetchSystemStatus()
 	if err != nil {
-		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to contact reactor")
+		s.writeErrorResponse(w, statusCode, err.Error())
 		return
 	}
+	s.writeJSONResponse(w, http.StatusOK, status)
+}
+
+// fetchSystemStatus polls the reactor's /api/v1/status, feeds it through the same metrics/
+// TSDB/decay-heat enrichment getSystemStatus has always done, and returns it for any caller -
+// the REST handler above and the GraphQL systemStatus/statusSnapshots resolvers
+// (api.ternary.fission.graphql.go) share this instead of duplicating the enrichment steps.
+// It has no *http.Request to take a context from (several callers are background pollers, not
+// a single inbound request), so unlike listEnergyFields/getEnergyField it does not carry the
+// request_id interceptor's X-Request-ID onto this particular reactor call.
+func (s *TernaryFissionAPIServer) fetchSystemStatus() (SystemStatusResponse, int, error) {
+	resp, err := s.reactorClient.Get(fmt.Sprintf("%s/api/v1/status", s.config.ReactorBaseURL))
+	if err != nil {
+		return SystemStatusResponse{}, http.StatusBadGateway, fmt.Errorf("Failed to contact reactor")
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		s.writeErrorResponse(w, resp.StatusCode, string(body))
-		return
+		return SystemStatusResponse{}, resp.StatusCode, fmt.Errorf("%s", string(body))
 	}
 
+	statusBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return SystemStatusResponse{}, http.StatusInternalServerError, fmt.Errorf("Invalid reactor response")
+	}
 	var status SystemStatusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
-		s.writeErrorResponse(w, http.StatusInternalServerError, "Invalid reactor response")
-		return
+	if err := s.codec().Unmarshal(statusBody, &status); err != nil {
+		return SystemStatusResponse{}, http.StatusInternalServerError, fmt.Errorf("Invalid reactor response")
 	}
 
 	if s.config.PrometheusEnabled {
 		s.reactorActiveFields.Set(float64(status.ActiveEnergyFields))
 		s.reactorTotalEnergy.Set(status.TotalEnergySimulated)
 	}
+	s.recordTSDBSample(status, time.Now())
+	now := time.Now()
+	parentZ, parentMass := s.parentNucleusZA()
+	s.decayTracker.IngestStatus(status, parentMass, parentZ, now)
+	status.DecayHeatMeVPerSecond = s.decayTracker.HeatRateMeVPerSecond(now)
+	status.TimestampUnix = now.Unix()
+
+	return status, http.StatusOK, nil
+}
 
-	s.writeJSONResponse(w, http.StatusOK, status)
+// reactorGet builds a GET request to url carrying r's context (so timeoutMiddleware's
+// deadline and loggingMiddleware's request ID both reach the reactor call, exactly like the
+// handlers already using http.NewRequestWithContext(r.Context(), ...) below) and issues it
+// through s.reactorClient, matching *http.Client.Get's behavior for callers that previously
+// used the context-less Get(url string).
+func (s *TernaryFissionAPIServer) reactorGet(r *http.Request, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.reactorClient.Do(req)
 }
 
 // We list all energy fields
 func (s *TernaryFissionAPIServer) listEnergyFields(w http.ResponseWriter, r *http.Request) {
-	resp, err := s.reactorClient.Get(fmt.Sprintf("%s/api/v1/energy-fields", s.config.ReactorBaseURL))
+	resp, err := s.reactorGet(r, fmt.Sprintf("%s/api/v1/energy-fields", s.config.ReactorBaseURL))
 	if err != nil {
 		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to contact reactor")
 		return
 	}
 	defer resp.Body.Close()
 
-	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	s.forwardReactorResponseHeaders(w, resp)
 	w.WriteHeader(resp.StatusCode)
 	io.Copy(w, resp.Body)
 }
@@ -1781,14 +1598,14 @@ func (s *TernaryFissionAPIServer) getEnergyField(w http.ResponseWriter, r *http.
 	}
 	fieldID := pathParts[len(pathParts)-1]
 
-	resp, err := s.reactorClient.Get(fmt.Sprintf("%s/api/v1/energy-fields/%s", s.config.ReactorBaseURL, fieldID))
+	resp, err := s.reactorGet(r, fmt.Sprintf("%s/api/v1/energy-fields/%s", s.config.ReactorBaseURL, fieldID))
 	if err != nil {
 		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to contact reactor")
 		return
 	}
 	defer resp.Body.Close()
 
-	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	s.forwardReactorResponseHeaders(w, resp)
 	w.WriteHeader(resp.StatusCode)
 	io.Copy(w, resp.Body)
 }
@@ -1815,49 +1632,13 @@ func (s *TernaryFissionAPIServer) deleteEnergyField(w http.ResponseWriter, r *ht
 	}
 	defer resp.Body.Close()
 
-	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
-	w.WriteHeader(resp.StatusCode)
-	io.Copy(w, resp.Body)
-}
-
-// We handle WebSocket connections for real-time monitoring
-func (s *TernaryFissionAPIServer) handleWebSocketConnection(w http.ResponseWriter, r *http.Request) {
-	conn, err := s.websocketUpgrader.Upgrade(w, r, nil)
-	if err != nil {
-		log.Printf("WebSocket upgrade failed: %v", err)
-		return
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		s.localEnergy.Forget(fieldID)
 	}
-	defer conn.Close()
-
-	log.Printf("WebSocket client connected: %s", r.RemoteAddr)
 
-	ticker := time.NewTicker(time.Duration(s.config.WebSocketPingInterval) * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			resp, err := s.reactorClient.Get(fmt.Sprintf("%s/api/v1/status", s.config.ReactorBaseURL))
-			if err != nil {
-				log.Printf("WebSocket status fetch failed: %v", err)
-				return
-			}
-			var status SystemStatusResponse
-			if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
-				resp.Body.Close()
-				log.Printf("WebSocket status decode failed: %v", err)
-				return
-			}
-			resp.Body.Close()
-
-			if err := conn.WriteJSON(status); err != nil {
-				log.Printf("WebSocket write failed: %v", err)
-				return
-			}
-		case <-s.ctx.Done():
-			return
-		}
-	}
+	s.forwardReactorResponseHeaders(w, resp)
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
 }
 
 // We dissipate an energy field by forwarding the request to the reactor
@@ -1880,7 +1661,7 @@ func (s *TernaryFissionAPIServer) dissipateEnergyField(w http.ResponseWriter, r
 		return
 	}
 
-	req, err := http.NewRequest(r.Method, fmt.Sprintf("%s/api/v1/energy-fields/%s/dissipate", s.config.ReactorBaseURL, fieldID), bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, fmt.Sprintf("%s/api/v1/energy-fields/%s/dissipate", s.config.ReactorBaseURL, fieldID), bytes.NewReader(body))
 	if err != nil {
 		s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to build reactor request")
 		return
@@ -1896,55 +1677,97 @@ func (s *TernaryFissionAPIServer) dissipateEnergyField(w http.ResponseWriter, r
 	}
 	defer resp.Body.Close()
 
-        w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
-        w.WriteHeader(resp.StatusCode)
-        io.Copy(w, resp.Body)
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to read reactor response")
+		return
+	}
+
+	// We run this dissipation round's Monte Carlo local-energy sample and merge it into the
+	// reactor's response, falling back to the unmodified body if the field has no registered
+	// estimator (e.g. it predates this server process) or the reactor's body isn't a JSON object.
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if estimate, ok := s.localEnergy.Sample(fieldID); ok {
+			var fields map[string]interface{}
+			if err := json.Unmarshal(respBody, &fields); err == nil {
+				fields["local_energy_mean"] = estimate.MeanMeV
+				fields["local_energy_variance"] = estimate.Variance
+				fields["acceptance_ratio"] = estimate.AcceptanceRatio
+				fields["n_walkers"] = estimate.NWalkers
+				if merged, err := json.Marshal(fields); err == nil {
+					respBody = merged
+				}
+			}
+		}
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		s.events.Publish(events.KindFieldDissipated, fieldDissipatedEvent{
+			FieldID:  fieldID,
+			Response: json.RawMessage(respBody),
+		})
+	}
+
+	s.forwardReactorResponseHeaders(w, resp)
+	w.WriteHeader(resp.StatusCode)
+	w.Write(respBody)
+}
+
+// fieldDissipatedEvent is the events.KindFieldDissipated payload: the dissipated field's
+// ID alongside the (possibly local-energy-enriched) reactor response body.
+type fieldDissipatedEvent struct {
+	FieldID  string          `json:"field_id"`
+	Response json.RawMessage `json:"response"`
 }
 
 // We trigger portal simulations by forwarding the request to the reactor
 func (s *TernaryFissionAPIServer) triggerPortalSimulation(w http.ResponseWriter, r *http.Request) {
-    var req struct {
-        DurationSeconds int     `json:"duration_seconds"`
-        PowerLevelMEV   float64 `json:"power_level_mev"`
-    }
-
-    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-        s.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
-        return
-    }
-    if req.DurationSeconds == 0 {
-        req.DurationSeconds = 900
-    } else if req.DurationSeconds < 0 {
-        s.writeErrorResponse(w, http.StatusBadRequest, "Duration must be non-negative")
-        return
-    }
-
-    body, err := json.Marshal(req)
-    if err != nil {
-        s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode request")
-        return
-    }
-
-    reactorURL := s.config.ReactorBaseURL + "/api/v1/portal/trigger"
-    reactorReq, err := http.NewRequest(http.MethodPut, reactorURL, bytes.NewReader(body))
-    if err != nil {
-        s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to build reactor request")
-        return
-    }
-    reactorReq.Header.Set("Content-Type", "application/json")
-
-    resp, err := s.reactorClient.Do(reactorReq)
-    if err != nil {
-        s.writeErrorResponse(w, http.StatusBadGateway, "Failed to contact reactor")
-        return
-    }
-    defer resp.Body.Close()
-
-    w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
-    w.WriteHeader(resp.StatusCode)
-    if _, err := io.Copy(w, resp.Body); err != nil {
-        log.Printf("Failed to forward reactor response: %v", err)
-    }
+	var req struct {
+		DurationSeconds int     `json:"duration_seconds"`
+		PowerLevelMEV   float64 `json:"power_level_mev"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.DurationSeconds == 0 {
+		req.DurationSeconds = 900
+	} else if req.DurationSeconds < 0 {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Duration must be non-negative")
+		return
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode request")
+		return
+	}
+
+	reactorURL := s.config.ReactorBaseURL + "/api/v1/portal/trigger"
+	reactorReq, err := http.NewRequestWithContext(r.Context(), http.MethodPut, reactorURL, bytes.NewReader(body))
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to build reactor request")
+		return
+	}
+	reactorReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.reactorClient.Do(reactorReq)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to contact reactor")
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		s.events.Publish(events.KindPortalTriggered, req)
+	}
+
+	s.forwardReactorResponseHeaders(w, resp)
+	w.WriteHeader(resp.StatusCode)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		s.logger.Error("Failed to forward reactor response", logging.Fields{"error": err.Error()})
+	}
 }
 
 // =============================================================================
@@ -1952,11 +1775,29 @@ func (s *TernaryFissionAPIServer) triggerPortalSimulation(w http.ResponseWriter,
 // =============================================================================
 
 // We provide various utility methods
+// forwardReactorResponseHeaders copies Content-Type onto w, plus Retry-After when the reactor
+// client set one - the circuit breaker interceptor does this on its synthetic open-breaker
+// response, so callers proxying a reactor response straight through (rather than decoding it)
+// still let the client back off cleanly instead of hammering an endpoint that just 503'd.
+func (s *TernaryFissionAPIServer) forwardReactorResponseHeaders(w http.ResponseWriter, resp *http.Response) {
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		w.Header().Set("Retry-After", retryAfter)
+	}
+}
+
 func (s *TernaryFissionAPIServer) writeJSONResponse(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		log.Printf("JSON encoding error: %v", err)
+	encoded, err := s.codec().Marshal(data)
+	if err != nil {
+		s.logger.Error("JSON encoding error", logging.Fields{"error": err.Error()})
+		return
+	}
+	// json.NewEncoder.Encode (the stdlib behavior this replaces) always appends a trailing
+	// newline after the value; we match that here so callers/tests relying on it see no change
+	if _, err := w.Write(append(encoded, '\n')); err != nil {
+		s.logger.Error("JSON encoding error", logging.Fields{"error": err.Error()})
 	}
 }
 
@@ -1968,13 +1809,144 @@ func (s *TernaryFissionAPIServer) writeErrorResponse(w http.ResponseWriter, stat
 }
 
 // Middleware implementations
+// statusRecordingWriter wraps a ResponseWriter to capture the status code written,
+// so loggingMiddleware can report it without handlers needing to expose it themselves.
+type statusRecordingWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecordingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Hijack implements http.Hijacker by delegating to the wrapped ResponseWriter, so the
+// logging middleware doesn't block WebSocket upgrades (/ws/monitor, /graphql) behind it.
+func (w *statusRecordingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("underlying ResponseWriter does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// Flush implements http.Flusher by delegating to the wrapped ResponseWriter, so the logging
+// middleware doesn't block the SSE monitor transport's incremental writes behind it.
+func (w *statusRecordingWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// generateRequestID returns a short hex request identifier for correlating a single
+// request's log lines; collisions are not security-sensitive here, so 8 bytes is plenty.
+func generateRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("fallback-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// requestIDContextKey is the context.Context key loggingMiddleware attaches a request's
+// generated ID under, so the reactor dispatcher's request_id interceptor
+// (api.ternary.fission.reactorclient.go) can propagate the same ID onto outbound reactor
+// requests without every reactor-forwarding handler having to pass it along explicitly.
+type requestIDContextKey struct{}
+
+// withRequestID returns a context carrying requestID for requestIDFromContext to retrieve.
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// requestIDFromContext returns the request ID loggingMiddleware attached to ctx, if any.
+func requestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDContextKey{}).(string)
+	return requestID, ok
+}
+
+// authContextCacheKey is the context.Context key loggingMiddleware attaches a request's
+// resolved authContext under (nil when no credential validated), so requireRole,
+// requireViewerPage, csrfMiddleware, and rateLimitMiddleware can all reuse the one
+// AuthManager.authenticate call loggingMiddleware already made instead of each repeating it.
+// Under the default config (AuthEnabled+CSRFEnabled+RateLimitingEnabled), a Basic-Auth
+// caller's bcrypt comparison previously ran once per one of those four layers; it now runs
+// once per request.
+type authContextCacheKey struct{}
+
+// withAuthContext returns a context carrying authCtx (possibly nil) for
+// authContextFromContext/resolvedAuthContext to retrieve.
+func withAuthContext(ctx context.Context, authCtx *authContext) context.Context {
+	return context.WithValue(ctx, authContextCacheKey{}, authCtx)
+}
+
+// authContextFromContext returns the authContext loggingMiddleware resolved for this
+// request and whether one was found; a present-but-nil cached value (loggingMiddleware ran
+// but no credential validated) reports ok=false, the same as AuthManager.authenticate itself.
+func authContextFromContext(ctx context.Context) (*authContext, bool) {
+	authCtx, _ := ctx.Value(authContextCacheKey{}).(*authContext)
+	return authCtx, authCtx != nil
+}
+
+// resolvedAuthContext returns the authContext loggingMiddleware already resolved and cached
+// on r's context, falling back to a direct AuthManager.authenticate call for a handler
+// invoked outside the normal middleware chain (e.g. a test calling it directly rather than
+// through api.router), so correctness never depends on the caching having run.
+func (s *TernaryFissionAPIServer) resolvedAuthContext(r *http.Request) (*authContext, bool) {
+	if authCtx, ok := authContextFromContext(r.Context()); ok {
+		return authCtx, true
+	}
+	return s.auth.authenticate(r)
+}
+
+// loggingMiddleware logs every request with structured, request-scoped fields. It
+// always logs at Info (or Error on a 5xx status); VerboseOutput instead controls
+// whether per-request Debug-level detail is additionally emitted. The generated request
+// ID is also attached to the request's context and echoed back as an X-Request-ID
+// response header, so it correlates with the reactor_upstream_id fields the request_id
+// interceptor logs for whatever reactor requests this one triggers.
 func (s *TernaryFissionAPIServer) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := generateRequestID()
+		r = r.WithContext(withRequestID(r.Context(), requestID))
+		w.Header().Set("X-Request-ID", requestID)
+
+		// Resolve the caller's identity once per request and cache it on r's context, so
+		// csrfMiddleware/rateLimitMiddleware/requireRole/requireViewerPage downstream (and the
+		// "user" field below) all reuse this single AuthManager.authenticate call rather than
+		// each repeating it - see authContextCacheKey's doc comment for why that matters.
+		authCtx, _ := s.auth.authenticate(r)
+		r = r.WithContext(withAuthContext(r.Context(), authCtx))
+
+		recorder := &statusRecordingWriter{ResponseWriter: w, status: http.StatusOK}
+
 		start := time.Now()
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(recorder, r)
 		duration := time.Since(start)
-		if s.config.VerboseOutput {
-			log.Printf("%s %s %v from %s", r.Method, r.URL.Path, duration, r.RemoteAddr)
+
+		user := ""
+		if authCtx != nil {
+			user = authCtx.Username
+		}
+
+		fields := logging.Fields{
+			"request_id":  requestID,
+			"remote_addr": r.RemoteAddr,
+			"endpoint":    r.URL.Path,
+			"method":      r.Method,
+			"status":      recorder.status,
+			"latency_ms":  float64(duration.Microseconds()) / 1000.0,
+			"user":        user,
+		}
+
+		message := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+		if recorder.status >= 500 {
+			s.logger.Error(message, fields)
+		} else if s.config.VerboseOutput {
+			s.logger.Info(message, fields)
+		} else {
+			s.logger.Debug(message, fields)
 		}
 	})
 }
@@ -2009,18 +1981,30 @@ func (s *TernaryFissionAPIServer) corsMiddleware(next http.Handler) http.Handler
 func (s *TernaryFissionAPIServer) healthCheck(w http.ResponseWriter, r *http.Request) {
 	uptime := time.Since(s.startTime)
 
-	resp, err := s.reactorClient.Get(fmt.Sprintf("%s/api/v1/status", s.config.ReactorBaseURL))
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, fmt.Sprintf("%s/api/v1/status", s.config.ReactorBaseURL), nil)
+	if err != nil {
+		s.writeErrorResponse(w, http.StatusInternalServerError, "Failed to build reactor request")
+		return
+	}
+	resp, err := s.reactorClient.Do(req)
 	if err != nil {
 		s.writeErrorResponse(w, http.StatusBadGateway, "Failed to contact reactor")
 		return
 	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("X-Reactor-Circuit-Breaker") == "open" {
+		s.forwardReactorResponseHeaders(w, resp)
+		w.WriteHeader(resp.StatusCode)
+		_, _ = io.Copy(w, resp.Body)
+		return
+	}
+
 	var status SystemStatusResponse
 	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
-		resp.Body.Close()
 		s.writeErrorResponse(w, http.StatusInternalServerError, "Invalid reactor response")
 		return
 	}
-	resp.Body.Close()
 
 	health := map[string]interface{}{
 		"status":               "healthy",
@@ -2037,19 +2021,25 @@ func (s *TernaryFissionAPIServer) healthCheck(w http.ResponseWriter, r *http.Req
 func (s *TernaryFissionAPIServer) Start() error {
 	go func() {
 		<-s.shutdownChan
-		log.Println("Shutdown signal received, stopping server...")
+		s.logger.Info("Shutdown signal received, stopping server...", nil)
 
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
 		if err := s.server.Shutdown(ctx); err != nil {
-			log.Printf("Server shutdown error: %v", err)
+			s.logger.Error("Server shutdown error", logging.Fields{"error": err.Error()})
+		}
+
+		if s.tracingShutdown != nil {
+			if err := s.tracingShutdown(ctx); err != nil {
+				s.logger.Error("Tracing shutdown error", logging.Fields{"error": err.Error()})
+			}
 		}
 
 		s.cancelFunc()
 	}()
 
-	log.Printf("🚀 Starting Ternary Fission API Server on %s", s.server.Addr)
+	s.logger.Info("Starting Ternary Fission API Server", logging.Fields{"addr": s.server.Addr})
 	return s.server.ListenAndServe()
 }
 
@@ -2059,9 +2049,10 @@ func (s *TernaryFissionAPIServer) Start() error {
 
 func main() {
 	var (
-		configFile = flag.String("config", "configs/ternary_fission.conf", "Configuration file path")
-		port       = flag.Int("port", 0, "Override API port (0 = use config file)")
-		help       = flag.Bool("help", false, "Show help message")
+		configFile     = flag.String("config", "configs/ternary_fission.conf", "Configuration file path")
+		port           = flag.Int("port", 0, "Override API port (0 = use config file)")
+		allowProfiling = flag.Bool("allow-profiling", false, "Allow the pprof/support-bundle debug endpoints even if debug_enabled is set in config")
+		help           = flag.Bool("help", false, "Show help message")
 	)
 	flag.Parse()
 
@@ -2099,24 +2090,31 @@ func main() {
 		log.Printf("Port overridden to %d", *port)
 	}
 
-	server := NewTernaryFissionAPIServer(config)
-
-	log.Println("=== Ternary Fission Energy Emulation API Server ===")
-	log.Printf("Author: bthlops (David StJ)")
-	log.Printf("Version: %s", Version)
-	log.Printf("Starting server on port %d", config.APIPort)
-	log.Printf("🌐 Web Dashboard: http://localhost:%d/", config.APIPort)
-	log.Printf("📡 API Documentation: http://localhost:%d/api/v1", config.APIPort)
-	if config.WebSocketEnabled {
-		log.Printf("🔌 WebSocket monitoring: ws://localhost:%d/api/v1/ws/monitor", config.APIPort)
-	}
-	if config.PrometheusEnabled {
-		log.Printf("📊 Prometheus metrics: http://localhost:%d/api/v1/metrics", config.APIPort)
+	// We require -allow-profiling in addition to debug_enabled so a config file copied
+	// from a dev box can't silently expose pprof/support-bundle endpoints in production
+	if config.DebugEnabled && !*allowProfiling {
+		log.Printf("debug_enabled is set but -allow-profiling was not passed; disabling debug endpoints")
+		config.DebugEnabled = false
 	}
 
+	server := NewTernaryFissionAPIServer(config)
+	server.configPath = *configFile
+
+	server.logger.Info("=== Ternary Fission Energy Emulation API Server ===", logging.Fields{
+		"author":             "bthlops (David StJ)",
+		"version":            Version,
+		"port":               config.APIPort,
+		"dashboard_url":      fmt.Sprintf("http://localhost:%d/", config.APIPort),
+		"api_docs_url":       fmt.Sprintf("http://localhost:%d/api/v1", config.APIPort),
+		"websocket_enabled":  config.WebSocketEnabled,
+		"prometheus_enabled": config.PrometheusEnabled,
+		"push_enabled":       config.PushEnabled,
+	})
+
 	if err := server.Start(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("Server failed to start: %v", err)
+		server.logger.Error("Server failed to start", logging.Fields{"error": err.Error()})
+		os.Exit(1)
 	}
 
-	log.Println("Server shutdown complete")
+	server.logger.Info("Server shutdown complete", nil)
 }
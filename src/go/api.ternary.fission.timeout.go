@@ -0,0 +1,200 @@
+/*
+ * File: src/go/api.ternary.fission.timeout.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Per-Request Timeout Middleware for Reactor-Forwarding Endpoints
+ * Purpose: Enforces a deadline on every non-streaming request, derived from r.Context(), so a
+ *          slow or wedged reactor can't hold a handler (and its goroutine) open indefinitely
+ * Reason: s.reactorClient.Do/Get previously only had http.Client's overall Timeout; a request
+ *         that hung past that had nothing canceling the in-flight reactor call, and callers got
+ *         no structured signal to retry with - only a generic connection close
+ *
+ * Change Log:
+ * 2025-08-09: Initial timeoutMiddleware, timeoutResponseWriter, and the per-route override
+ *             parsing used by request_timeout_overrides
+ *
+ * Carry-over Context:
+ * - This mirrors stdlib's http.TimeoutHandler internally (context.WithTimeout + a goroutine
+ *   running the handler against a guarded ResponseWriter + select on done/ctx.Done()), but we
+ *   can't use http.TimeoutHandler itself: it hardcodes a 503 plain-text body and has no hook to
+ *   increment a Prometheus counter, and the request asked for a structured JSON 504 with a
+ *   retry_after hint
+ * - Known long-lived streaming routes (/ws/monitor, /graphql, /api/v1/monitor/sse,
+ *   /api/v1/monitor/poll, /api/v1/events) are listed in streamingRoutes and bypass the
+ *   deadline entirely, since router.Use wraps every route including the catch-all
+ *   routeHandler and mux gives middleware no cheaper way to know which path a request will
+ *   resolve to ahead of dispatch
+ * - dissipateEnergyField, triggerPortalSimulation, and healthCheck build their outbound reactor
+ *   request with http.NewRequestWithContext(r.Context(), ...) rather than http.NewRequest, so
+ *   the deadline this middleware attaches to r.Context() cancels the reactor round-trip too
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// parseRequestTimeoutOverrides accepts a comma-separated list of path=seconds pairs from the
+// config file, mirroring parseLokiLabels's comma-split-and-trim style.
+func parseRequestTimeoutOverrides(value string) map[string]int {
+	overrides := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		seconds, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil || seconds <= 0 {
+			continue
+		}
+		overrides[strings.TrimSpace(kv[0])] = seconds
+	}
+	return overrides
+}
+
+// requestTimeoutFor returns the deadline for path: the configured override if one matches
+// exactly, otherwise config.RequestTimeoutSeconds.
+func (s *TernaryFissionAPIServer) requestTimeoutFor(path string) time.Duration {
+	if seconds, ok := s.requestTimeoutOverrides[path]; ok {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Duration(s.config.RequestTimeoutSeconds) * time.Second
+}
+
+// initializeRequestTimeouts parses the per-route overrides and registers the timeout counter,
+// guarded by config.PrometheusEnabled like every other collector in this server.
+func (s *TernaryFissionAPIServer) initializeRequestTimeouts() {
+	s.requestTimeoutOverrides = parseRequestTimeoutOverrides(s.config.RequestTimeoutOverrides)
+
+	if s.config.PrometheusEnabled {
+		s.reactorTimeouts = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ternary_fission_reactor_timeouts_total",
+				Help: "Total number of requests that hit the per-route request timeout",
+			},
+			[]string{"path"},
+		)
+		prometheus.MustRegister(s.reactorTimeouts)
+	}
+}
+
+// timeoutResponseWriter buffers WriteHeader/Write behind a guard, so a handler goroutine that
+// is still running after the deadline fires can't race the timeout branch's own write to the
+// real ResponseWriter.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+
+	mu        sync.Mutex
+	timedOut  bool
+	wroteCode bool
+}
+
+func (tw *timeoutResponseWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return
+	}
+	tw.wroteCode = true
+	tw.ResponseWriter.WriteHeader(status)
+}
+
+func (tw *timeoutResponseWriter) Write(body []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteCode {
+		tw.wroteCode = true
+		tw.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	return tw.ResponseWriter.Write(body)
+}
+
+// markTimedOut flips the guard so any later WriteHeader/Write from the still-running handler
+// goroutine is dropped instead of racing the timeout response below. It reports whether this
+// call was the one that won the race (i.e. the handler hadn't already written a response).
+func (tw *timeoutResponseWriter) markTimedOut() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.wroteCode {
+		return false
+	}
+	tw.timedOut = true
+	tw.wroteCode = true
+	return true
+}
+
+// streamingRoutes holds the long-lived connections a blanket request deadline would sever:
+// the WS/SSE/long-poll monitor transports, the event stream, and the GraphQL WebSocket. These
+// are excluded from timeoutMiddleware rather than given a very long override, since they are
+// meant to stay open indefinitely rather than merely tolerate a slow reactor.
+var streamingRoutes = map[string]bool{
+	"/ws/monitor":          true,
+	"/graphql":             true,
+	"/api/v1/monitor/sse":  true,
+	"/api/v1/monitor/poll": true,
+	"/api/v1/events":       true,
+}
+
+// timeoutMiddleware enforces requestTimeoutFor(r.URL.Path) on every route it wraps, running
+// the handler in a goroutine against a guarded writer and racing it against the deadline. On
+// timeout it writes a structured JSON 504 with a retry_after hint and counts the route under
+// reactorTimeouts; streamingRoutes bypass the deadline entirely.
+func (s *TernaryFissionAPIServer) timeoutMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if streamingRoutes[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		timeout := s.requestTimeoutFor(r.URL.Path)
+		if timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+
+		tw := &timeoutResponseWriter{ResponseWriter: w}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			next.ServeHTTP(tw, r)
+		}()
+
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			if tw.markTimedOut() {
+				if s.reactorTimeouts != nil {
+					s.reactorTimeouts.WithLabelValues(r.URL.Path).Inc()
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Retry-After", fmt.Sprintf("%.0f", timeout.Seconds()))
+				w.WriteHeader(http.StatusGatewayTimeout)
+				fmt.Fprintf(w, `{"code":504,"error":"request timed out contacting reactor","retry_after":%.0f}`, timeout.Seconds())
+			}
+			// We wait for the handler goroutine to return so it can't write to the real
+			// ResponseWriter (via tw, now guarded) after this request's connection is reused.
+			<-done
+		}
+	})
+}
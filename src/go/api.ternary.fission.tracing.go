@@ -0,0 +1,231 @@
+/*
+ * File: src/go/api.ternary.fission.tracing.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: OpenTelemetry Distributed Tracing for Reactor Forwarding
+ * Purpose: Wraps the router in otelhttp so every inbound request gets a span, injects W3C
+ *          traceparent headers onto outgoing reactor requests via a tracing interceptor, and
+ *          exports completed spans to an OTLP collector over gRPC
+ * Reason: ReactorDispatcher's retry/circuit-breaker/cache chain forwards opaquely; operators
+ *         had no way to correlate a slow dashboard load with the specific reactor call (or
+ *         retry) that caused it, or with spans the reactor's native side may emit
+ *
+ * Change Log:
+ * 2025-08-09: Initial TracerProvider setup, the "tracing" reactor interceptor, and a
+ *             SIGHUP-triggered reload of the sampler so Config.OTLPSamplerType/
+ *             OTLPSamplingRatio can be retuned without restarting the process
+ *
+ * Carry-over Context:
+ * - The sampler is wrapped in reloadableSampler specifically because sdktrace.TracerProvider
+ *   has no public API to swap a running provider's sampler; a custom sdktrace.Sampler that
+ *   forwards to an atomically-swappable inner sampler gets the same effect
+ * - reloadTracingSampler re-parses server.configPath rather than mutating s.config in place,
+ *   mirroring how main() itself only ever reads Config once at startup - SIGHUP only ever
+ *   touches the sampler, not the rest of the server's configuration
+ * - The "tracing" interceptor is registered in interceptorFactories like any other (so
+ *   reactor_interceptors can name it explicitly), but NewReactorDispatcher also prepends it
+ *   automatically outermost when Config.TracingEnabled is true, so turning tracing on doesn't
+ *   require operators to also edit their interceptor list
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	"google.golang.org/grpc/credentials"
+
+	"ternary-fission/logging"
+)
+
+// reloadableSampler lets a running TracerProvider's sampling decision change at runtime:
+// sdktrace.TracerProvider has no API to swap its sampler once built, so we hand it this
+// wrapper instead and swap the sampler it delegates to from reloadTracingSampler.
+type reloadableSampler struct {
+	mu      sync.RWMutex
+	sampler sdktrace.Sampler
+}
+
+func (r *reloadableSampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	r.mu.RLock()
+	inner := r.sampler
+	r.mu.RUnlock()
+	return inner.ShouldSample(params)
+}
+
+func (r *reloadableSampler) Description() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return "Reloadable(" + r.sampler.Description() + ")"
+}
+
+func (r *reloadableSampler) set(sampler sdktrace.Sampler) {
+	r.mu.Lock()
+	r.sampler = sampler
+	r.mu.Unlock()
+}
+
+// buildSampler translates Config.OTLPSamplerType/OTLPSamplingRatio into an sdktrace.Sampler,
+// defaulting to AlwaysSample for an unrecognized type so a typo doesn't silently drop traces.
+func buildSampler(config *Config) sdktrace.Sampler {
+	ratio := config.OTLPSamplingRatio
+	if ratio <= 0 {
+		ratio = 1.0
+	}
+
+	switch strings.ToLower(config.OTLPSamplerType) {
+	case "always_off":
+		return sdktrace.NeverSample()
+	case "ratio":
+		return sdktrace.TraceIDRatioBased(ratio)
+	case "parentbased_ratio":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+	default:
+		return sdktrace.AlwaysSample()
+	}
+}
+
+// loadOTLPTransportCredentials builds gRPC transport credentials trusting rootCAFile in
+// addition to the system pool, for collectors behind a private CA.
+func loadOTLPTransportCredentials(rootCAFile string) (credentials.TransportCredentials, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	pem, err := os.ReadFile(rootCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OTLP root CA file %s: %w", rootCAFile, err)
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("failed to parse OTLP root CA file %s as PEM", rootCAFile)
+	}
+
+	return credentials.NewTLS(&tls.Config{RootCAs: pool}), nil
+}
+
+// initTracing builds the OTLP/gRPC exporter and TracerProvider named by Config, sets it (and
+// a W3C tracecontext propagator) as the global default, and starts the SIGHUP reload watcher.
+// It returns a shutdown func (a no-op when tracing is disabled) that callers should invoke
+// during graceful shutdown to flush any buffered spans.
+func (s *TernaryFissionAPIServer) initTracing() (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if !s.config.TracingEnabled {
+		return noop, nil
+	}
+
+	ctx := context.Background()
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(s.config.OTLPEndpoint)}
+	if s.config.OTLPInsecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	} else if s.config.OTLPRootCAFile != "" {
+		creds, err := loadOTLPTransportCredentials(s.config.OTLPRootCAFile)
+		if err != nil {
+			return noop, fmt.Errorf("tracing: %w", err)
+		}
+		opts = append(opts, otlptracegrpc.WithTLSCredentials(creds))
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return noop, fmt.Errorf("tracing: failed to build OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(
+		semconv.ServiceName("ternary-fission-api"),
+		semconv.ServiceVersion(Version),
+	))
+	if err != nil {
+		return noop, fmt.Errorf("tracing: failed to build resource: %w", err)
+	}
+
+	s.traceSampler = &reloadableSampler{sampler: buildSampler(s.config)}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(s.traceSampler),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	s.watchTracingReload()
+
+	s.logger.Info("Tracing initialized", logging.Fields{
+		"otlp_endpoint":       s.config.OTLPEndpoint,
+		"otlp_sampler_type":   s.config.OTLPSamplerType,
+		"otlp_sampling_ratio": s.config.OTLPSamplingRatio,
+	})
+
+	return tp.Shutdown, nil
+}
+
+// watchTracingReload starts a goroutine that re-parses server.configPath and swaps in a
+// freshly built sampler on every SIGHUP, for the life of the server (s.ctx is canceled on
+// shutdown alongside everything else this server owns).
+func (s *TernaryFissionAPIServer) watchTracingReload() {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sighup)
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-sighup:
+				s.reloadTracingSampler()
+			}
+		}
+	}()
+}
+
+// reloadTracingSampler re-parses the config file main() loaded at startup and swaps the
+// tracing sampler to match, without touching anything else the process has already configured.
+func (s *TernaryFissionAPIServer) reloadTracingSampler() {
+	if s.traceSampler == nil || s.configPath == "" {
+		return
+	}
+
+	reloaded, err := parseConfigFile(s.configPath)
+	if err != nil {
+		s.logger.Error("SIGHUP tracing reload: failed to re-parse config file", logging.Fields{
+			"config_path": s.configPath,
+			"error":       err.Error(),
+		})
+		return
+	}
+
+	s.traceSampler.set(buildSampler(reloaded))
+	s.logger.Info("SIGHUP tracing reload: sampler updated", logging.Fields{
+		"otlp_sampler_type":   reloaded.OTLPSamplerType,
+		"otlp_sampling_ratio": reloaded.OTLPSamplingRatio,
+	})
+}
+
+// newTracingInterceptor wraps the transport with otelhttp, so every outgoing reactor request
+// carries a W3C traceparent header derived from the span in the request's context (the span
+// otelhttp.NewHandler started for the inbound request that triggered it).
+func newTracingInterceptor(server *TernaryFissionAPIServer) Interceptor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return otelhttp.NewTransport(next)
+	}
+}
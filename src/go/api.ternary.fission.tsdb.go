@@ -0,0 +1,279 @@
+/*
+ * File: src/go/api.ternary.fission.tsdb.go
+ * Author: bthlops (David StJ)
+ * Date: August 6, 2025
+ * Title: In-Process Time-Series Retention and Grafana SimpleJSON Datasource
+ * Purpose: Retains recent reactor metrics in memory and serves them to Grafana without Prometheus
+ * Reason: Operators asked for historical charts without standing up a separate TSDB
+ *
+ * Change Log:
+ * 2025-08-06: Initial ring-buffer time-series store with a fine (recent, high-resolution) and
+ *             coarse (older, downsampled) tier per metric, plus the /api/v1/tsdb/{search,query,
+ *             annotations} handlers implementing the Grafana SimpleJSON datasource contract
+ *
+ * Carry-over Context:
+ * - We record into the store from the same poll tick that already updates the Prometheus
+ *   gauges in updateReactorMetrics, so this is additive rather than a second polling path
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// RING BUFFER
+// =============================================================================
+
+// tsPoint is one sample at a point in time.
+type tsPoint struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// ringBuffer is a fixed-capacity circular buffer of tsPoints. Once full, the
+// oldest point is overwritten, bounding memory regardless of uptime.
+type ringBuffer struct {
+	points []tsPoint
+	next   int
+	filled bool
+}
+
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{points: make([]tsPoint, capacity)}
+}
+
+func (b *ringBuffer) add(point tsPoint) {
+	if len(b.points) == 0 {
+		return
+	}
+	b.points[b.next] = point
+	b.next = (b.next + 1) % len(b.points)
+	if b.next == 0 {
+		b.filled = true
+	}
+}
+
+// since returns every stored point with Timestamp >= from, oldest first.
+func (b *ringBuffer) since(from time.Time) []tsPoint {
+	count := b.next
+	if b.filled {
+		count = len(b.points)
+	}
+
+	result := make([]tsPoint, 0, count)
+	for i := 0; i < count; i++ {
+		var idx int
+		if b.filled {
+			idx = (b.next + i) % len(b.points)
+		} else {
+			idx = i
+		}
+		p := b.points[idx]
+		if !p.Timestamp.Before(from) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// =============================================================================
+// TIME SERIES STORE
+// =============================================================================
+
+// metricSeries holds one metric's fine and coarse tiers plus the accumulator
+// used to downsample fine points into the next coarse bucket.
+type metricSeries struct {
+	fine   *ringBuffer
+	coarse *ringBuffer
+
+	coarseBucketStart time.Time
+	coarseAccumSum    float64
+	coarseAccumCount  int
+}
+
+// TimeSeriesStore retains recent values for a fixed set of reactor metrics,
+// downsampling older data to bound memory: fine resolution for the retention
+// window configured by Config.TSDBFineRetentionSeconds, and a coarser rollup
+// for Config.TSDBCoarseRetentionSeconds beyond that.
+type TimeSeriesStore struct {
+	mu     sync.RWMutex
+	series map[string]*metricSeries
+
+	fineResolution   time.Duration
+	coarseResolution time.Duration
+}
+
+// KnownTSDBMetrics lists every metric the store accepts, in the order the
+// Grafana SimpleJSON /search endpoint should present them.
+var KnownTSDBMetrics = []string{
+	"active_fields",
+	"total_energy_mev",
+	"cpu_percent",
+	"memory_percent",
+	"estimated_power_mev",
+	"portal_duration_remaining",
+}
+
+func NewTimeSeriesStore(config *Config) *TimeSeriesStore {
+	fineResolutionSeconds := orDefault(config.TSDBFineResolutionSeconds, 1)
+	fineRetentionSeconds := orDefault(config.TSDBFineRetentionSeconds, 3600)
+	coarseResolutionSeconds := orDefault(config.TSDBCoarseResolutionSeconds, 60)
+	coarseRetentionSeconds := orDefault(config.TSDBCoarseRetentionSeconds, 86400)
+
+	fineRes := time.Duration(fineResolutionSeconds) * time.Second
+	coarseRes := time.Duration(coarseResolutionSeconds) * time.Second
+	fineCapacity := fineRetentionSeconds / fineResolutionSeconds
+	coarseCapacity := coarseRetentionSeconds / coarseResolutionSeconds
+
+	store := &TimeSeriesStore{
+		series:           make(map[string]*metricSeries),
+		fineResolution:   fineRes,
+		coarseResolution: coarseRes,
+	}
+
+	for _, name := range KnownTSDBMetrics {
+		store.series[name] = &metricSeries{
+			fine:   newRingBuffer(fineCapacity),
+			coarse: newRingBuffer(coarseCapacity),
+		}
+	}
+
+	return store
+}
+
+// orDefault returns value unless it is non-positive, in which case fallback is
+// used; this keeps minimal Config literals (as used in tests) from dividing by zero.
+func orDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// Record appends a sample for metric at time now, and rolls the fine tier's
+// accumulator into the coarse tier whenever a coarse bucket boundary is crossed.
+func (s *TimeSeriesStore) Record(metric string, value float64, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	series, found := s.series[metric]
+	if !found {
+		return
+	}
+
+	series.fine.add(tsPoint{Timestamp: now, Value: value})
+
+	bucketStart := now.Truncate(s.coarseResolution)
+	if series.coarseBucketStart.IsZero() {
+		series.coarseBucketStart = bucketStart
+	}
+	if bucketStart.After(series.coarseBucketStart) {
+		if series.coarseAccumCount > 0 {
+			average := series.coarseAccumSum / float64(series.coarseAccumCount)
+			series.coarse.add(tsPoint{Timestamp: series.coarseBucketStart, Value: average})
+		}
+		series.coarseBucketStart = bucketStart
+		series.coarseAccumSum = 0
+		series.coarseAccumCount = 0
+	}
+	series.coarseAccumSum += value
+	series.coarseAccumCount++
+}
+
+// Query returns every point at or after "from" for the given metric. We serve
+// from the fine tier whenever it can cover the requested window, and fall back
+// to the downsampled coarse tier for older history.
+func (s *TimeSeriesStore) Query(metric string, from time.Time) []tsPoint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	series, found := s.series[metric]
+	if !found {
+		return nil
+	}
+
+	if time.Since(from) <= s.fineResolution*time.Duration(len(series.fine.points)) {
+		if points := series.fine.since(from); len(points) > 0 {
+			return points
+		}
+	}
+	return series.coarse.since(from)
+}
+
+// =============================================================================
+// GRAFANA SIMPLEJSON HANDLERS
+// =============================================================================
+
+// We answer the SimpleJSON /search request with the list of known metric names
+func (s *TernaryFissionAPIServer) handleTSDBSearch(w http.ResponseWriter, r *http.Request) {
+	s.writeJSONResponse(w, http.StatusOK, KnownTSDBMetrics)
+}
+
+type tsdbQueryRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type tsdbQueryTarget struct {
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+type tsdbQueryRequest struct {
+	Range   tsdbQueryRange    `json:"range"`
+	Targets []tsdbQueryTarget `json:"targets"`
+}
+
+type tsdbQueryResponse struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// We answer the SimpleJSON /query request for one or more targets over a time range
+func (s *TernaryFissionAPIServer) handleTSDBQuery(w http.ResponseWriter, r *http.Request) {
+	var req tsdbQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeErrorResponse(w, http.StatusBadRequest, "Invalid query request")
+		return
+	}
+
+	from := req.Range.From
+	if from.IsZero() {
+		from = time.Now().Add(-time.Hour)
+	}
+
+	results := make([]tsdbQueryResponse, 0, len(req.Targets))
+	for _, target := range req.Targets {
+		points := s.tsdb.Query(target.Target, from)
+		datapoints := make([][2]float64, 0, len(points))
+		for _, p := range points {
+			datapoints = append(datapoints, [2]float64{p.Value, float64(p.Timestamp.UnixMilli())})
+		}
+		results = append(results, tsdbQueryResponse{Target: target.Target, Datapoints: datapoints})
+	}
+
+	s.writeJSONResponse(w, http.StatusOK, results)
+}
+
+// We do not support annotations yet; Grafana requires a 200 with a JSON array
+func (s *TernaryFissionAPIServer) handleTSDBAnnotations(w http.ResponseWriter, r *http.Request) {
+	s.writeJSONResponse(w, http.StatusOK, []interface{}{})
+}
+
+// recordTSDBSample fans a polled SystemStatusResponse out into the time-series store
+func (s *TernaryFissionAPIServer) recordTSDBSample(status SystemStatusResponse, now time.Time) {
+	if s.tsdb == nil {
+		return
+	}
+	s.tsdb.Record("active_fields", float64(status.ActiveEnergyFields), now)
+	s.tsdb.Record("total_energy_mev", status.TotalEnergySimulated, now)
+	s.tsdb.Record("cpu_percent", status.CPUUsagePercent, now)
+	s.tsdb.Record("memory_percent", status.MemoryUsagePercent, now)
+	s.tsdb.Record("estimated_power_mev", status.EstimatedPower, now)
+	s.tsdb.Record("portal_duration_remaining", float64(status.PortalDurationRemain), now)
+}
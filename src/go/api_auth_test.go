@@ -0,0 +1,259 @@
+/*
+ * File: src/go/api_auth_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 4, 2025
+ * Title: Tests for the session and API token auth subsystem
+ * Purpose: Ensures role-gated endpoints reject anonymous callers and accept valid tokens
+ * Reason: Provides regression coverage for the auth middleware wired into handleAPIRoutes
+ *
+ * Change Log:
+ * 2025-08-04: Initial tests for token-gated energy field creation and role enforcement
+ * 2025-08-09: Added tests for HTTP Basic Auth, static API key loading, and the
+ *             auth-failures counter
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestCreateEnergyFieldRequiresAuthWhenEnabled verifies that enabling auth blocks
+// anonymous requests to a mutating endpoint with a 401.
+func TestCreateEnergyFieldRequiresAuthWhenEnabled(t *testing.T) {
+	cfg := &Config{
+		ReactorBaseURL:    "http://127.0.0.1:0",
+		APITimeout:        5,
+		PrometheusEnabled: false,
+		AuthEnabled:       true,
+		SessionCookieName: "tfs_session",
+		SessionTTLMinutes: 60,
+	}
+
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/api/v1/energy-fields", "application/json", nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+// TestCreateEnergyFieldAcceptsOperatorToken verifies that an operator-scoped bearer
+// token is sufficient to reach the reactor-forwarding handler.
+func TestCreateEnergyFieldAcceptsOperatorToken(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"field_id":"abc"}`))
+	}))
+	defer stub.Close()
+
+	cfg := &Config{
+		ReactorBaseURL:    stub.URL,
+		APITimeout:        5,
+		PrometheusEnabled: false,
+		AuthEnabled:       true,
+		SessionCookieName: "tfs_session",
+		SessionTTLMinutes: 60,
+	}
+
+	api := NewTernaryFissionAPIServer(cfg)
+	token := api.auth.tokens.Issue("ci", RoleOperator)
+
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/energy-fields", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestRoleSatisfies exercises the role-rank comparison used by requireRole.
+func TestRoleSatisfies(t *testing.T) {
+	cases := []struct {
+		have, need string
+		want       bool
+	}{
+		{RoleViewer, RoleViewer, true},
+		{RoleViewer, RoleOperator, false},
+		{RoleAdmin, RoleViewer, true},
+		{RoleOperator, RoleAdmin, false},
+	}
+
+	for _, c := range cases {
+		if got := roleSatisfies(c.have, c.need); got != c.want {
+			t.Errorf("roleSatisfies(%s, %s) = %v, want %v", c.have, c.need, got, c.want)
+		}
+	}
+}
+
+// TestCreateEnergyFieldAcceptsBasicAuth verifies that valid HTTP Basic
+// credentials checked against the AuthStore are sufficient to reach a
+// role-gated endpoint.
+func TestCreateEnergyFieldAcceptsBasicAuth(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret"), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	usersFile, err := os.CreateTemp(t.TempDir(), "users-*.conf")
+	if err != nil {
+		t.Fatalf("failed to create temp users file: %v", err)
+	}
+	fmt.Fprintf(usersFile, "operator1:%s:operator\n", hash)
+	usersFile.Close()
+
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"field_id":"abc"}`))
+	}))
+	defer stub.Close()
+
+	cfg := &Config{
+		ReactorBaseURL:    stub.URL,
+		APITimeout:        5,
+		PrometheusEnabled: false,
+		AuthEnabled:       true,
+		AuthUsersFile:     usersFile.Name(),
+		SessionCookieName: "tfs_session",
+		SessionTTLMinutes: 60,
+	}
+
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/energy-fields", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.SetBasicAuth("operator1", "s3cret")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestStaticAPIKeysLoadFromFile verifies that tokens listed in
+// Config.AuthAPIKeysFile are usable as bearer tokens without ever calling
+// TokenManager.Issue.
+func TestStaticAPIKeysLoadFromFile(t *testing.T) {
+	keysFile, err := os.CreateTemp(t.TempDir(), "api-keys-*.conf")
+	if err != nil {
+		t.Fatalf("failed to create temp api keys file: %v", err)
+	}
+	fmt.Fprintln(keysFile, "fixed-viewer-token:monitoring-bot:viewer")
+	keysFile.Close()
+
+	cfg := &Config{
+		ReactorBaseURL:    "http://127.0.0.1:0",
+		APITimeout:        5,
+		PrometheusEnabled: false,
+		AuthEnabled:       true,
+		AuthAPIKeysFile:   keysFile.Name(),
+		SessionCookieName: "tfs_session",
+		SessionTTLMinutes: 60,
+	}
+
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/tokens", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer fixed-viewer-token")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a viewer-scoped key to be forbidden from listing admin tokens, got %d", resp.StatusCode)
+	}
+}
+
+// TestAuthFailuresCounterIncrementsOnBadBearerToken verifies that a rejected
+// bearer token is counted under the "bearer" reason label. The count is 2, not
+// 1, because loggingMiddleware also calls authenticate (to attribute the log
+// line to a user) independently of requireRole's own check.
+func TestAuthFailuresCounterIncrementsOnBadBearerToken(t *testing.T) {
+	cfg := &Config{
+		ReactorBaseURL:     "http://127.0.0.1:0",
+		APITimeout:         5,
+		PrometheusEnabled:  true,
+		StatusPollInterval: 15,
+		AuthEnabled:        true,
+		SessionCookieName:  "tfs_session",
+		SessionTTLMinutes:  60,
+	}
+
+	api := NewTernaryFissionAPIServer(cfg)
+	defer func() {
+		prometheus.Unregister(api.auth.authFailures)
+		prometheus.Unregister(api.rateLimitHits)
+		prometheus.Unregister(api.requestCounter)
+		prometheus.Unregister(api.responseTime)
+		prometheus.Unregister(api.reactorActiveFields)
+		prometheus.Unregister(api.reactorTotalEnergy)
+		prometheus.Unregister(api.reactorTimeouts)
+		prometheus.Unregister(api.csrf.csrfRejected)
+	}()
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/energy-fields", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer not-a-real-token")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+
+	if got := testutil.ToFloat64(api.auth.authFailures.WithLabelValues("bearer")); got != 2 {
+		t.Fatalf("expected 2 bearer auth failures recorded, got %v", got)
+	}
+}
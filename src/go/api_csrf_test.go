@@ -0,0 +1,208 @@
+/*
+ * File: src/go/api_csrf_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the CSRF protection middleware
+ * Purpose: Exercises the mint-then-validate cookie/header round trip, the bearer and
+ *          trusted-Origin bypasses, and the csrf_rejected_total counter
+ * Reason: Provides regression coverage for api.ternary.fission.csrf.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial token round-trip, bypass, and rejection-counter tests
+ * 2025-08-10: Added a regression test for the chunk3-4 fix - a request carrying both a valid
+ *             session cookie and an arbitrary Authorization value must still require a valid
+ *             CSRF token, not be exempted by the header's mere presence
+ */
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// csrfTestServer builds a Ternary Fission API server with CSRF enforcement enabled.
+func csrfTestServer(t *testing.T) (*httptest.Server, *TernaryFissionAPIServer) {
+	t.Helper()
+
+	cfg := &Config{
+		ReactorBaseURL:     "http://127.0.0.1:0",
+		APITimeout:         5,
+		PrometheusEnabled:  true,
+		StatusPollInterval: 60,
+		AuthEnabled:        true,
+		SessionCookieName:  "tfs_session",
+		SessionTTLMinutes:  60,
+		CSRFEnabled:        true,
+		CSRFTrustedOrigins: "https://trusted.example",
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	t.Cleanup(func() {
+		prometheus.Unregister(api.csrf.csrfRejected)
+		prometheus.Unregister(api.auth.authFailures)
+		prometheus.Unregister(api.rateLimitHits)
+		prometheus.Unregister(api.requestCounter)
+		prometheus.Unregister(api.responseTime)
+		prometheus.Unregister(api.reactorActiveFields)
+		prometheus.Unregister(api.reactorTotalEnergy)
+		prometheus.Unregister(api.reactorTimeouts)
+	})
+	server := httptest.NewServer(api.router)
+	t.Cleanup(server.Close)
+
+	return server, api
+}
+
+// TestCSRFMiddlewareRejectsMutationWithoutToken verifies a POST with no CSRF
+// cookie/header is refused with a 403 rather than reaching the handler.
+func TestCSRFMiddlewareRejectsMutationWithoutToken(t *testing.T) {
+	server, _ := csrfTestServer(t)
+
+	resp, err := http.Post(server.URL+"/api/v1/energy-fields", "application/json", nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", resp.StatusCode)
+	}
+}
+
+// TestCSRFMiddlewareAcceptsMatchingCookieAndHeader verifies that a token minted
+// on a GET is accepted as the header on a subsequent mutating request carrying
+// the same anonymous cookie.
+func TestCSRFMiddlewareAcceptsMatchingCookieAndHeader(t *testing.T) {
+	server, api := csrfTestServer(t)
+
+	getResp, err := http.Get(server.URL + "/api/v1/status")
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	getResp.Body.Close()
+
+	var anonCookie, csrfCookie *http.Cookie
+	for _, c := range getResp.Cookies() {
+		switch c.Name {
+		case csrfAnonCookieName:
+			anonCookie = c
+		case api.csrf.cookieName():
+			csrfCookie = c
+		}
+	}
+	if anonCookie == nil || csrfCookie == nil {
+		t.Fatalf("expected both the anon session and CSRF cookies to be set on GET, got %+v", getResp.Cookies())
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/energy-fields", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.AddCookie(anonCookie)
+	req.AddCookie(csrfCookie)
+	req.Header.Set(api.csrf.headerName(), csrfCookie.Value)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		t.Fatalf("expected the matching token to be accepted, got 403")
+	}
+}
+
+// TestCSRFMiddlewareBypassesBearerAuth verifies bearer-authenticated callers
+// are exempt from CSRF enforcement, since they aren't exposed to the
+// cross-site browser threat CSRF tokens defend against.
+func TestCSRFMiddlewareBypassesBearerAuth(t *testing.T) {
+	server, api := csrfTestServer(t)
+	token := api.auth.tokens.Issue("ci", RoleOperator)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/energy-fields", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		t.Fatalf("expected a bearer-authenticated request to bypass CSRF enforcement, got 403")
+	}
+}
+
+// TestCSRFMiddlewareBypassesTrustedOrigin verifies a request from a
+// configured trusted Origin is exempt from CSRF enforcement.
+func TestCSRFMiddlewareBypassesTrustedOrigin(t *testing.T) {
+	server, _ := csrfTestServer(t)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/energy-fields", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Origin", "https://trusted.example")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		t.Fatalf("expected a trusted-Origin request to bypass CSRF enforcement, got 403")
+	}
+}
+
+// TestCSRFMiddlewareRejectsSessionCookiePlusGarbageAuthHeader verifies a request
+// authenticated via a valid session cookie is NOT exempted from CSRF enforcement merely
+// because it also carries an arbitrary Authorization header - only a request that actually
+// authenticates via bearer/Basic is exempt.
+func TestCSRFMiddlewareRejectsSessionCookiePlusGarbageAuthHeader(t *testing.T) {
+	server, api := csrfTestServer(t)
+
+	session := api.auth.sessions.Create("alice", RoleOperator)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/api/v1/energy-fields", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.AddCookie(&http.Cookie{Name: "tfs_session", Value: session.ID})
+	req.Header.Set("Authorization", "Bearer not-a-real-token")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a session-cookie request with a garbage Authorization header to still require a CSRF token, got %d", resp.StatusCode)
+	}
+}
+
+// TestCSRFMiddlewareIncrementsRejectedCounter verifies csrf_rejected_total is
+// counted under the "missing_session" reason when a mutating request carries
+// neither a session nor an anonymous cookie.
+func TestCSRFMiddlewareIncrementsRejectedCounter(t *testing.T) {
+	server, api := csrfTestServer(t)
+
+	resp, err := http.Post(server.URL+"/api/v1/energy-fields", "application/json", nil)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := testutil.ToFloat64(api.csrf.csrfRejected.WithLabelValues("missing_session")); got != 1 {
+		t.Fatalf("expected 1 missing_session rejection recorded, got %v", got)
+	}
+}
@@ -0,0 +1,184 @@
+/*
+ * File: src/go/api_debug_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the admin-only debug subsystem
+ * Purpose: Exercises the pprof handlers, the support-bundle zip, the role gate, and the
+ *          reactor-passthrough proxy
+ * Reason: Provides regression coverage for api.ternary.fission.debug.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial role-gate, pprof, support-bundle, and passthrough tests
+ */
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// debugTestServer builds a Ternary Fission API server with DebugEnabled and auth on, pointed
+// at a reactor stub that serves /api/v1/status and /api/v1/custom-diagnostic.
+func debugTestServer(t *testing.T) (*httptest.Server, *TernaryFissionAPIServer, string) {
+	t.Helper()
+
+	reactor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/status":
+			w.Write([]byte(`{"active_energy_fields":0,"total_energy_simulated_mev":0}`))
+		case "/api/v1/custom-diagnostic":
+			w.Write([]byte(`{"ok":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(reactor.Close)
+
+	cfg := &Config{
+		ReactorBaseURL:     reactor.URL,
+		APITimeout:         5,
+		StatusPollInterval: 60,
+		DebugEnabled:       true,
+		AuthEnabled:        true,
+		SessionCookieName:  "tfs_session",
+		SessionTTLMinutes:  60,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	t.Cleanup(server.Close)
+
+	token := api.auth.tokens.Issue("ci", RoleAdmin)
+	return server, api, token.Token
+}
+
+// TestDebugRoutesRequireAdminRole verifies an operator-scoped token is rejected from the
+// debug endpoints, which are admin-only diagnostic surface.
+func TestDebugRoutesRequireAdminRole(t *testing.T) {
+	server, api, _ := debugTestServer(t)
+	operatorToken := api.auth.tokens.Issue("ci-operator", RoleOperator)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/debug/pprof/goroutine", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+operatorToken.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for an operator-scoped token, got %d", resp.StatusCode)
+	}
+}
+
+// TestDebugPprofGoroutineServesProfile verifies the goroutine pprof handler is reachable
+// for an admin-scoped token.
+func TestDebugPprofGoroutineServesProfile(t *testing.T) {
+	server, _, adminToken := debugTestServer(t)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/debug/pprof/goroutine", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestDebugSupportBundleContainsExpectedFiles verifies the zip streamed by
+// /api/v1/debug/support-bundle contains the config, status, logs, goroutine, and metrics
+// files it promises.
+func TestDebugSupportBundleContainsExpectedFiles(t *testing.T) {
+	server, _, adminToken := debugTestServer(t)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/debug/support-bundle", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("response was not a valid zip: %v", err)
+	}
+
+	want := map[string]bool{
+		"config.json":    false,
+		"status.json":    false,
+		"logs.txt":       false,
+		"goroutines.txt": false,
+		"metrics.txt":    false,
+	}
+	for _, f := range zr.File {
+		if _, ok := want[f.Name]; ok {
+			want[f.Name] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("expected support bundle to contain %s", name)
+		}
+	}
+}
+
+// TestDebugReactorPassthroughForwardsResponse verifies an arbitrary reactor path is
+// proxied through verbatim.
+func TestDebugReactorPassthroughForwardsResponse(t *testing.T) {
+	server, _, adminToken := debugTestServer(t)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/debug/reactor-passthrough/api/v1/custom-diagnostic", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("expected the reactor stub's body to be forwarded verbatim, got %q", string(body))
+	}
+}
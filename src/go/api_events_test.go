@@ -0,0 +1,137 @@
+/*
+ * File: src/go/api_events_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the GET /api/v1/events SSE endpoint
+ * Purpose: Exercises live publish delivery, mask filtering, and since-based replay
+ * Reason: Provides regression coverage for handleEventsSSE and its publishers
+ *
+ * Change Log:
+ * 2025-08-09: Initial live-delivery, mask, and replay tests
+ */
+
+package main
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"ternary-fission/events"
+)
+
+func eventsTestServer(t *testing.T) (*httptest.Server, *TernaryFissionAPIServer) {
+	t.Helper()
+
+	cfg := &Config{
+		ReactorBaseURL:           "http://127.0.0.1:0",
+		APITimeout:               5,
+		PrometheusEnabled:        false,
+		EventsBufferCapacity:     16,
+		EventsIdleTimeoutSeconds: 2,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	t.Cleanup(server.Close)
+
+	return server, api
+}
+
+// readSSEFrame reads one "event:"/"data:" frame (and its trailing blank line) from r.
+func readSSEFrame(t *testing.T, r *bufio.Reader) (kind, data string) {
+	t.Helper()
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read SSE line: %v", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if strings.HasPrefix(line, "event: ") {
+			kind = strings.TrimPrefix(line, "event: ")
+		}
+		if strings.HasPrefix(line, "data: ") {
+			data = strings.TrimPrefix(line, "data: ")
+		}
+		if line == "" && kind != "" {
+			return kind, data
+		}
+	}
+}
+
+// TestEventsSSEDeliversPublishedEvent verifies a live Publish reaches a connected subscriber.
+func TestEventsSSEDeliversPublishedEvent(t *testing.T) {
+	server, api := eventsTestServer(t)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/events", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+
+	// We give the handler a moment to subscribe before publishing, since Subscribe races
+	// with Publish otherwise.
+	time.Sleep(50 * time.Millisecond)
+	api.events.Publish(events.KindPortalTriggered, map[string]int{"duration_seconds": 900})
+
+	kind, data := readSSEFrame(t, reader)
+	if kind != string(events.KindPortalTriggered) {
+		t.Fatalf("expected event %q, got %q", events.KindPortalTriggered, kind)
+	}
+	if !strings.Contains(data, "900") {
+		t.Fatalf("expected payload to contain duration, got %q", data)
+	}
+}
+
+// TestEventsSSEMaskFiltersOtherKinds verifies ?mask=portal suppresses field events.
+func TestEventsSSEMaskFiltersOtherKinds(t *testing.T) {
+	server, api := eventsTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/v1/events?mask=portal")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+
+	time.Sleep(50 * time.Millisecond)
+	api.events.Publish(events.KindFieldCreated, map[string]string{"field_id": "abc"})
+	api.events.Publish(events.KindPortalTriggered, map[string]string{"status": "ok"})
+
+	kind, _ := readSSEFrame(t, reader)
+	if kind != string(events.KindPortalTriggered) {
+		t.Fatalf("expected the FieldCreated event to be filtered out, got %q first", kind)
+	}
+}
+
+// TestEventsSSEReplaysSinceID verifies ?since= replays backlog before live events.
+func TestEventsSSEReplaysSinceID(t *testing.T) {
+	server, api := eventsTestServer(t)
+
+	first := api.events.Publish(events.KindFieldCreated, map[string]string{"field_id": "one"})
+	api.events.Publish(events.KindFieldCreated, map[string]string{"field_id": "two"})
+
+	resp, err := http.Get(server.URL + "/api/v1/events?since=" + strconv.FormatUint(first.ID, 10))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	kind, data := readSSEFrame(t, reader)
+	if kind != string(events.KindFieldCreated) || !strings.Contains(data, "two") {
+		t.Fatalf("expected replay of the second field event, got kind=%q data=%q", kind, data)
+	}
+}
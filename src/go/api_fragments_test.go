@@ -0,0 +1,73 @@
+/*
+ * File: src/go/api_fragments_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for fragment_burst momentum synthesis
+ * Purpose: Ensures the three emitted fragments carry a plausible energy/momentum split
+ * Reason: Provides regression coverage for the 3D trajectory viewer's data source
+ *
+ * Change Log:
+ * 2025-08-09: Initial tests for computeFragmentBurst
+ */
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestComputeFragmentBurstProducesThreeFragments confirms the ternary split (two
+// heavy fragments plus an alpha) and that the lighter alpha gets the larger share of
+// the kinetic energy, matching momentum conservation's inverse-mass partition.
+func TestComputeFragmentBurstProducesThreeFragments(t *testing.T) {
+	burst := computeFragmentBurst(42, 180.0, 235.0, 92.0)
+
+	if burst.Type != "fragment_burst" {
+		t.Errorf("expected type fragment_burst, got %q", burst.Type)
+	}
+	if burst.EventID != 42 {
+		t.Errorf("expected event id 42, got %d", burst.EventID)
+	}
+	if len(burst.Fragments) != 3 {
+		t.Fatalf("expected 3 fragments, got %d", len(burst.Fragments))
+	}
+
+	var alpha *fragment
+	totalKE := 0.0
+	for i := range burst.Fragments {
+		f := &burst.Fragments[i]
+		totalKE += f.KineticEnergyMeV
+		if f.Label == "alpha" {
+			alpha = f
+		}
+		if f.MomentumMeVPerC <= 0 {
+			t.Errorf("expected positive momentum for fragment %s, got %f", f.Label, f.MomentumMeVPerC)
+		}
+	}
+	if alpha == nil {
+		t.Fatal("expected an alpha fragment")
+	}
+	if math.Abs(totalKE-180.0) > 0.01 {
+		t.Errorf("expected fragment kinetic energies to sum to 180 MeV, got %f", totalKE)
+	}
+
+	for _, f := range burst.Fragments {
+		if f.Label != "alpha" && f.KineticEnergyMeV >= alpha.KineticEnergyMeV {
+			t.Errorf("expected heavy fragment %s to carry less kinetic energy than the alpha", f.Label)
+		}
+	}
+}
+
+// TestComputeFragmentBurstDirectionsAreUnitVectors confirms each fragment's emission
+// direction is normalized, since the dashboard viewer scales it by momentum directly.
+func TestComputeFragmentBurstDirectionsAreUnitVectors(t *testing.T) {
+	burst := computeFragmentBurst(1, 170.0, 235.0, 92.0)
+
+	for _, f := range burst.Fragments {
+		length := math.Sqrt(f.DirectionX*f.DirectionX + f.DirectionY*f.DirectionY + f.DirectionZ*f.DirectionZ)
+		if math.Abs(length-1.0) > 1e-9 {
+			t.Errorf("expected unit direction vector for %s, got length %f", f.Label, length)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/*
+ * File: src/go/api_graphql_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the GraphQL transport
+ * Purpose: Exercises the graphql-transport-ws handshake and a one-shot systemStatus query
+ *          end-to-end against an httptest server
+ * Reason: Provides regression coverage for api.ternary.fission.graphql.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial handshake and systemStatus subscription tests, plus a unit test for
+ *             graphQLRootField
+ * 2025-08-10: Added a regression test for the chunk2-1 fix - a viewer-role connection must
+ *             not reach the createEnergyField mutation resolver
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// TestGraphQLRootField confirms the root field extractor handles both shorthand queries and
+// named operations with variables.
+func TestGraphQLRootField(t *testing.T) {
+	cases := map[string]string{
+		"{ systemStatus { uptimeSeconds } }":                        "systemStatus",
+		"query Status { systemStatus { uptimeSeconds } }":           "systemStatus",
+		"subscription { statusSnapshots { uptimeSeconds } }":        "statusSnapshots",
+		"mutation($v: Int) { createEnergyField(input: $v) { id } }": "createEnergyField",
+		"not a query": "",
+	}
+
+	for query, want := range cases {
+		if got := graphQLRootField(query); got != want {
+			t.Errorf("graphQLRootField(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+// TestGraphQLSystemStatusSubscription drives a full connection_init/ack handshake, subscribes
+// to systemStatus, and checks the pushed next message matches the reactor stub's response.
+func TestGraphQLSystemStatusSubscription(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/status" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SystemStatusResponse{
+			UptimeSeconds:        42,
+			TotalEnergySimulated: 1.5,
+			ActiveEnergyFields:   2,
+		})
+	}))
+	defer stub.Close()
+
+	cfg := &Config{
+		ReactorBaseURL:                         stub.URL,
+		APITimeout:                             5,
+		PrometheusEnabled:                      false,
+		GraphQLConnectionAckWaitTimeoutSeconds: 5,
+	}
+
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/graphql"
+	dialer := websocket.Dialer{Subprotocols: []string{graphQLWSSubprotocol}}
+	conn, _, err := dialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(graphQLMessage{Type: gqlMsgConnectionInit}); err != nil {
+		t.Fatalf("failed to send connection_init: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var ack graphQLMessage
+	if err := conn.ReadJSON(&ack); err != nil {
+		t.Fatalf("failed to read connection_ack: %v", err)
+	}
+	if ack.Type != gqlMsgConnectionAck {
+		t.Fatalf("expected connection_ack, got %q", ack.Type)
+	}
+
+	subscribePayload, _ := json.Marshal(graphQLSubscribePayload{Query: "{ systemStatus { uptimeSeconds } }"})
+	if err := conn.WriteJSON(graphQLMessage{ID: "q1", Type: gqlMsgSubscribe, Payload: subscribePayload}); err != nil {
+		t.Fatalf("failed to send subscribe: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var next graphQLMessage
+	if err := conn.ReadJSON(&next); err != nil {
+		t.Fatalf("failed to read next: %v", err)
+	}
+	if next.Type != gqlMsgNext || next.ID != "q1" {
+		t.Fatalf("expected next for q1, got type=%q id=%q", next.Type, next.ID)
+	}
+
+	var body struct {
+		Data SystemStatusResponse `json:"data"`
+	}
+	if err := json.Unmarshal(next.Payload, &body); err != nil {
+		t.Fatalf("failed to decode next payload: %v", err)
+	}
+	if body.Data.UptimeSeconds != 42 || body.Data.ActiveEnergyFields != 2 {
+		t.Fatalf("unexpected status in next payload: %+v", body.Data)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var complete graphQLMessage
+	if err := conn.ReadJSON(&complete); err != nil {
+		t.Fatalf("failed to read complete: %v", err)
+	}
+	if complete.Type != gqlMsgComplete || complete.ID != "q1" {
+		t.Fatalf("expected complete for q1, got type=%q id=%q", complete.Type, complete.ID)
+	}
+}
+
+// TestGraphQLViewerCannotCreateEnergyField verifies a viewer-role session connected over
+// /graphql gets "insufficient role" from createEnergyField, the same RoleOperator floor the
+// REST equivalent (POST /api/v1/energy-fields) enforces - regression coverage for the chunk2-1
+// fix, since requireRole(RoleViewer, ...) on the route only gates the WebSocket upgrade, not
+// each operation sent over the connection afterward.
+func TestGraphQLViewerCannotCreateEnergyField(t *testing.T) {
+	cfg := &Config{
+		ReactorBaseURL:                         "http://127.0.0.1:0",
+		APITimeout:                             5,
+		PrometheusEnabled:                      false,
+		AuthEnabled:                            true,
+		SessionCookieName:                      "tfs_session",
+		SessionTTLMinutes:                      60,
+		GraphQLConnectionAckWaitTimeoutSeconds: 5,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	session := api.auth.sessions.Create("viewer-user", RoleViewer)
+	header := http.Header{"Cookie": {cfg.SessionCookieName + "=" + session.ID}}
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/graphql"
+	dialer := websocket.Dialer{Subprotocols: []string{graphQLWSSubprotocol}}
+	conn, _, err := dialer.Dial(wsURL, header)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(graphQLMessage{Type: gqlMsgConnectionInit}); err != nil {
+		t.Fatalf("failed to send connection_init: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var ack graphQLMessage
+	if err := conn.ReadJSON(&ack); err != nil {
+		t.Fatalf("failed to read connection_ack: %v", err)
+	}
+
+	subscribePayload, _ := json.Marshal(graphQLSubscribePayload{
+		Query:     "mutation { createEnergyField(input: $v) { field_id } }",
+		Variables: map[string]interface{}{"field_name": "viewer-probe"},
+	})
+	if err := conn.WriteJSON(graphQLMessage{ID: "m1", Type: gqlMsgSubscribe, Payload: subscribePayload}); err != nil {
+		t.Fatalf("failed to send subscribe: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var reply graphQLMessage
+	if err := conn.ReadJSON(&reply); err != nil {
+		t.Fatalf("failed to read reply: %v", err)
+	}
+	if reply.Type != gqlMsgError || reply.ID != "m1" {
+		t.Fatalf("expected an error for m1 from a viewer-role caller, got type=%q id=%q", reply.Type, reply.ID)
+	}
+
+	var errs []map[string]string
+	if err := json.Unmarshal(reply.Payload, &errs); err != nil {
+		t.Fatalf("failed to decode error payload: %v", err)
+	}
+	if len(errs) == 0 || !strings.Contains(errs[0]["message"], "insufficient role") {
+		t.Fatalf("expected an insufficient-role error message, got %+v", errs)
+	}
+}
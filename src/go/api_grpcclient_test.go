@@ -0,0 +1,196 @@
+/*
+ * File: src/go/api_grpcclient_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the gRPC reactor transport
+ * Purpose: Exercises grpcReactorClient's Do/Get translation layer and streamStatusEvents
+ *          against an in-process ReactorTransportServer, using bufconn in place of a real
+ *          listener the same way httptest.NewServer stands in for one on the HTTP side
+ * Reason: Provides regression coverage for api.ternary.fission.grpcclient.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial GetStatus/ListEnergyFields/GetEnergyField/streamStatusEvents tests
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"ternary-fission/pb"
+)
+
+// fakeReactorTransportServer is a minimal in-memory ReactorTransportServer standing in for the
+// reactor's own gRPC implementation.
+type fakeReactorTransportServer struct {
+	pb.UnimplementedReactorTransportServer
+	status SystemStatusResponse
+	fields map[string]EnergyFieldResponse
+	events []pb.FissionEvent
+}
+
+func (f *fakeReactorTransportServer) GetStatus(ctx context.Context, _ *pb.GetStatusRequest) (*pb.SystemStatus, error) {
+	return &pb.SystemStatus{
+		UptimeSeconds:           f.status.UptimeSeconds,
+		TotalFissionEvents:      f.status.TotalFissionEvents,
+		TotalEnergySimulatedMeV: f.status.TotalEnergySimulated,
+		SimulationRunning:       f.status.SimulationRunning,
+	}, nil
+}
+
+func (f *fakeReactorTransportServer) GetEnergyField(ctx context.Context, req *pb.GetEnergyFieldRequest) (*pb.EnergyField, error) {
+	field := f.fields[req.FieldID]
+	return &pb.EnergyField{FieldID: field.FieldID, EnergyMeV: field.EnergyMeV, Active: field.Active}, nil
+}
+
+func (f *fakeReactorTransportServer) ListEnergyFields(ctx context.Context, _ *pb.ListEnergyFieldsRequest) (*pb.ListEnergyFieldsResponse, error) {
+	resp := &pb.ListEnergyFieldsResponse{}
+	for _, field := range f.fields {
+		resp.Fields = append(resp.Fields, pb.EnergyField{FieldID: field.FieldID, EnergyMeV: field.EnergyMeV, Active: field.Active})
+	}
+	return resp, nil
+}
+
+func (f *fakeReactorTransportServer) SubscribeEvents(_ *pb.SubscribeEventsRequest, stream pb.ReactorTransport_SubscribeEventsServer) error {
+	for i := range f.events {
+		if err := stream.Send(&f.events[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dialFakeReactorTransport starts fake on a bufconn listener and returns a grpcReactorClient
+// dialed against it; t.Cleanup tears both down.
+func dialFakeReactorTransport(t *testing.T, fake *fakeReactorTransportServer) *grpcReactorClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	pb.RegisterReactorTransportServer(grpcServer, fake)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufconn reactor transport: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &grpcReactorClient{
+		server: reactorClientTestServer(t, nil),
+		conn:   conn,
+		client: pb.NewReactorTransportClient(conn),
+	}
+}
+
+func TestGRPCReactorClientGetStatus(t *testing.T) {
+	fake := &fakeReactorTransportServer{status: SystemStatusResponse{TotalFissionEvents: 42, SimulationRunning: true}}
+	client := dialFakeReactorTransport(t, fake)
+
+	resp, err := client.Get("http://reactor.invalid/api/v1/status")
+	if err != nil {
+		t.Fatalf("Get status failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var status SystemStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if status.TotalFissionEvents != 42 || !status.SimulationRunning {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestGRPCReactorClientGetEnergyField(t *testing.T) {
+	fake := &fakeReactorTransportServer{fields: map[string]EnergyFieldResponse{
+		"field-1": {FieldID: "field-1", EnergyMeV: 10.5, Active: true},
+	}}
+	client := dialFakeReactorTransport(t, fake)
+
+	resp, err := client.Get("http://reactor.invalid/api/v1/energy-fields/field-1")
+	if err != nil {
+		t.Fatalf("Get energy field failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var field EnergyFieldResponse
+	if err := json.NewDecoder(resp.Body).Decode(&field); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if field.FieldID != "field-1" || field.EnergyMeV != 10.5 {
+		t.Fatalf("unexpected field: %+v", field)
+	}
+}
+
+func TestGRPCReactorClientListEnergyFields(t *testing.T) {
+	fake := &fakeReactorTransportServer{fields: map[string]EnergyFieldResponse{
+		"field-1": {FieldID: "field-1", EnergyMeV: 1},
+		"field-2": {FieldID: "field-2", EnergyMeV: 2},
+	}}
+	client := dialFakeReactorTransport(t, fake)
+
+	resp, err := client.Get("http://reactor.invalid/api/v1/energy-fields")
+	if err != nil {
+		t.Fatalf("list energy fields failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var fields []EnergyFieldResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(fields) != 2 {
+		t.Fatalf("expected 2 fields, got %d", len(fields))
+	}
+}
+
+func TestGRPCReactorClientStreamStatusEvents(t *testing.T) {
+	fake := &fakeReactorTransportServer{events: []pb.FissionEvent{
+		{EventID: 1, Status: &pb.SystemStatus{TotalFissionEvents: 1}},
+		{EventID: 2, Status: &pb.SystemStatus{TotalFissionEvents: 2}},
+	}}
+	client := dialFakeReactorTransport(t, fake)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var received []SystemStatusResponse
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.streamStatusEvents(ctx, func(status SystemStatusResponse) {
+			received = append(received, status)
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(4 * time.Second):
+		t.Fatal("streamStatusEvents did not return after the server finished sending")
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 pushed statuses, got %d: %+v", len(received), received)
+	}
+	if received[0].TotalFissionEvents != 1 || received[1].TotalFissionEvents != 2 {
+		t.Fatalf("unexpected pushed statuses: %+v", received)
+	}
+}
@@ -104,6 +104,13 @@ func TestGetSystemStatusParsesResponse(t *testing.T) {
         t.Fatalf("decode failed: %v", err)
     }
 
+    // TimestampUnix is stamped with time.Now() by fetchSystemStatus, so it can't be part of
+    // the literal comparison below; we only check that it was actually set.
+    if got.TimestampUnix == 0 {
+        t.Fatal("expected TimestampUnix to be stamped")
+    }
+    got.TimestampUnix = 0
+
     if got != expected {
         t.Fatalf("unexpected status: %+v", got)
     }
@@ -0,0 +1,231 @@
+/*
+ * File: src/go/api_jsoncodec_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests and benchmarks for the pluggable JSON codec
+ * Purpose: Confirms stdlibJSONCodec and jsoniterJSONCodec produce byte-identical output for the
+ *          reactor proxy path's response types, that TestGetSystemStatusParsesResponse-style
+ *          forwarding keeps working under either codec, and benchmarks forwarding throughput
+ *          for /api/v1/status and /api/v1/energy-fields under concurrent load
+ * Reason: Provides regression coverage for api.ternary.fission.jsoncodec.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial byte-for-byte compatibility tests and concurrent forwarding benchmarks
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func sampleSystemStatusResponse() SystemStatusResponse {
+	return SystemStatusResponse{
+		UptimeSeconds:         3600,
+		TotalFissionEvents:    128,
+		TotalEnergySimulated:  9876.5,
+		ActiveEnergyFields:    4,
+		PeakMemoryUsage:       1048576,
+		AverageCalcTime:       12.34,
+		TotalCalculations:     512,
+		SimulationRunning:     true,
+		CPUUsagePercent:       42.1,
+		MemoryUsagePercent:    17.5,
+		EstimatedPower:        321.9,
+		PortalDurationRemain:  60,
+		DecayHeatMeVPerSecond: 0.042,
+		TimestampUnix:         1700000000,
+	}
+}
+
+func sampleEnergyFieldResponses() []EnergyFieldResponse {
+	return []EnergyFieldResponse{
+		{
+			FieldID:             "field-1",
+			EnergyMeV:           10.5,
+			MemoryBytes:         2048,
+			CPUCycles:           4096,
+			EntropyFactor:       0.5,
+			DissipationRate:     0.1,
+			StabilityFactor:     0.9,
+			InteractionStrength: 0.3,
+			Active:              true,
+			TotalEnergyMeV:      11.2,
+			Status:              "active",
+		},
+		{
+			FieldID:   "field-2",
+			EnergyMeV: 5.25,
+			Status:    "dissipated",
+		},
+	}
+}
+
+// TestJSONCodecsProduceIdenticalOutput confirms jsoniterJSONCodec's output for the reactor proxy
+// path's response types is byte-for-byte identical to stdlibJSONCodec's, so swapping
+// Config.JSONCodec never changes what a client observes on the wire.
+func TestJSONCodecsProduceIdenticalOutput(t *testing.T) {
+	cases := []interface{}{
+		sampleSystemStatusResponse(),
+		sampleEnergyFieldResponses(),
+		sampleEnergyFieldResponses()[0],
+	}
+
+	for i, data := range cases {
+		stdlibOut, err := (stdlibJSONCodec{}).Marshal(data)
+		if err != nil {
+			t.Fatalf("case %d: stdlib marshal failed: %v", i, err)
+		}
+		jsoniterOut, err := (jsoniterJSONCodec{}).Marshal(data)
+		if err != nil {
+			t.Fatalf("case %d: jsoniter marshal failed: %v", i, err)
+		}
+		if string(stdlibOut) != string(jsoniterOut) {
+			t.Fatalf("case %d: codec output differs\nstdlib:   %s\njsoniter: %s", i, stdlibOut, jsoniterOut)
+		}
+	}
+}
+
+// TestJSONCodecFor confirms the empty string (the zero value for a *Config built without
+// defaultConfig, as several existing integration tests do) resolves the same as "stdlib".
+func TestJSONCodecFor(t *testing.T) {
+	if _, ok := jsonCodecFor("").(stdlibJSONCodec); !ok {
+		t.Fatalf("jsonCodecFor(\"\") = %T, want stdlibJSONCodec", jsonCodecFor(""))
+	}
+	if _, ok := jsonCodecFor("stdlib").(stdlibJSONCodec); !ok {
+		t.Fatalf("jsonCodecFor(\"stdlib\") = %T, want stdlibJSONCodec", jsonCodecFor("stdlib"))
+	}
+	if _, ok := jsonCodecFor("jsoniter").(jsoniterJSONCodec); !ok {
+		t.Fatalf("jsonCodecFor(\"jsoniter\") = %T, want jsoniterJSONCodec", jsonCodecFor("jsoniter"))
+	}
+	if _, ok := jsonCodecFor("bogus").(stdlibJSONCodec); !ok {
+		t.Fatalf("jsonCodecFor(\"bogus\") = %T, want stdlibJSONCodec", jsonCodecFor("bogus"))
+	}
+}
+
+// TestGetSystemStatusParsesResponseUnderJSONCodec mirrors
+// TestGetSystemStatusParsesResponse in api_integration_test.go, but exercises both codec
+// configurations explicitly so a future codec change can't silently break one of them.
+func TestGetSystemStatusParsesResponseUnderJSONCodec(t *testing.T) {
+	for _, codecName := range []string{"stdlib", "jsoniter"} {
+		t.Run(codecName, func(t *testing.T) {
+			expected := sampleSystemStatusResponse()
+
+			stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(expected)
+			}))
+			defer stub.Close()
+
+			config := &Config{
+				ReactorBaseURL:    stub.URL,
+				APITimeout:        5,
+				PrometheusEnabled: false,
+				JSONCodec:         codecName,
+			}
+			server := NewTernaryFissionAPIServer(config)
+			defer server.cancelFunc()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+			rr := httptest.NewRecorder()
+			server.getSystemStatus(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+
+			var got SystemStatusResponse
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			// DecayHeatMeVPerSecond is always recomputed locally from the decay tracker
+			// (fetchSystemStatus), never trusted from the reactor's response, same as
+			// TimestampUnix - zero both before comparing.
+			got.TimestampUnix = 0
+			expected.TimestampUnix = 0
+			got.DecayHeatMeVPerSecond = 0
+			expected.DecayHeatMeVPerSecond = 0
+			if got != expected {
+				t.Fatalf("unexpected status under %s codec: got %+v, want %+v", codecName, got, expected)
+			}
+		})
+	}
+}
+
+func benchmarkStatusForwarding(b *testing.B, codecName string) {
+	status := sampleSystemStatusResponse()
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(status)
+	}))
+	defer stub.Close()
+
+	server := NewTernaryFissionAPIServer(&Config{
+		ReactorBaseURL:    stub.URL,
+		APITimeout:        5,
+		PrometheusEnabled: false,
+		JSONCodec:         codecName,
+	})
+	defer server.cancelFunc()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+			rr := httptest.NewRecorder()
+			server.getSystemStatus(rr, req)
+			io.Copy(io.Discard, rr.Body)
+		}
+	})
+}
+
+func BenchmarkStatusForwardingStdlib(b *testing.B)   { benchmarkStatusForwarding(b, "stdlib") }
+func BenchmarkStatusForwardingJSONIter(b *testing.B) { benchmarkStatusForwarding(b, "jsoniter") }
+
+// benchmarkEnergyFieldsForwarding measures listEnergyFields, which io.Copys the reactor's raw
+// body straight through (see TestListEnergyFieldsForwards) rather than decoding/re-encoding it -
+// so JSONCodec doesn't touch this leg over HTTP transport today, and the two variants below are
+// expected to land within noise of each other. It's kept alongside the status benchmark so a
+// future change that does add a decode/encode step here (e.g. the gRPC transport's jsonResponse,
+// which does use JSONCodec) has an existing baseline to compare against.
+func benchmarkEnergyFieldsForwarding(b *testing.B, codecName string) {
+	fields := sampleEnergyFieldResponses()
+	body, err := json.Marshal(fields)
+	if err != nil {
+		b.Fatalf("marshal fixture: %v", err)
+	}
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	defer stub.Close()
+
+	server := NewTernaryFissionAPIServer(&Config{
+		ReactorBaseURL:    stub.URL,
+		APITimeout:        5,
+		PrometheusEnabled: false,
+		JSONCodec:         codecName,
+	})
+	defer server.cancelFunc()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/energy-fields", nil)
+			rr := httptest.NewRecorder()
+			server.listEnergyFields(rr, req)
+			io.Copy(io.Discard, rr.Body)
+		}
+	})
+}
+
+func BenchmarkEnergyFieldsForwardingStdlib(b *testing.B) {
+	benchmarkEnergyFieldsForwarding(b, "stdlib")
+}
+func BenchmarkEnergyFieldsForwardingJSONIter(b *testing.B) {
+	benchmarkEnergyFieldsForwarding(b, "jsoniter")
+}
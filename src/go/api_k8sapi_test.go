@@ -0,0 +1,194 @@
+/*
+ * File: src/go/api_k8sapi_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the Kubernetes-shaped read surface
+ * Purpose: Confirms the /apis/ternary.fission.io/v1 routes are only mounted when
+ *          Config.K8sAPIEnabled is set, and that discovery/list/get/status responses are
+ *          shaped the way a Kubernetes client expects
+ * Reason: Provides regression coverage for api.ternary.fission.k8sapi.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial discovery/energyfields/reactorstatuses/fissionevents tests
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newK8sAPITestServer(t *testing.T, reactorHandler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	stub := httptest.NewServer(reactorHandler)
+	t.Cleanup(stub.Close)
+
+	api := NewTernaryFissionAPIServer(&Config{
+		ReactorBaseURL:    stub.URL,
+		APITimeout:        5,
+		PrometheusEnabled: false,
+		K8sAPIEnabled:     true,
+	})
+	t.Cleanup(api.cancelFunc)
+
+	server := httptest.NewServer(api.router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestK8sAPIRoutesNotMountedWhenDisabled(t *testing.T) {
+	api := NewTernaryFissionAPIServer(&Config{APITimeout: 5, PrometheusEnabled: false})
+	defer api.cancelFunc()
+
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/apis/" + k8sGroupVersion)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected 404 when K8sAPIEnabled is false, got %d", resp.StatusCode)
+	}
+}
+
+func TestK8sAPIDiscovery(t *testing.T) {
+	server := newK8sAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("reactor should not be contacted for discovery, got %s", r.URL.Path)
+	})
+
+	resp, err := http.Get(server.URL + "/apis/" + k8sGroupVersion)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	var list k8sAPIResourceList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if list.GroupVersion != k8sGroupVersion {
+		t.Fatalf("expected groupVersion %q, got %q", k8sGroupVersion, list.GroupVersion)
+	}
+	if len(list.Resources) == 0 {
+		t.Fatal("expected at least one discovered resource")
+	}
+}
+
+func TestK8sAPIEnergyFieldList(t *testing.T) {
+	server := newK8sAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/energy-fields" {
+			t.Fatalf("unexpected reactor path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"field_id":"field-1","energy_mev":10.5,"status":"active"}]`))
+	})
+
+	resp, err := http.Get(server.URL + "/apis/" + k8sGroupVersion + "/energyfields")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var list k8sEnergyFieldList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(list.Items))
+	}
+	item := list.Items[0]
+	if item.Kind != "EnergyField" || item.Metadata.Name != "field-1" || item.Spec.EnergyMeV != 10.5 {
+		t.Fatalf("unexpected item: %+v", item)
+	}
+	if item.Status.Phase != "active" {
+		t.Fatalf("expected status.phase=active, got %q", item.Status.Phase)
+	}
+}
+
+func TestK8sAPIEnergyFieldGet(t *testing.T) {
+	server := newK8sAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/energy-fields/field-1" {
+			t.Fatalf("unexpected reactor path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"field_id":"field-1","energy_mev":10.5,"status":"active"}`))
+	})
+
+	resp, err := http.Get(server.URL + "/apis/" + k8sGroupVersion + "/energyfields/field-1")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var field k8sEnergyField
+	if err := json.NewDecoder(resp.Body).Decode(&field); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if field.Metadata.Name != "field-1" || field.Spec.EnergyMeV != 10.5 {
+		t.Fatalf("unexpected field: %+v", field)
+	}
+}
+
+func TestK8sAPIReactorStatus(t *testing.T) {
+	server := newK8sAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/status" {
+			t.Fatalf("unexpected reactor path: %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SystemStatusResponse{TotalFissionEvents: 7, SimulationRunning: true})
+	})
+
+	resp, err := http.Get(server.URL + "/apis/" + k8sGroupVersion + "/reactorstatuses/cluster")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var status k8sReactorStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if status.Metadata.Name != "cluster" || status.Status.TotalFissionEvents != 7 || !status.Status.SimulationRunning {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	statusSubresource, err := http.Get(server.URL + "/apis/" + k8sGroupVersion + "/reactorstatuses/cluster/status")
+	if err != nil {
+		t.Fatalf("status subresource request failed: %v", err)
+	}
+	defer statusSubresource.Body.Close()
+	if statusSubresource.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 from status subresource, got %d", statusSubresource.StatusCode)
+	}
+}
+
+func TestK8sAPIFissionEventList(t *testing.T) {
+	server := newK8sAPITestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("reactor should not be contacted for fissionevents, got %s", r.URL.Path)
+	})
+
+	resp, err := http.Get(server.URL + "/apis/" + k8sGroupVersion + "/fissionevents")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var list k8sFissionEventList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if list.Kind != "FissionEventList" {
+		t.Fatalf("unexpected kind: %q", list.Kind)
+	}
+}
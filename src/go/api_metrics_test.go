@@ -0,0 +1,140 @@
+/*
+ * File: src/go/api_metrics_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 5, 2025
+ * Title: Tests for Prometheus scrape target discovery and remote_write encoding
+ * Purpose: Ensures /api/v1/targets reflects configured reactor endpoints and the
+ *          hand-rolled WriteRequest encoder produces a parseable protobuf payload
+ * Reason: Provides regression coverage for the federation subsystem
+ *
+ * Change Log:
+ * 2025-08-05: Initial tests for HTTP SD targets and WriteRequest marshaling
+ * 2025-08-10: Added a regression test for the chunk0-2 fix - pushRemoteWrite must not leak
+ *             the reactor's own Authorization header to Config.RemoteWriteURL
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// TestHandleScrapeTargetsUsesConfiguredEndpoints verifies the HTTP SD JSON
+// response enumerates every configured reactor endpoint.
+func TestHandleScrapeTargetsUsesConfiguredEndpoints(t *testing.T) {
+	cfg := &Config{
+		ReactorBaseURL:     "http://127.0.0.1:8333",
+		ReactorEndpoints:   []string{"http://127.0.0.1:8333", "http://127.0.0.1:8334"},
+		APITimeout:         5,
+		StatusPollInterval: 60,
+		PrometheusEnabled:  true,
+	}
+
+	api := NewTernaryFissionAPIServer(cfg)
+	defer func() {
+		prometheus.Unregister(api.auth.authFailures)
+		prometheus.Unregister(api.rateLimitHits)
+		prometheus.Unregister(api.requestCounter)
+		prometheus.Unregister(api.responseTime)
+		prometheus.Unregister(api.reactorActiveFields)
+		prometheus.Unregister(api.reactorTotalEnergy)
+		prometheus.Unregister(api.reactorTimeouts)
+		prometheus.Unregister(api.csrf.csrfRejected)
+	}()
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/targets")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var targets []ScrapeTarget
+	if err := json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d", len(targets))
+	}
+	if targets[0].Targets[0] != "127.0.0.1:8333" {
+		t.Errorf("unexpected target host: %s", targets[0].Targets[0])
+	}
+}
+
+// TestPushRemoteWriteDoesNotLeakReactorAuthHeader verifies pushRemoteWrite's POST to
+// Config.RemoteWriteURL never carries the reactor's own Authorization header - regression
+// coverage for the chunk0-2 fix, since remote_write targets are arbitrary third-party
+// endpoints and must not inherit the reactor client's auth injection.
+func TestPushRemoteWriteDoesNotLeakReactorAuthHeader(t *testing.T) {
+	var gotAuth string
+	var sawRequest bool
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawRequest = true
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer stub.Close()
+
+	cfg := &Config{
+		ReactorBaseURL:     "http://127.0.0.1:8333",
+		ReactorAuthToken:   "super-secret-reactor-token",
+		RemoteWriteURL:     stub.URL,
+		APITimeout:         5,
+		StatusPollInterval: 60,
+		PrometheusEnabled:  true,
+	}
+
+	api := NewTernaryFissionAPIServer(cfg)
+	defer func() {
+		prometheus.Unregister(api.auth.authFailures)
+		prometheus.Unregister(api.rateLimitHits)
+		prometheus.Unregister(api.requestCounter)
+		prometheus.Unregister(api.responseTime)
+		prometheus.Unregister(api.reactorActiveFields)
+		prometheus.Unregister(api.reactorTotalEnergy)
+		prometheus.Unregister(api.reactorTimeouts)
+		prometheus.Unregister(api.csrf.csrfRejected)
+	}()
+
+	api.pushRemoteWrite(1, 2)
+
+	if !sawRequest {
+		t.Fatal("expected the remote_write stub to receive a request")
+	}
+	if gotAuth != "" {
+		t.Fatalf("expected no Authorization header on the remote_write push, got %q", gotAuth)
+	}
+}
+
+// TestMarshalWriteRequestRoundTrips confirms the hand-encoded WriteRequest
+// bytes are valid protobuf that protowire can walk back through.
+func TestMarshalWriteRequestRoundTrips(t *testing.T) {
+	series := []remoteWriteSeries{
+		{
+			Labels:  []remoteWriteLabel{{Name: "__name__", Value: "reactor_active_fields"}},
+			Samples: []remoteWriteSample{{Value: 3, Timestamp: 1000}},
+		},
+	}
+
+	buf := marshalWriteRequest(series)
+	if len(buf) == 0 {
+		t.Fatal("expected non-empty payload")
+	}
+
+	// We walk the top-level WriteRequest.timeseries field (tag 1, bytes) to
+	// confirm it is well-formed without needing the generated prompb types.
+	num, typ, n := protowire.ConsumeTag(buf)
+	if n < 0 {
+		t.Fatalf("failed to consume tag: %v", protowire.ParseError(n))
+	}
+	if num != 1 || typ != protowire.BytesType {
+		t.Fatalf("unexpected field: num=%d type=%v", num, typ)
+	}
+}
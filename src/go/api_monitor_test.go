@@ -0,0 +1,133 @@
+/*
+ * File: src/go/api_monitor_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the multi-transport real-time monitor
+ * Purpose: Confirms the SSE and long-poll transports both surface a reactor status snapshot
+ *          published by MonitorTransportNegotiator
+ * Reason: Provides regression coverage for api.ternary.fission.monitor.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial SSE and long-poll transport tests
+ */
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMonitorSSEStreamsStatus confirms /api/v1/monitor/sse emits an "event: status" frame
+// carrying the reactor stub's status.
+func TestMonitorSSEStreamsStatus(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SystemStatusResponse{UptimeSeconds: 7, ActiveEnergyFields: 1})
+	}))
+	defer stub.Close()
+
+	cfg := &Config{
+		ReactorBaseURL:        stub.URL,
+		APITimeout:            5,
+		WebSocketEnabled:      true,
+		WebSocketPingInterval: 1,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	req, err := http.NewRequest("GET", server.URL+"/api/v1/monitor/sse", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", resp.Header.Get("Content-Type"))
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	var dataLine string
+	for i := 0; i < 20; i++ {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read SSE stream: %v", err)
+		}
+		if strings.HasPrefix(line, "data: ") {
+			dataLine = strings.TrimPrefix(strings.TrimSpace(line), "data: ")
+			break
+		}
+	}
+	if dataLine == "" {
+		t.Fatal("did not receive a data: line from SSE stream")
+	}
+
+	var status SystemStatusResponse
+	if err := json.Unmarshal([]byte(dataLine), &status); err != nil {
+		t.Fatalf("failed to decode SSE payload: %v", err)
+	}
+	if status.UptimeSeconds != 7 || status.ActiveEnergyFields != 1 {
+		t.Fatalf("unexpected status in SSE payload: %+v", status)
+	}
+}
+
+// TestMonitorLongPollReturnsNextSnapshot confirms /api/v1/monitor/poll blocks until a
+// snapshot newer than ?cursor= is available, then returns it with an advanced next_cursor.
+func TestMonitorLongPollReturnsNextSnapshot(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SystemStatusResponse{UptimeSeconds: 9, ActiveEnergyFields: 3})
+	}))
+	defer stub.Close()
+
+	cfg := &Config{
+		ReactorBaseURL:        stub.URL,
+		APITimeout:            5,
+		WebSocketEnabled:      true,
+		WebSocketPingInterval: 1,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	// A single poll's internal timeout is exactly the negotiator's own tick interval, so we
+	// may race a tick that fires just after the timeout; retrying a few times (each call
+	// re-arms with the last next_cursor) tolerates that without weakening what's asserted.
+	var body monitorPollResponse
+	cursor := uint64(0)
+	for attempt := 0; attempt < 5 && body.Status == nil; attempt++ {
+		resp, err := client.Get(fmt.Sprintf("%s/api/v1/monitor/poll?cursor=%d", server.URL, cursor))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			resp.Body.Close()
+			t.Fatalf("failed to decode long-poll response: %v", err)
+		}
+		resp.Body.Close()
+		cursor = body.NextCursor
+	}
+	if body.Status == nil {
+		t.Fatal("expected a status snapshot, got nil")
+	}
+	if body.Status.UptimeSeconds != 9 || body.Status.ActiveEnergyFields != 3 {
+		t.Fatalf("unexpected status in long-poll response: %+v", body.Status)
+	}
+	if body.NextCursor == 0 {
+		t.Fatal("expected next_cursor to advance past 0")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * File: src/go/api_montecarlo_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the Monte Carlo local-energy estimator
+ * Purpose: Ensures the trial-wavefunction registry resolves/falls back correctly and that
+ *          Sample() reports a finite, bounded-acceptance estimate and respects registration
+ * Reason: Provides regression coverage for api.ternary.fission.montecarlo.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial tests for resolveTrialWavefunction, LocalEnergyEstimator.Sample, and
+ *             LocalEnergyRegistry's Register/Sample/Forget lifecycle
+ */
+
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// TestResolveTrialWavefunctionFallsBackToDefault confirms an empty or unrecognized name
+// resolves to defaultTrialWavefunctionName rather than a nil wavefunction.
+func TestResolveTrialWavefunctionFallsBackToDefault(t *testing.T) {
+	for _, name := range []string{"", "not_a_real_wavefunction"} {
+		wf := resolveTrialWavefunction(name)
+		if wf.Name() != defaultTrialWavefunctionName {
+			t.Errorf("resolveTrialWavefunction(%q) = %q, want %q", name, wf.Name(), defaultTrialWavefunctionName)
+		}
+	}
+
+	wf := resolveTrialWavefunction("slater_jastrow")
+	if wf.Name() != "slater_jastrow" {
+		t.Errorf("expected slater_jastrow, got %q", wf.Name())
+	}
+}
+
+// TestLocalEnergyEstimatorSampleProducesFiniteStats confirms Sample() returns a finite mean
+// and non-negative variance, and an acceptance ratio within [0, 1].
+func TestLocalEnergyEstimatorSampleProducesFiniteStats(t *testing.T) {
+	estimator := NewLocalEnergyEstimator(resolveTrialWavefunction(defaultTrialWavefunctionName), defaultEnergyFieldPotential(92))
+
+	estimate := estimator.Sample()
+	if math.IsNaN(estimate.MeanMeV) || math.IsInf(estimate.MeanMeV, 0) {
+		t.Errorf("expected a finite mean, got %v", estimate.MeanMeV)
+	}
+	if estimate.Variance < 0 {
+		t.Errorf("expected non-negative variance, got %v", estimate.Variance)
+	}
+	if estimate.AcceptanceRatio < 0 || estimate.AcceptanceRatio > 1 {
+		t.Errorf("expected acceptance ratio in [0, 1], got %v", estimate.AcceptanceRatio)
+	}
+	if estimate.NWalkers != defaultWalkerCount {
+		t.Errorf("expected %d walkers, got %d", defaultWalkerCount, estimate.NWalkers)
+	}
+}
+
+// TestLocalEnergyRegistryLifecycle confirms Sample() only succeeds after Register(), and
+// Forget() removes the estimator so a later Sample() reports ok=false again.
+func TestLocalEnergyRegistryLifecycle(t *testing.T) {
+	registry := NewLocalEnergyRegistry()
+
+	if _, ok := registry.Sample("field-1"); ok {
+		t.Fatal("expected Sample() to fail before Register()")
+	}
+
+	registry.Register("field-1", "hydrogenic_1s", defaultEnergyFieldPotential(92))
+	if _, ok := registry.Sample("field-1"); !ok {
+		t.Fatal("expected Sample() to succeed after Register()")
+	}
+
+	registry.Forget("field-1")
+	if _, ok := registry.Sample("field-1"); ok {
+		t.Fatal("expected Sample() to fail after Forget()")
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * File: src/go/api_physics_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the physics-list registry
+ * Purpose: Ensures the default physics list is seeded correctly and custom recipes
+ *          register/activate/override as expected
+ * Reason: Provides regression coverage for the /api/v1/physics-lists handlers
+ *
+ * Change Log:
+ * 2025-08-09: Initial tests for PhysicsList/PhysicsListRegistry and recipe decoding
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestNewPhysicsListRegistrySeedsDefault confirms FTFP_TERNARY_DEFAULT is registered
+// and active out of the box, reproducing the server's historical fixed behavior.
+func TestNewPhysicsListRegistrySeedsDefault(t *testing.T) {
+	registry := NewPhysicsListRegistry()
+
+	summaries := registry.Snapshot()
+	if len(summaries) != 1 {
+		t.Fatalf("expected exactly 1 default list, got %d", len(summaries))
+	}
+	if summaries[0].Name != defaultPhysicsListName {
+		t.Errorf("expected default list %q, got %q", defaultPhysicsListName, summaries[0].Name)
+	}
+	if !summaries[0].Active {
+		t.Error("expected the default list to be active")
+	}
+}
+
+// TestPhysicsListRegisterProcessOverridesSameName confirms re-registering a process
+// under a name already present replaces it rather than adding a duplicate.
+func TestPhysicsListRegisterProcessOverridesSameName(t *testing.T) {
+	list := NewPhysicsList("test-list")
+	list.RegisterProcess("TernaryFission", physicsProcessFactories["TernaryFission"](1, nil))
+	list.RegisterProcess("TernaryFission", physicsProcessFactories["TernaryFission"](2, map[string]float64{"cross_section_barns": 2.5}))
+
+	processes := list.Processes()
+	if len(processes) != 1 {
+		t.Fatalf("expected 1 process after override, got %d", len(processes))
+	}
+	if got := processes[0].CrossSectionBarns(1.0); got != 2.5 {
+		t.Errorf("expected overridden cross section 2.5, got %f", got)
+	}
+}
+
+// TestHandlePhysicsListsPostRegistersAndActivates exercises the POST handler end to end:
+// a valid recipe should be registered, activated, and reflected in a subsequent GET.
+func TestHandlePhysicsListsPostRegistersAndActivates(t *testing.T) {
+	config := &Config{}
+	server := &TernaryFissionAPIServer{physicsLists: NewPhysicsListRegistry()}
+	_ = config
+
+	recipe := physicsListRecipe{
+		Name: "custom-list",
+		Processes: []physicsListRecipeProcess{
+			{Name: "BinaryFission", Seed: 7},
+			{Name: "NeutronTransport"},
+		},
+		Activate: true,
+	}
+	body, _ := json.Marshal(recipe)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/physics-lists", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	server.handlePhysicsLists(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	summaries := server.physicsLists.Snapshot()
+	var found *physicsListSummary
+	for i := range summaries {
+		if summaries[i].Name == "custom-list" {
+			found = &summaries[i]
+		}
+	}
+	if found == nil {
+		t.Fatal("expected custom-list to be registered")
+	}
+	if !found.Active {
+		t.Error("expected custom-list to be active after Activate: true")
+	}
+	if len(found.Processes) != 2 {
+		t.Errorf("expected 2 processes, got %d", len(found.Processes))
+	}
+}
+
+// TestHandlePhysicsListsPostRejectsUnknownProcess confirms an unrecognized process name
+// is rejected with 400 rather than silently registering a partial list.
+func TestHandlePhysicsListsPostRejectsUnknownProcess(t *testing.T) {
+	server := &TernaryFissionAPIServer{physicsLists: NewPhysicsListRegistry()}
+
+	recipe := physicsListRecipe{
+		Name:      "bad-list",
+		Processes: []physicsListRecipeProcess{{Name: "Nonexistent"}},
+	}
+	body, _ := json.Marshal(recipe)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/physics-lists", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	server.handlePhysicsLists(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	if _, ok := server.physicsLists.Get("bad-list"); ok {
+		t.Error("expected bad-list not to be registered")
+	}
+}
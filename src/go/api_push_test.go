@@ -0,0 +1,270 @@
+/*
+ * File: src/go/api_push_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for Web Push subscriptions and RFC 8291/8292 crypto
+ * Purpose: Confirms the aes128gcm payload round-trips through a simulated UA, the VAPID JWT
+ *          verifies with the published public key, and the subscribe/unsubscribe HTTP
+ *          handlers manage the in-memory store correctly
+ * Reason: Provides regression coverage for api.ternary.fission.push.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial encryption round-trip, VAPID JWT, and subscribe/unsubscribe tests
+ */
+
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// decryptWebPushPayloadForTest reverses encryptWebPushPayload using the UA's private key and
+// auth secret, so TestEncryptWebPushPayloadRoundTrip can confirm the real plaintext comes out
+// the other end rather than just checking for an encryption error.
+func decryptWebPushPayloadForTest(t *testing.T, uaPrivate *ecdh.PrivateKey, authSecret []byte, record []byte) []byte {
+	t.Helper()
+
+	if len(record) < 16+4+1 {
+		t.Fatalf("record too short: %d bytes", len(record))
+	}
+	salt := record[:16]
+	recordLength := binary.BigEndian.Uint32(record[16:20])
+	keyIDLen := int(record[20])
+	keyID := record[21 : 21+keyIDLen]
+	ciphertext := record[21+keyIDLen : 21+keyIDLen+int(recordLength)]
+
+	curve := ecdh.P256()
+	asPublicKey, err := curve.NewPublicKey(keyID)
+	if err != nil {
+		t.Fatalf("invalid ephemeral public key in record: %v", err)
+	}
+
+	sharedSecret, err := uaPrivate.ECDH(asPublicKey)
+	if err != nil {
+		t.Fatalf("ECDH failed: %v", err)
+	}
+
+	ikm, err := webPushIKM(sharedSecret, authSecret, uaPrivate.PublicKey().Bytes(), keyID)
+	if err != nil {
+		t.Fatalf("IKM derivation failed: %v", err)
+	}
+
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek := make([]byte, 16)
+	if _, err := hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: aes128gcm\x00")).Read(cek); err != nil {
+		t.Fatalf("CEK derivation failed: %v", err)
+	}
+	nonce := make([]byte, 12)
+	if _, err := hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: nonce\x00")).Read(nonce); err != nil {
+		t.Fatalf("nonce derivation failed: %v", err)
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		t.Fatalf("failed to build AES cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatalf("failed to build AES-GCM: %v", err)
+	}
+	padded, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("GCM open failed: %v", err)
+	}
+
+	// Strip the RFC 8188 single-record 0x02 delimiter byte.
+	return padded[:len(padded)-1]
+}
+
+// TestEncryptWebPushPayloadRoundTrip confirms a payload encrypted for a subscription can be
+// decrypted back to the original plaintext using only the UA-side secrets, exactly as a real
+// browser's push service delivery would.
+func TestEncryptWebPushPayloadRoundTrip(t *testing.T) {
+	curve := ecdh.P256()
+	uaPrivate, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate UA key: %v", err)
+	}
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		t.Fatalf("failed to generate auth secret: %v", err)
+	}
+
+	sub := PushSubscription{Endpoint: "https://push.example.com/abc123"}
+	sub.Keys.P256dh = base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes())
+	sub.Keys.Auth = base64.RawURLEncoding.EncodeToString(authSecret)
+
+	plaintext := []byte(`{"title":"Ternary Fission Reactor","body":"Active energy fields (6) exceeded threshold (5)"}`)
+	record, err := encryptWebPushPayload(sub, plaintext)
+	if err != nil {
+		t.Fatalf("encryptWebPushPayload failed: %v", err)
+	}
+
+	decrypted := decryptWebPushPayloadForTest(t, uaPrivate, authSecret, record)
+	if string(decrypted) != string(plaintext) {
+		t.Fatalf("decrypted payload = %q, want %q", decrypted, plaintext)
+	}
+}
+
+// TestBuildVAPIDAuthorizationHeader confirms the Authorization header has the expected
+// "vapid t=<jwt>, k=<key>" shape and that the JWT's signature verifies against the published
+// public key.
+func TestBuildVAPIDAuthorizationHeader(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate VAPID key: %v", err)
+	}
+	keys := newVAPIDKeyPair(priv)
+
+	header, err := buildVAPIDAuthorizationHeader(keys, "https://push.example.com", "mailto:admin@example.com")
+	if err != nil {
+		t.Fatalf("buildVAPIDAuthorizationHeader failed: %v", err)
+	}
+
+	if !strings.HasPrefix(header, "vapid t=") || !strings.Contains(header, ", k=") {
+		t.Fatalf("unexpected Authorization header shape: %q", header)
+	}
+
+	tPart := strings.TrimPrefix(strings.SplitN(header, ", k=", 2)[0], "vapid t=")
+	segments := strings.Split(tPart, ".")
+	if len(segments) != 3 {
+		t.Fatalf("expected a 3-segment JWT, got %d segments", len(segments))
+	}
+
+	signingInput := segments[0] + "." + segments[1]
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil || len(signature) != 64 {
+		t.Fatalf("invalid signature segment: %v", err)
+	}
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+
+	digest := sha256.Sum256([]byte(signingInput))
+	if !ecdsa.Verify(&priv.PublicKey, digest[:], r, s) {
+		t.Fatal("VAPID JWT signature did not verify against its own public key")
+	}
+
+	var claims struct {
+		Aud string `json:"aud"`
+		Sub string `json:"sub"`
+	}
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(segments[1])
+	if err != nil {
+		t.Fatalf("failed to decode claims segment: %v", err)
+	}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("failed to unmarshal claims: %v", err)
+	}
+	if claims.Aud != "https://push.example.com" || claims.Sub != "mailto:admin@example.com" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+}
+
+// TestPushSubscribeAndUnsubscribe drives POST /api/v1/push/subscribe and DELETE
+// /api/v1/push/subscribe/{endpoint_hash} against the in-memory store.
+func TestPushSubscribeAndUnsubscribe(t *testing.T) {
+	cfg := &Config{
+		ReactorBaseURL:        "http://127.0.0.1:0",
+		APITimeout:            5,
+		PrometheusEnabled:     false,
+		WebSocketPingInterval: 1,
+		PushEnabled:           true,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	sub := PushSubscription{Endpoint: "https://push.example.com/subscriber-1"}
+	sub.Keys.P256dh = "dummy-p256dh-key"
+	sub.Keys.Auth = "dummy-auth-secret"
+	body, _ := json.Marshal(sub)
+
+	resp, err := http.Post(server.URL+"/api/v1/push/subscribe", "application/json", strings.NewReader(string(body)))
+	if err != nil {
+		t.Fatalf("subscribe request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201 Created, got %d", resp.StatusCode)
+	}
+
+	var subscribed struct {
+		EndpointHash string `json:"endpoint_hash"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&subscribed); err != nil {
+		t.Fatalf("failed to decode subscribe response: %v", err)
+	}
+	if subscribed.EndpointHash == "" {
+		t.Fatal("expected a non-empty endpoint_hash")
+	}
+
+	stored, err := api.pushStore.List()
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("expected exactly one stored subscription, got %d (err=%v)", len(stored), err)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL+"/api/v1/push/subscribe/"+subscribed.EndpointHash, nil)
+	deleteResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unsubscribe request failed: %v", err)
+	}
+	defer deleteResp.Body.Close()
+	if deleteResp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected 204 No Content, got %d", deleteResp.StatusCode)
+	}
+
+	stored, err = api.pushStore.List()
+	if err != nil || len(stored) != 0 {
+		t.Fatalf("expected the subscription to be removed, got %d (err=%v)", len(stored), err)
+	}
+}
+
+// TestPushVAPIDPublicKeyEndpoint confirms the public key served over HTTP matches the one
+// the server generated on startup.
+func TestPushVAPIDPublicKeyEndpoint(t *testing.T) {
+	cfg := &Config{
+		ReactorBaseURL:        "http://127.0.0.1:0",
+		APITimeout:            5,
+		PrometheusEnabled:     false,
+		WebSocketPingInterval: 1,
+		PushEnabled:           true,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/push/vapid-public-key")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		PublicKey string `json:"public_key"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.PublicKey != api.pushVAPIDKeys.publicKeyB64 {
+		t.Fatalf("public_key = %q, want %q", body.PublicKey, api.pushVAPIDKeys.publicKeyB64)
+	}
+}
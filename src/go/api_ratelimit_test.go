@@ -0,0 +1,164 @@
+/*
+ * File: src/go/api_ratelimit_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 8, 2025
+ * Title: Tests for token-bucket rate limiting
+ * Purpose: Ensures bucket exhaustion/refill behaves and the HTTP layer enforces quotas
+ * Reason: Provides regression coverage for the rate limiting subsystem
+ *
+ * Change Log:
+ * 2025-08-08: Initial tests for RateLimiter.Allow and the 429/header behavior over HTTP
+ * 2025-08-10: Added a regression test for the chunk0-5 fix - distinct unvalidated Bearer
+ *             tokens from the same caller must share one rate-limit bucket, not each mint
+ *             their own fresh one
+ */
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestRateLimiterAllowsBurstThenRejects confirms a limiter allows up to its
+// burst capacity, then rejects until tokens refill.
+func TestRateLimiterAllowsBurstThenRejects(t *testing.T) {
+	limiter := NewRateLimiter(1, 2)
+
+	for i := 0; i < 2; i++ {
+		allowed, _, _ := limiter.Allow("caller")
+		if !allowed {
+			t.Fatalf("expected request %d within burst to be allowed", i)
+		}
+	}
+
+	allowed, _, retryAfter := limiter.Allow("caller")
+	if allowed {
+		t.Fatal("expected third request to be rejected once burst is exhausted")
+	}
+	if retryAfter <= 0 {
+		t.Errorf("expected a positive retry-after, got %v", retryAfter)
+	}
+}
+
+// TestRateLimiterKeysAreIndependent confirms two callers don't share a bucket.
+func TestRateLimiterKeysAreIndependent(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+
+	if allowed, _, _ := limiter.Allow("caller-a"); !allowed {
+		t.Fatal("expected caller-a's first request to be allowed")
+	}
+	if allowed, _, _ := limiter.Allow("caller-b"); !allowed {
+		t.Fatal("expected caller-b's first request to be allowed independently of caller-a")
+	}
+}
+
+// TestRateLimiterReapRemovesIdleBuckets confirms reap bounds memory by
+// dropping buckets that haven't been touched within maxIdle.
+func TestRateLimiterReapRemovesIdleBuckets(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	limiter.Allow("caller")
+
+	limiter.mu.Lock()
+	limiter.buckets["caller"].lastSeen = time.Now().Add(-time.Hour)
+	limiter.mu.Unlock()
+
+	limiter.reap(time.Minute)
+
+	limiter.mu.Lock()
+	_, found := limiter.buckets["caller"]
+	limiter.mu.Unlock()
+	if found {
+		t.Error("expected idle bucket to be reaped")
+	}
+}
+
+// TestEnergyFieldCreateRateLimitedReturns429 confirms the tighter per-endpoint
+// limiter on POST /energy-fields rejects once exhausted, with the expected headers.
+func TestEnergyFieldCreateRateLimitedReturns429(t *testing.T) {
+	cfg := &Config{
+		ReactorBaseURL:            "http://127.0.0.1:8333",
+		StatusPollInterval:        60,
+		RateLimitingEnabled:       true,
+		RateLimitRPS:              1000,
+		RateLimitBurst:            1000,
+		RateLimitEnergyFieldRPS:   1,
+		RateLimitEnergyFieldBurst: 1,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	post := func() *http.Response {
+		resp, err := http.Post(server.URL+"/api/v1/energy-fields", "application/json", nil)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp
+	}
+
+	first := post()
+	first.Body.Close()
+
+	second := post()
+	defer second.Body.Close()
+	if second.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", second.StatusCode)
+	}
+	if second.Header.Get("Retry-After") == "" {
+		t.Error("expected Retry-After header on rate-limited response")
+	}
+	if second.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("expected X-RateLimit-Remaining 0, got %q", second.Header.Get("X-RateLimit-Remaining"))
+	}
+}
+
+// TestRateLimitSharesBucketAcrossFakeBearerTokens verifies that two requests carrying
+// distinct, unvalidated Bearer tokens from the same caller share one rate-limit bucket keyed
+// by IP, rather than each minting its own fresh bucket - regression coverage for the chunk0-5
+// fix, since RateLimiter.Allow gives any unseen key a full bucket.
+func TestRateLimitSharesBucketAcrossFakeBearerTokens(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer stub.Close()
+
+	cfg := &Config{
+		ReactorBaseURL:      stub.URL,
+		StatusPollInterval:  60,
+		RateLimitingEnabled: true,
+		RateLimitRPS:        0.001,
+		RateLimitBurst:      1,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	get := func(bearer string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/status", nil)
+		if err != nil {
+			t.Fatalf("failed to build request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp
+	}
+
+	first := get("fake-token-one")
+	first.Body.Close()
+	if first.StatusCode == http.StatusTooManyRequests {
+		t.Fatal("expected the first request to be allowed")
+	}
+
+	second := get("fake-token-two")
+	defer second.Body.Close()
+	if second.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a distinct unvalidated Bearer token to share the IP bucket and be rejected, got %d", second.StatusCode)
+	}
+}
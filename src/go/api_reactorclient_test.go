@@ -0,0 +1,294 @@
+/*
+ * File: src/go/api_reactorclient_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the reactor interceptor chain
+ * Purpose: Confirms interceptors run in the configured order, a retry-exhausted failure trips
+ *          the circuit breaker, and the cache interceptor serves repeated GETs without
+ *          re-hitting the transport
+ * Reason: Provides regression coverage for api.ternary.fission.reactorclient.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial ordering, retry-then-circuit-break, and cache hit accounting tests
+ * 2025-08-09: Added idempotency-gating and retryable-status-code coverage for the
+ *             rolling-window retry/circuit-breaker rework
+ */
+
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// roundTripFunc adapts a plain function to http.RoundTripper, used to stub the transport at
+// the bottom of a chain under test.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// recordingTransport counts how many times it was actually called, so tests can confirm an
+// interceptor short-circuited (or didn't) without reaching the transport below it.
+type recordingTransport struct {
+	mu    sync.Mutex
+	calls int
+	fn    func(req *http.Request) (*http.Response, error)
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.calls++
+	rt.mu.Unlock()
+	return rt.fn(req)
+}
+
+func newStubResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Proto:      "HTTP/1.1", ProtoMajor: 1, ProtoMinor: 1,
+		Header:  make(http.Header),
+		Body:    io.NopCloser(strings.NewReader(body)),
+		Request: req,
+	}
+}
+
+// reactorClientTestServer builds a server with just enough Config to construct a
+// ReactorDispatcher, matching the minimal Config literals api_push_test.go already uses.
+func reactorClientTestServer(t *testing.T, mutate func(cfg *Config)) *TernaryFissionAPIServer {
+	t.Helper()
+
+	cfg := &Config{
+		ReactorBaseURL:                   "http://127.0.0.1:0",
+		APITimeout:                       5,
+		PrometheusEnabled:                false,
+		WebSocketPingInterval:            1,
+		ReactorInterceptors:              "retry,redirect,circuit_breaker,cache,metrics,auth",
+		ReactorRetryMaxAttempts:          3,
+		ReactorRetryBaseDelayMS:          1,
+		ReactorMaxRedirections:           5,
+		ReactorCircuitBreakerThreshold:   2,
+		ReactorCircuitBreakerOpenSeconds: 60,
+		ReactorCacheTTLSeconds:           2,
+	}
+	if mutate != nil {
+		mutate(cfg)
+	}
+	return NewTernaryFissionAPIServer(cfg)
+}
+
+// TestReactorDispatcherOrdersInterceptorsOuterToInner confirms ReactorInterceptors' list
+// order becomes the chain's outer-to-inner call order.
+func TestReactorDispatcherOrdersInterceptorsOuterToInner(t *testing.T) {
+	var order []string
+
+	RegisterInterceptor("test_order_outer", func(server *TernaryFissionAPIServer) Interceptor {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, "outer")
+				return next.RoundTrip(req)
+			})
+		}
+	})
+	RegisterInterceptor("test_order_inner", func(server *TernaryFissionAPIServer) Interceptor {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, "inner")
+				return newStubResponse(req, http.StatusOK, "ok"), nil
+			})
+		}
+	})
+
+	server := reactorClientTestServer(t, func(cfg *Config) {
+		cfg.ReactorInterceptors = "test_order_outer,test_order_inner"
+	})
+
+	resp, err := server.reactorClient.Get("http://127.0.0.1:0/ignored")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Fatalf("unexpected interceptor call order: %v", order)
+	}
+}
+
+// TestRetryThenCircuitBreakerOpens confirms the retry interceptor exhausts its attempts
+// against a failing transport, those failures trip the circuit breaker, and the next request
+// is short-circuited with a synthetic response rather than reaching the transport again.
+func TestRetryThenCircuitBreakerOpens(t *testing.T) {
+	server := reactorClientTestServer(t, func(cfg *Config) {
+		cfg.ReactorRetryMaxAttempts = 2
+		cfg.ReactorCircuitBreakerThreshold = 2
+	})
+
+	transport := &recordingTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return newStubResponse(req, http.StatusServiceUnavailable, "down"), nil
+	}}
+
+	var chain http.RoundTripper = transport
+	chain = newCircuitBreakerInterceptor(server)(chain)
+	chain = newRetryInterceptor(server)(chain)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:0/api/v1/status", nil)
+
+	resp, err := chain.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	resp.Body.Close()
+	if transport.calls != 2 {
+		t.Fatalf("expected retry to exhaust 2 attempts against the transport, got %d", transport.calls)
+	}
+
+	resp2, err := chain.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("second request failed: %v", err)
+	}
+	defer resp2.Body.Close()
+	body2, _ := io.ReadAll(resp2.Body)
+	if !strings.Contains(string(body2), "circuit breaker is open") {
+		t.Fatalf("expected the open breaker's synthetic response, got %q", body2)
+	}
+	if transport.calls != 2 {
+		t.Fatalf("expected the open breaker to short-circuit without calling the transport again, got %d calls", transport.calls)
+	}
+}
+
+// TestRetryLeavesNonIdempotentPostAlone confirms a POST without an Idempotency-Key header is
+// sent exactly once even when it fails with a retryable status, since replaying it risks
+// double-applying whatever mutation it requested.
+func TestRetryLeavesNonIdempotentPostAlone(t *testing.T) {
+	server := reactorClientTestServer(t, func(cfg *Config) {
+		cfg.ReactorRetryMaxAttempts = 3
+	})
+
+	transport := &recordingTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return newStubResponse(req, http.StatusServiceUnavailable, "down"), nil
+	}}
+	chain := newRetryInterceptor(server)(transport)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:0/api/v1/energy-fields", nil)
+	resp, err := chain.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if transport.calls != 1 {
+		t.Fatalf("expected a non-idempotent POST without Idempotency-Key to be sent once, got %d calls", transport.calls)
+	}
+}
+
+// TestRetryHonorsIdempotencyKeyOnPost confirms a POST carrying an Idempotency-Key header is
+// retried like any other idempotent request.
+func TestRetryHonorsIdempotencyKeyOnPost(t *testing.T) {
+	server := reactorClientTestServer(t, func(cfg *Config) {
+		cfg.ReactorRetryMaxAttempts = 3
+	})
+
+	transport := &recordingTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return newStubResponse(req, http.StatusServiceUnavailable, "down"), nil
+	}}
+	chain := newRetryInterceptor(server)(transport)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1:0/api/v1/energy-fields", nil)
+	req.Header.Set("Idempotency-Key", "test-key-1")
+	resp, err := chain.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if transport.calls != 3 {
+		t.Fatalf("expected a POST with Idempotency-Key to retry up to maxAttempts, got %d calls", transport.calls)
+	}
+}
+
+// TestRetrySkipsNonRetryableStatus confirms a plain 500 is treated as fatal rather than
+// retryable, unlike 502/503/504.
+func TestRetrySkipsNonRetryableStatus(t *testing.T) {
+	server := reactorClientTestServer(t, func(cfg *Config) {
+		cfg.ReactorRetryMaxAttempts = 3
+	})
+
+	transport := &recordingTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return newStubResponse(req, http.StatusInternalServerError, "broken"), nil
+	}}
+	chain := newRetryInterceptor(server)(transport)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:0/api/v1/status", nil)
+	resp, err := chain.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if transport.calls != 1 {
+		t.Fatalf("expected a plain 500 to be treated as fatal (not retried), got %d calls", transport.calls)
+	}
+}
+
+// TestCircuitBreakerOpenResponseCarriesRetryAfter confirms the synthetic open-breaker
+// response carries a numeric Retry-After header callers (and the retry interceptor) can use.
+func TestCircuitBreakerOpenResponseCarriesRetryAfter(t *testing.T) {
+	server := reactorClientTestServer(t, func(cfg *Config) {
+		cfg.ReactorCircuitBreakerThreshold = 1
+		cfg.ReactorCircuitBreakerOpenSeconds = 30
+	})
+
+	transport := &recordingTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return newStubResponse(req, http.StatusServiceUnavailable, "down"), nil
+	}}
+	chain := newCircuitBreakerInterceptor(server)(transport)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:0/api/v1/status", nil)
+	resp, _ := chain.RoundTrip(req)
+	resp.Body.Close()
+
+	resp2, err := chain.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("second request failed: %v", err)
+	}
+	defer resp2.Body.Close()
+
+	if resp2.Header.Get("X-Reactor-Circuit-Breaker") != "open" {
+		t.Fatalf("expected the open-breaker sentinel header, got headers %v", resp2.Header)
+	}
+	if resp2.Header.Get("Retry-After") == "" {
+		t.Fatalf("expected a Retry-After header on the open-breaker response")
+	}
+}
+
+// TestCacheInterceptorCountsHits confirms repeated GETs within the TTL are served from cache,
+// only the first reaching the transport.
+func TestCacheInterceptorCountsHits(t *testing.T) {
+	server := reactorClientTestServer(t, nil)
+
+	transport := &recordingTransport{fn: func(req *http.Request) (*http.Response, error) {
+		return newStubResponse(req, http.StatusOK, "status-payload"), nil
+	}}
+	chain := newCacheInterceptor(server)(transport)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1:0/api/v1/status", nil)
+
+	for i := 0; i < 3; i++ {
+		resp, err := chain.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != "status-payload" {
+			t.Fatalf("request %d got unexpected body %q", i, body)
+		}
+	}
+
+	if transport.calls != 1 {
+		t.Fatalf("expected 2 of 3 requests to be served from cache, transport was called %d times, want 1", transport.calls)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ * File: src/go/api_requestid_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 10, 2025
+ * Title: Tests for request ID propagation to the reactor
+ * Purpose: Confirms the X-Request-ID loggingMiddleware generates for an inbound request
+ *          round-trips onto the outbound reactor request the request_id interceptor builds
+ * Reason: Provides regression coverage for api.ternary.fission.server.go's loggingMiddleware
+ *         and api.ternary.fission.reactorclient.go's requestIDInterceptor
+ *
+ * Change Log:
+ * 2025-08-10: Initial round-trip and client-supplied-ID tests
+ */
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRequestIDRoundTripsToReactorStub confirms the X-Request-ID loggingMiddleware generates
+// for the inbound request is forwarded as X-Request-ID on the outbound reactor request, and
+// echoed back to the original caller on the response.
+func TestRequestIDRoundTripsToReactorStub(t *testing.T) {
+	var gotUpstreamRequestID string
+
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUpstreamRequestID = r.Header.Get("X-Request-ID")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer stub.Close()
+
+	api := NewTernaryFissionAPIServer(&Config{
+		ReactorBaseURL:    stub.URL,
+		APITimeout:        5,
+		PrometheusEnabled: false,
+	})
+	defer api.cancelFunc()
+
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/energy-fields")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	clientRequestID := resp.Header.Get("X-Request-ID")
+	if clientRequestID == "" {
+		t.Fatal("expected X-Request-ID response header from our API")
+	}
+	if gotUpstreamRequestID == "" {
+		t.Fatal("expected reactor stub to receive an X-Request-ID header")
+	}
+	if gotUpstreamRequestID != clientRequestID {
+		t.Fatalf("request ID did not round-trip: client got %q, reactor stub got %q", clientRequestID, gotUpstreamRequestID)
+	}
+}
+
+// TestRequestIDDiffersAcrossRequests confirms each inbound request gets its own generated ID
+// rather than one being reused, so concurrent requests' reactor-forwarding logs don't collide.
+func TestRequestIDDiffersAcrossRequests(t *testing.T) {
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer stub.Close()
+
+	api := NewTernaryFissionAPIServer(&Config{
+		ReactorBaseURL:    stub.URL,
+		APITimeout:        5,
+		PrometheusEnabled: false,
+	})
+	defer api.cancelFunc()
+
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	first, err := http.Get(server.URL + "/api/v1/energy-fields")
+	if err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	defer first.Body.Close()
+
+	second, err := http.Get(server.URL + "/api/v1/energy-fields")
+	if err != nil {
+		t.Fatalf("second request failed: %v", err)
+	}
+	defer second.Body.Close()
+
+	firstID := first.Header.Get("X-Request-ID")
+	secondID := second.Header.Get("X-Request-ID")
+	if firstID == "" || secondID == "" {
+		t.Fatal("expected both responses to carry an X-Request-ID header")
+	}
+	if firstID == secondID {
+		t.Fatalf("expected distinct request IDs, got %q for both", firstID)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * File: src/go/api_timeout_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the per-request timeout middleware
+ * Purpose: Exercises the 504 JSON body, the reactor_timeouts_total counter, and that a
+ *          wedged reactor is actually canceled rather than left running
+ * Reason: Provides regression coverage for api.ternary.fission.timeout.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial timeout response, counter, and cancellation-propagation tests
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// timeoutTestServer starts a reactor stub that blocks until canceled (recording whether its
+// context was actually canceled) and a Ternary Fission API server pointed at it with a short
+// RequestTimeoutSeconds.
+func timeoutTestServer(t *testing.T) (*httptest.Server, *TernaryFissionAPIServer, chan bool) {
+	t.Helper()
+
+	canceled := make(chan bool, 1)
+	reactor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			canceled <- true
+		case <-time.After(2 * time.Second):
+			canceled <- false
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	t.Cleanup(reactor.Close)
+
+	cfg := &Config{
+		ReactorBaseURL:        reactor.URL,
+		APITimeout:            5,
+		PrometheusEnabled:     true,
+		StatusPollInterval:    60,
+		RequestTimeoutSeconds: 1,
+	}
+	api := NewTernaryFissionAPIServer(cfg)
+	t.Cleanup(func() {
+		prometheus.Unregister(api.reactorTimeouts)
+		prometheus.Unregister(api.csrf.csrfRejected)
+		prometheus.Unregister(api.auth.authFailures)
+		prometheus.Unregister(api.rateLimitHits)
+		prometheus.Unregister(api.requestCounter)
+		prometheus.Unregister(api.responseTime)
+		prometheus.Unregister(api.reactorActiveFields)
+		prometheus.Unregister(api.reactorTotalEnergy)
+	})
+	server := httptest.NewServer(api.router)
+	t.Cleanup(server.Close)
+
+	return server, api, canceled
+}
+
+// TestTimeoutMiddlewareRespondsWithStructuredJSON verifies a handler that outlives its
+// deadline gets a 504 with a code/error/retry_after body instead of a bare connection close.
+func TestTimeoutMiddlewareRespondsWithStructuredJSON(t *testing.T) {
+	server, _, _ := timeoutTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/v1/health")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected 504, got %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Code       int     `json:"code"`
+		Error      string  `json:"error"`
+		RetryAfter float64 `json:"retry_after"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode timeout body: %v", err)
+	}
+	if body.Code != http.StatusGatewayTimeout || body.Error == "" || body.RetryAfter <= 0 {
+		t.Fatalf("unexpected timeout body: %+v", body)
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Fatal("expected a Retry-After header")
+	}
+}
+
+// TestTimeoutMiddlewareIncrementsCounter verifies reactor_timeouts_total is counted under the
+// route that timed out.
+func TestTimeoutMiddlewareIncrementsCounter(t *testing.T) {
+	server, api, _ := timeoutTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/v1/health")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	got := testutil.ToFloat64(api.reactorTimeouts.WithLabelValues("/api/v1/health"))
+	if got != 1 {
+		t.Fatalf("expected reactor_timeouts_total{path=\"/api/v1/health\"}=1, got %v", got)
+	}
+}
+
+// TestTimeoutMiddlewarePropagatesCancellation verifies the reactor-bound request is actually
+// canceled on timeout, not merely abandoned by the handler.
+func TestTimeoutMiddlewarePropagatesCancellation(t *testing.T) {
+	server, _, canceled := timeoutTestServer(t)
+
+	resp, err := http.Get(server.URL + "/api/v1/health")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	select {
+	case wasCanceled := <-canceled:
+		if !wasCanceled {
+			t.Fatal("expected the reactor stub's context to be canceled, but it ran to completion")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("reactor stub never observed cancellation or completion")
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * File: src/go/api_tracing_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for OpenTelemetry tracing setup
+ * Purpose: Exercises buildSampler's Config translation, reloadableSampler's swap-in-place
+ *          behavior, and initTracing's disabled/no-op path
+ * Reason: Provides regression coverage for api.ternary.fission.tracing.go
+ *
+ * Change Log:
+ * 2025-08-09: Initial sampler and disabled-tracing tests
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TestBuildSamplerSelectsByType confirms each OTLPSamplerType value maps to the expected
+// sdktrace.Sampler, defaulting to AlwaysSample for an unrecognized type.
+func TestBuildSamplerSelectsByType(t *testing.T) {
+	cases := []struct {
+		samplerType string
+		want        string
+	}{
+		{"always_off", "AlwaysOffSampler"},
+		{"ratio", "TraceIDRatioBased{0.25}"},
+		{"parentbased_ratio", "ParentBased{root:TraceIDRatioBased{0.25},remoteParentSampled:AlwaysOnSampler,remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler,localParentNotSampled:AlwaysOffSampler}"},
+		{"unrecognized", "AlwaysOnSampler"},
+		{"", "AlwaysOnSampler"},
+	}
+
+	for _, tc := range cases {
+		cfg := &Config{OTLPSamplerType: tc.samplerType, OTLPSamplingRatio: 0.25}
+		got := buildSampler(cfg).Description()
+		if got != tc.want {
+			t.Errorf("buildSampler(%q).Description() = %q, want %q", tc.samplerType, got, tc.want)
+		}
+	}
+}
+
+// TestBuildSamplerDefaultsRatio confirms a non-positive OTLPSamplingRatio falls back to 1.0
+// rather than building a sampler that never samples anything.
+func TestBuildSamplerDefaultsRatio(t *testing.T) {
+	cfg := &Config{OTLPSamplerType: "ratio", OTLPSamplingRatio: 0}
+	want := sdktrace.TraceIDRatioBased(1.0).Description()
+	if got := buildSampler(cfg).Description(); got != want {
+		t.Fatalf("buildSampler with zero ratio = %q, want %q", got, want)
+	}
+}
+
+// TestReloadableSamplerSwapsInPlace confirms set() changes which sampler ShouldSample
+// delegates to, without callers needing a new reloadableSampler instance.
+func TestReloadableSamplerSwapsInPlace(t *testing.T) {
+	r := &reloadableSampler{sampler: sdktrace.AlwaysSample()}
+	if got := r.Description(); got != "Reloadable(AlwaysOnSampler)" {
+		t.Fatalf("unexpected initial description: %q", got)
+	}
+
+	r.set(sdktrace.NeverSample())
+	if got := r.Description(); got != "Reloadable(AlwaysOffSampler)" {
+		t.Fatalf("expected description to reflect the swapped sampler, got %q", got)
+	}
+}
+
+// TestInitTracingDisabledReturnsNoop confirms a server with TracingEnabled false gets a
+// working no-op shutdown func and never touches traceSampler.
+func TestInitTracingDisabledReturnsNoop(t *testing.T) {
+	server := reactorClientTestServer(t, func(cfg *Config) {
+		cfg.TracingEnabled = false
+	})
+
+	shutdown, err := server.initTracing()
+	if err != nil {
+		t.Fatalf("initTracing with tracing disabled returned an error: %v", err)
+	}
+	if server.traceSampler != nil {
+		t.Fatalf("expected traceSampler to stay nil when tracing is disabled")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("expected the no-op shutdown func to succeed, got: %v", err)
+	}
+}
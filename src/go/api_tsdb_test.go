@@ -0,0 +1,148 @@
+/*
+ * File: src/go/api_tsdb_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 6, 2025
+ * Title: Tests for the in-process time-series store and Grafana SimpleJSON handlers
+ * Purpose: Ensures ring-buffer retention/downsampling and the /api/v1/tsdb endpoints behave
+ * Reason: Provides regression coverage for the Grafana datasource subsystem
+ *
+ * Change Log:
+ * 2025-08-06: Initial tests for ring buffer recording/querying and the SimpleJSON handlers
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestRingBufferSinceReturnsStoredPoints verifies points are returned oldest
+// first and that points older than "from" are excluded.
+func TestRingBufferSinceReturnsStoredPoints(t *testing.T) {
+	buf := newRingBuffer(3)
+	base := time.Unix(1000, 0)
+
+	buf.add(tsPoint{Timestamp: base, Value: 1})
+	buf.add(tsPoint{Timestamp: base.Add(time.Second), Value: 2})
+	buf.add(tsPoint{Timestamp: base.Add(2 * time.Second), Value: 3})
+
+	points := buf.since(base.Add(time.Second))
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(points))
+	}
+	if points[0].Value != 2 || points[1].Value != 3 {
+		t.Errorf("unexpected values: %v", points)
+	}
+}
+
+// TestRingBufferWrapsAtCapacity confirms the oldest point is overwritten once
+// the buffer is full rather than growing unbounded.
+func TestRingBufferWrapsAtCapacity(t *testing.T) {
+	buf := newRingBuffer(2)
+	base := time.Unix(2000, 0)
+
+	buf.add(tsPoint{Timestamp: base, Value: 1})
+	buf.add(tsPoint{Timestamp: base.Add(time.Second), Value: 2})
+	buf.add(tsPoint{Timestamp: base.Add(2 * time.Second), Value: 3})
+
+	points := buf.since(time.Unix(0, 0))
+	if len(points) != 2 {
+		t.Fatalf("expected capacity-bounded 2 points, got %d", len(points))
+	}
+	if points[0].Value != 2 || points[1].Value != 3 {
+		t.Errorf("expected oldest point overwritten, got %v", points)
+	}
+}
+
+// TestTimeSeriesStoreRecordAndQuery confirms Record/Query round-trip through
+// the fine tier for a recently recorded sample.
+func TestTimeSeriesStoreRecordAndQuery(t *testing.T) {
+	store := NewTimeSeriesStore(&Config{
+		TSDBFineResolutionSeconds:   1,
+		TSDBFineRetentionSeconds:    3600,
+		TSDBCoarseResolutionSeconds: 60,
+		TSDBCoarseRetentionSeconds:  86400,
+	})
+
+	now := time.Now()
+	store.Record("active_fields", 5, now)
+
+	points := store.Query("active_fields", now.Add(-time.Minute))
+	if len(points) != 1 {
+		t.Fatalf("expected 1 point, got %d", len(points))
+	}
+	if points[0].Value != 5 {
+		t.Errorf("expected value 5, got %v", points[0].Value)
+	}
+}
+
+// TestTimeSeriesStoreUnknownMetricIsNoop confirms recording/querying an
+// unregistered metric name does not panic and simply yields nothing.
+func TestTimeSeriesStoreUnknownMetricIsNoop(t *testing.T) {
+	store := NewTimeSeriesStore(&Config{})
+	store.Record("not_a_real_metric", 1, time.Now())
+
+	if points := store.Query("not_a_real_metric", time.Time{}); points != nil {
+		t.Errorf("expected nil points for unknown metric, got %v", points)
+	}
+}
+
+// TestHandleTSDBSearchListsKnownMetrics verifies the SimpleJSON /search
+// endpoint enumerates every known metric name.
+func TestHandleTSDBSearchListsKnownMetrics(t *testing.T) {
+	api := NewTernaryFissionAPIServer(&Config{StatusPollInterval: 60})
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/tsdb/search")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var names []string
+	if err := json.NewDecoder(resp.Body).Decode(&names); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(names) != len(KnownTSDBMetrics) {
+		t.Fatalf("expected %d metrics, got %d", len(KnownTSDBMetrics), len(names))
+	}
+}
+
+// TestHandleTSDBQueryReturnsRecordedSample confirms a point recorded via the
+// store is returned by the /query endpoint for its target.
+func TestHandleTSDBQueryReturnsRecordedSample(t *testing.T) {
+	api := NewTernaryFissionAPIServer(&Config{StatusPollInterval: 60})
+	server := httptest.NewServer(api.router)
+	defer server.Close()
+
+	now := time.Now()
+	api.tsdb.Record("cpu_percent", 42, now)
+
+	reqBody, _ := json.Marshal(tsdbQueryRequest{
+		Range:   tsdbQueryRange{From: now.Add(-time.Minute), To: now.Add(time.Minute)},
+		Targets: []tsdbQueryTarget{{Target: "cpu_percent"}},
+	})
+
+	resp, err := http.Post(server.URL+"/api/v1/tsdb/query", "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var results []tsdbQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Datapoints) != 1 {
+		t.Fatalf("expected 1 target with 1 datapoint, got %+v", results)
+	}
+	if results[0].Datapoints[0][0] != 42 {
+		t.Errorf("expected value 42, got %v", results[0].Datapoints[0][0])
+	}
+}
@@ -0,0 +1,84 @@
+/*
+ * File: src/go/cmd/nucdata-import/main.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: AME2020/ENSDF Importer
+ * Purpose: Converts an official AME2020 mass table (and, optionally, per-nuclide ENSDF
+ *          level files alongside it) into the compact on-disk table nucdata.LoadIndex and
+ *          nucdata.NewEmbeddedIndex read, plus a SHA-256 checksum file alongside it
+ * Reason: nucdata.LoadIndex expects the official published table layout at runtime, but
+ *         operators deploying offline want a single compact file (and its checksum) they
+ *         can ship instead of the full multi-megabyte AME2020/ENSDF download
+ *
+ * Change Log:
+ * 2025-08-09: Initial importer: -mass-table (required, must be named mass_1.mas20 so
+ *             nucdata.LoadIndex's own loader recognizes it) and -out (compact table path;
+ *             a sibling <out>.sha256 checksum file is written alongside it)
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"ternary-fission/nucdata"
+)
+
+func main() {
+	massTablePath := flag.String("mass-table", "", "path to the AME2020 mass_1.mas20 file (required); any <Symbol>.ensdf level files in the same directory are picked up automatically")
+	outPath := flag.String("out", "fallback_nuclides.tsv", "path to write the compact table to; a sibling <out>.sha256 file is also written")
+	flag.Parse()
+
+	if *massTablePath == "" {
+		fmt.Fprintln(os.Stderr, "nucdata-import: -mass-table is required")
+		os.Exit(1)
+	}
+	if filepath.Base(*massTablePath) != "mass_1.mas20" {
+		fmt.Fprintln(os.Stderr, "nucdata-import: -mass-table must be named mass_1.mas20, matching the official AME2020 filename nucdata.LoadIndex expects")
+		os.Exit(1)
+	}
+
+	idx, err := nucdata.LoadIndex(filepath.Dir(*massTablePath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "nucdata-import: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeCompactTableWithChecksum(idx, *outPath); err != nil {
+		fmt.Fprintf(os.Stderr, "nucdata-import: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("nucdata-import: wrote %s and %s.sha256\n", *outPath, *outPath)
+}
+
+// writeCompactTableWithChecksum writes idx's nuclides as the compact table format and a
+// sibling SHA-256 checksum file, matching what nucdata.NewEmbeddedIndex verifies at load time.
+func writeCompactTableWithChecksum(idx *nucdata.Index, outPath string) error {
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", outPath, err)
+	}
+	defer file.Close()
+
+	if err := nucdata.WriteCompactTable(file, idx.All()); err != nil {
+		return fmt.Errorf("writing %s: %w", outPath, err)
+	}
+
+	contents, err := os.ReadFile(outPath)
+	if err != nil {
+		return fmt.Errorf("re-reading %s for checksumming: %w", outPath, err)
+	}
+	sum := sha256.Sum256(contents)
+
+	checksumPath := outPath + ".sha256"
+	if err := os.WriteFile(checksumPath, []byte(hex.EncodeToString(sum[:])+"\n"), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", checksumPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+ * File: src/go/events/events.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Buffered, Kind-Filtered Event Bus
+ * Purpose: Provides a single ring-buffered event log that every transport emitting
+ *          domain events (field lifecycle, portal triggers, reactor status) can publish
+ *          to, and that SSE/WebSocket subscribers can replay by ID after a reconnect
+ * Reason: The dashboard, curl, and Grafana Live all want the same event stream, but only
+ *         the WebSocket monitor had a replay-capable buffer; this generalizes that pattern
+ *         to arbitrary event kinds instead of just reactor status snapshots
+ *
+ * Change Log:
+ * 2025-08-09: Initial BufferedSubscription with mask-filtered Subscribe/Since, inspired by
+ *             syncthing's buffered event subscription model
+ *
+ * Carry-over Context:
+ * - This mirrors MonitorTransportNegotiator's subscribe()/since()/bounded-history pattern in
+ *   api.ternary.fission.monitor.go; that type predates this package and keeps its own
+ *   reactor-status-only buffer rather than being rebuilt on top of this one, so as not to
+ *   disturb the already-shipped ternary-monitor-v1 replay semantics
+ * - A zero mask passed to Subscribe or Since means "every kind", not "no kind"
+ */
+
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Kind identifies the domain event a publisher is emitting.
+type Kind string
+
+const (
+	KindFieldCreated    Kind = "FieldCreated"
+	KindFieldDissipated Kind = "FieldDissipated"
+	KindPortalTriggered Kind = "PortalTriggered"
+	KindReactorStatus   Kind = "ReactorStatus"
+)
+
+// DefaultBufferCapacity is used when BufferedSubscription is constructed with a
+// non-positive capacity.
+const DefaultBufferCapacity = 1024
+
+// Event is one published occurrence: a monotonically increasing ID scoped to a single
+// BufferedSubscription, the kind, when it was published, and an arbitrary JSON-marshalable
+// payload.
+type Event struct {
+	ID        uint64      `json:"id"`
+	Kind      Kind        `json:"kind"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// BufferedSubscription is a ring-buffered, kind-filtered event bus: every Publish is
+// appended to a bounded history and fanned out to every subscribed client, so a late
+// subscriber can request events since a given ID instead of only seeing new ones.
+type BufferedSubscription struct {
+	mu       sync.Mutex
+	nextID   uint64
+	ring     []Event
+	capacity int
+
+	subMu   sync.Mutex
+	nextSub uint64
+	subs    map[uint64]*subscriber
+}
+
+type subscriber struct {
+	feed chan Event
+	mask map[Kind]bool
+}
+
+// New builds a BufferedSubscription retaining up to capacity events; a non-positive
+// capacity falls back to DefaultBufferCapacity.
+func New(capacity int) *BufferedSubscription {
+	if capacity <= 0 {
+		capacity = DefaultBufferCapacity
+	}
+	return &BufferedSubscription{
+		capacity: capacity,
+		subs:     make(map[uint64]*subscriber),
+	}
+}
+
+// matches reports whether kind passes mask; a nil or empty mask matches everything.
+func matches(mask map[Kind]bool, kind Kind) bool {
+	if len(mask) == 0 {
+		return true
+	}
+	return mask[kind]
+}
+
+// Publish appends a new event of kind carrying data to the bounded history and fans it
+// out to every subscriber whose mask includes kind, dropping the event for any client
+// whose feed is full rather than blocking the publisher.
+func (b *BufferedSubscription) Publish(kind Kind, data interface{}) Event {
+	b.mu.Lock()
+	b.nextID++
+	event := Event{ID: b.nextID, Kind: kind, Timestamp: time.Now(), Data: data}
+	b.ring = append(b.ring, event)
+	if len(b.ring) > b.capacity {
+		b.ring = b.ring[len(b.ring)-b.capacity:]
+	}
+	b.mu.Unlock()
+
+	b.subMu.Lock()
+	for _, sub := range b.subs {
+		if !matches(sub.mask, kind) {
+			continue
+		}
+		select {
+		case sub.feed <- event:
+		default:
+		}
+	}
+	b.subMu.Unlock()
+
+	return event
+}
+
+// Subscribe registers a new client feed restricted to mask (nil/empty for every kind) and
+// returns an unsubscribe func the caller must defer immediately.
+func (b *BufferedSubscription) Subscribe(mask []Kind) (uint64, <-chan Event, func()) {
+	b.subMu.Lock()
+	id := b.nextSub
+	b.nextSub++
+
+	sub := &subscriber{feed: make(chan Event, 16)}
+	if len(mask) > 0 {
+		sub.mask = make(map[Kind]bool, len(mask))
+		for _, kind := range mask {
+			sub.mask[kind] = true
+		}
+	}
+	b.subs[id] = sub
+	b.subMu.Unlock()
+
+	unsubscribe := func() {
+		b.subMu.Lock()
+		delete(b.subs, id)
+		b.subMu.Unlock()
+	}
+	return id, sub.feed, unsubscribe
+}
+
+// Since returns every retained event with an ID greater than sinceID and whose kind
+// passes mask (nil/empty for every kind), or ok=false if sinceID predates the retained
+// history and the caller should resync to the latest event instead of replaying a gap.
+func (b *BufferedSubscription) Since(sinceID uint64, mask []Kind) ([]Event, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.ring) == 0 {
+		return nil, sinceID == 0
+	}
+	if sinceID < b.ring[0].ID-1 {
+		return nil, false
+	}
+
+	maskSet := make(map[Kind]bool, len(mask))
+	for _, kind := range mask {
+		maskSet[kind] = true
+	}
+
+	var out []Event
+	for _, event := range b.ring {
+		if event.ID > sinceID && matches(maskSet, event.Kind) {
+			out = append(out, event)
+		}
+	}
+	return out, true
+}
+
+// LatestID returns the most recently published event ID, or 0 if nothing has been
+// published yet.
+func (b *BufferedSubscription) LatestID() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.nextID
+}
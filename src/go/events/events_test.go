@@ -0,0 +1,91 @@
+/*
+ * File: src/go/events/events_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for BufferedSubscription
+ * Purpose: Exercises Publish/Subscribe fan-out, mask filtering, and Since-based replay
+ * Reason: Provides regression coverage for the event bus backing GET /api/v1/events
+ *
+ * Change Log:
+ * 2025-08-09: Initial publish/subscribe, mask filtering, and replay tests
+ */
+
+package events
+
+import "testing"
+
+func TestPublishFansOutToSubscribers(t *testing.T) {
+	bus := New(0)
+	_, feed, unsubscribe := bus.Subscribe(nil)
+	defer unsubscribe()
+
+	bus.Publish(KindFieldCreated, "abc")
+
+	select {
+	case event := <-feed:
+		if event.Kind != KindFieldCreated || event.Data != "abc" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	default:
+		t.Fatal("expected an event on the feed")
+	}
+}
+
+func TestSubscribeMaskFiltersOtherKinds(t *testing.T) {
+	bus := New(0)
+	_, feed, unsubscribe := bus.Subscribe([]Kind{KindPortalTriggered})
+	defer unsubscribe()
+
+	bus.Publish(KindFieldCreated, "ignored")
+	bus.Publish(KindPortalTriggered, "wanted")
+
+	event := <-feed
+	if event.Kind != KindPortalTriggered || event.Data != "wanted" {
+		t.Fatalf("expected only PortalTriggered to pass the mask, got %+v", event)
+	}
+
+	select {
+	case unexpected := <-feed:
+		t.Fatalf("did not expect a second event, got %+v", unexpected)
+	default:
+	}
+}
+
+func TestSinceReplaysEventsAfterID(t *testing.T) {
+	bus := New(0)
+	first := bus.Publish(KindReactorStatus, 1)
+	second := bus.Publish(KindReactorStatus, 2)
+
+	replay, ok := bus.Since(first.ID, nil)
+	if !ok {
+		t.Fatal("expected ok=true for an ID within retained history")
+	}
+	if len(replay) != 1 || replay[0].ID != second.ID {
+		t.Fatalf("expected only the second event, got %+v", replay)
+	}
+}
+
+func TestSinceResyncsWhenIDPredatesHistory(t *testing.T) {
+	bus := New(2)
+	bus.Publish(KindReactorStatus, 1)
+	bus.Publish(KindReactorStatus, 2)
+	bus.Publish(KindReactorStatus, 3)
+
+	if _, ok := bus.Since(0, nil); ok {
+		t.Fatal("expected ok=false once the ring buffer has evicted the requested ID")
+	}
+}
+
+func TestSinceMasksReplayedEvents(t *testing.T) {
+	bus := New(0)
+	bus.Publish(KindFieldCreated, "field")
+	bus.Publish(KindPortalTriggered, "portal")
+
+	replay, ok := bus.Since(0, []Kind{KindPortalTriggered})
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	if len(replay) != 1 || replay[0].Kind != KindPortalTriggered {
+		t.Fatalf("expected only PortalTriggered in the masked replay, got %+v", replay)
+	}
+}
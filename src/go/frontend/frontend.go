@@ -0,0 +1,150 @@
+/*
+ * File: src/go/frontend/frontend.go
+ * Author: bthlops (David StJ)
+ * Date: August 7, 2025
+ * Title: Templated Frontend Service with Embedded Static Assets
+ * Purpose: Renders dashboard/login/portal/energy-field pages from base+content templates
+ * Reason: api.ternary.fission.server.go previously embedded the entire dashboard as one
+ *         Go string constant, making every new page a multi-hundred-line edit to the server file
+ *
+ * Change Log:
+ * 2025-08-07: Initial FrontendService backed by embed.FS, composing a shared base layout with
+ *             per-page content templates and serving /static/ assets with cache-busting query
+ *             parameters derived from a content hash
+ * 2025-08-09: Added ServiceWorkerHandler, serving static/sw.js at /sw.js (outside the
+ *             cache-busted /static/ tree) so the push-notification service worker's default
+ *             scope covers the whole site
+ *
+ * Carry-over Context:
+ * - New pages only require a new templates/<name>.gotemplate.html file defining "content" (and
+ *   optionally "styles"/"scripts"); the server wires a route to RenderPage(w, "<name>", data)
+ * - Page data types are defined by callers in package main; this package only needs a Title field
+ *   to be present since base.gotemplate.html renders {{.Title}}
+ */
+
+package frontend
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed templates/*.gotemplate.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+// FrontendService renders page templates and serves the embedded static assets.
+type FrontendService struct {
+	base        *template.Template
+	static      fs.FS
+	assetHashes map[string]string
+}
+
+// New builds a FrontendService, hashing every embedded static asset up front so
+// AssetURL can cache-bust without touching disk on each request.
+func New() (*FrontendService, error) {
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		return nil, fmt.Errorf("frontend: failed to open embedded static assets: %w", err)
+	}
+
+	f := &FrontendService{static: static}
+	if err := f.hashStaticAssets(); err != nil {
+		return nil, err
+	}
+
+	base, err := template.New("base.gotemplate.html").
+		Funcs(template.FuncMap{"asset": f.AssetURL}).
+		ParseFS(templateFS, "templates/base.gotemplate.html")
+	if err != nil {
+		return nil, fmt.Errorf("frontend: failed to parse base template: %w", err)
+	}
+	f.base = base
+
+	return f, nil
+}
+
+// hashStaticAssets walks the embedded static tree and records a short content
+// hash per file, keyed by its path relative to the static root (e.g. "css/dashboard.css").
+func (f *FrontendService) hashStaticAssets() error {
+	hashes := make(map[string]string)
+
+	err := fs.WalkDir(f.static, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		contents, err := fs.ReadFile(f.static, path)
+		if err != nil {
+			return fmt.Errorf("reading static asset %q: %w", path, err)
+		}
+		sum := sha256.Sum256(contents)
+		hashes[path] = hex.EncodeToString(sum[:])[:8]
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("frontend: failed to hash static assets: %w", err)
+	}
+
+	f.assetHashes = hashes
+	return nil
+}
+
+// AssetURL returns the /static/ URL for relPath (e.g. "css/dashboard.css"),
+// appending a content-hash query parameter so browsers cache it until it changes.
+func (f *FrontendService) AssetURL(relPath string) string {
+	hash, ok := f.assetHashes[relPath]
+	if !ok {
+		return "/static/" + relPath
+	}
+	return "/static/" + relPath + "?v=" + hash
+}
+
+// StaticHandler serves the embedded static assets, expected to be mounted at /static/.
+func (f *FrontendService) StaticHandler() http.Handler {
+	return http.StripPrefix("/static/", http.FileServer(http.FS(f.static)))
+}
+
+// ServiceWorkerHandler serves static/sw.js, expected to be mounted at /sw.js rather than
+// under /static/ so the registered service worker's default scope covers the whole site,
+// not just the static asset tree.
+func (f *FrontendService) ServiceWorkerHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contents, err := fs.ReadFile(f.static, "sw.js")
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/javascript")
+		w.Write(contents)
+	})
+}
+
+// RenderPage composes the base layout with the named page's content template
+// (templates/<page>.gotemplate.html) and executes it against data.
+func (f *FrontendService) RenderPage(w http.ResponseWriter, page string, data interface{}) error {
+	tmpl, err := f.base.Clone()
+	if err != nil {
+		return fmt.Errorf("frontend: failed to clone base template: %w", err)
+	}
+
+	tmpl, err = tmpl.ParseFS(templateFS, "templates/"+page+".gotemplate.html")
+	if err != nil {
+		return fmt.Errorf("frontend: failed to parse page %q: %w", page, err)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.ExecuteTemplate(w, "base.gotemplate.html", data); err != nil {
+		return fmt.Errorf("frontend: failed to render page %q: %w", page, err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+ * File: src/go/frontend/frontend_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 7, 2025
+ * Title: Tests for the templated frontend service
+ * Purpose: Ensures page rendering, asset cache-busting, and static serving behave
+ * Reason: Provides regression coverage for the dashboard/login page refactor
+ *
+ * Change Log:
+ * 2025-08-07: Initial tests for RenderPage, AssetURL, and StaticHandler
+ */
+
+package frontend
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRenderPageDashboardIncludesData confirms page data reaches the rendered HTML.
+func TestRenderPageDashboardIncludesData(t *testing.T) {
+	f, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	data := struct {
+		Title      string
+		ServerHost string
+		ServerPort int
+		Config     struct {
+			ParentMass       float64
+			ExcitationEnergy float64
+			EventsPerSecond  float64
+			MaxEnergyField   float64
+		}
+	}{
+		Title:      "Test Dashboard",
+		ServerHost: "127.0.0.1",
+		ServerPort: 8238,
+	}
+
+	rec := httptest.NewRecorder()
+	if err := f.RenderPage(rec, "dashboard", data); err != nil {
+		t.Fatalf("RenderPage failed: %v", err)
+	}
+
+	body := rec.Body.String()
+	if !strings.Contains(body, "Test Dashboard") {
+		t.Errorf("expected rendered page to contain title, got: %s", body[:200])
+	}
+	if !strings.Contains(body, "127.0.0.1:8238") {
+		t.Errorf("expected rendered page to contain server host:port")
+	}
+}
+
+// TestRenderPageUnknownPageFails confirms a missing template name surfaces an error
+// instead of silently rendering an empty page.
+func TestRenderPageUnknownPageFails(t *testing.T) {
+	f, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	if err := f.RenderPage(rec, "does-not-exist", nil); err == nil {
+		t.Error("expected an error rendering an unknown page, got nil")
+	}
+}
+
+// TestAssetURLIsStableAndCacheBusted confirms the same asset always hashes to the
+// same URL, and that the URL changes only when content changes.
+func TestAssetURLIsStableAndCacheBusted(t *testing.T) {
+	f, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	first := f.AssetURL("css/dashboard.css")
+	second := f.AssetURL("css/dashboard.css")
+	if first != second {
+		t.Errorf("expected stable asset URL, got %q then %q", first, second)
+	}
+	if !strings.HasPrefix(first, "/static/css/dashboard.css?v=") {
+		t.Errorf("expected cache-busted static URL, got %q", first)
+	}
+}
+
+// TestStaticHandlerServesEmbeddedAsset confirms the static handler serves an
+// embedded file under /static/.
+func TestStaticHandlerServesEmbeddedAsset(t *testing.T) {
+	f, err := New()
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/static/css/dashboard.css", nil)
+	rec := httptest.NewRecorder()
+	f.StaticHandler().ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if rec.Body.Len() == 0 {
+		t.Error("expected non-empty static asset body")
+	}
+}
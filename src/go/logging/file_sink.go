@@ -0,0 +1,133 @@
+/*
+ * File: src/go/logging/file_sink.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Size/Age-Rotated File Sink
+ * Purpose: Writes log entries to a file, rotating it once it crosses a size limit and
+ *          pruning rotated files older than an age limit
+ * Reason: Lets operators ship to local disk without an external rotation tool (logrotate)
+ *
+ * Change Log:
+ * 2025-08-09: Initial rotating file sink
+ */
+
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileSink appends formatted entries to a file, rotating it to a timestamped name
+// once its size would exceed maxSize, and pruning rotated files past maxAge.
+type fileSink struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	size    int64
+	maxSize int64
+	maxAge  time.Duration
+	format  Format
+}
+
+// newFileSink opens (or creates) path for appending. maxSizeMB <= 0 disables
+// rotation; maxAgeDays <= 0 disables pruning of already-rotated files.
+func newFileSink(path string, maxSizeMB, maxAgeDays int, format Format) (*fileSink, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory %s: %w", dir, err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	var size int64
+	if info, err := file.Stat(); err == nil {
+		size = info.Size()
+	}
+
+	sink := &fileSink{
+		path:    path,
+		file:    file,
+		size:    size,
+		maxSize: int64(maxSizeMB) * 1024 * 1024,
+		maxAge:  time.Duration(maxAgeDays) * 24 * time.Hour,
+		format:  format,
+	}
+	sink.pruneRotated()
+	return sink, nil
+}
+
+func (s *fileSink) Write(entry Entry) error {
+	line := formatEntry(entry, s.format)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.maxSize > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+// rotate closes the current file, renames it with a timestamp suffix, and opens a
+// fresh file at the original path.
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", s.path, time.Now().Format("20060102T150405"))
+	if err := os.Rename(s.path, rotatedPath); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	s.file = file
+	s.size = 0
+	s.pruneRotated()
+	return nil
+}
+
+// pruneRotated removes already-rotated files older than maxAge.
+func (s *fileSink) pruneRotated() {
+	if s.maxAge <= 0 {
+		return
+	}
+
+	matches, err := filepath.Glob(s.path + ".*")
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-s.maxAge)
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			os.Remove(match)
+		}
+	}
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
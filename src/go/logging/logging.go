@@ -0,0 +1,358 @@
+/*
+ * File: src/go/logging/logging.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Structured Logger With Configurable Sinks
+ * Purpose: Provides a leveled, structured logger that can fan a single log line out to
+ *          stdout, a size/age-rotated file, syslog, and a Loki push endpoint
+ * Reason: Replaces ad-hoc log.Printf calls so operators can ship logs to a central
+ *         aggregator without a sidecar, and so request-scoped fields travel with each line
+ *
+ * Change Log:
+ * 2025-08-09: Initial structured logger, rotating file sink, Loki batch sink, and the
+ *             syslog sink split by build tag in logging_syslog_*.go
+ * 2025-08-10: Added FormatColor, an ANSI-colorized text rendering selected by log_format
+ *             ("color"/"console"/"dev") for a CLI-friendly development handler alongside the
+ *             existing plain-text and JSON ones
+ *
+ * Carry-over Context:
+ * - Field values are passed as a Fields map rather than variadic key/value pairs, matching
+ *   how the rest of the API server favors small structs over variadic argument lists
+ * - Sinks are independent; a failing sink logs to stderr rather than blocking the others
+ */
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a single log entry.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// String renders the level the way it appears in both text and JSON output.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
+
+// ParseLevel maps a config string (as used by LogLevel) to a Level, defaulting to Info
+// for an empty or unrecognized value rather than failing startup over a typo.
+func ParseLevel(value string) Level {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "debug":
+		return LevelDebug
+	case "warn", "warning":
+		return LevelWarn
+	case "error":
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
+
+// Format selects how a rendered line looks; it is independent of which sinks are active.
+type Format int
+
+const (
+	FormatText Format = iota
+	FormatJSON
+	FormatColor
+)
+
+// ParseFormat maps a config string to a Format, defaulting to text. "color"/"console"/"dev"
+// select the ANSI-colorized text handler meant for an operator's terminal; "json" selects the
+// handler meant for a production log aggregator; anything else (including empty) is plain text.
+func ParseFormat(value string) Format {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "json":
+		return FormatJSON
+	case "color", "console", "dev":
+		return FormatColor
+	default:
+		return FormatText
+	}
+}
+
+// Fields carries the request-scoped or call-scoped data attached to a log line, e.g.
+// request_id, remote_addr, endpoint, status, latency_ms, user.
+type Fields map[string]interface{}
+
+// Entry is one fully-resolved log line, ready to be rendered by a sink.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Fields  Fields
+}
+
+// Sink receives every entry a Logger is configured to emit; sinks format and deliver
+// independently so a slow or failing aggregator doesn't block the others.
+type Sink interface {
+	Write(entry Entry) error
+	Close() error
+}
+
+// Config describes which sinks to build and how to format their output. It mirrors the
+// Config fields on TernaryFissionAPIServer so callers can pass those straight through.
+type Config struct {
+	Level  string
+	Format string
+
+	Stdout bool
+
+	FilePath       string
+	FileMaxSizeMB  int
+	FileMaxAgeDays int
+
+	SyslogEnabled bool
+	SyslogNetwork string
+	SyslogAddress string
+	SyslogTag     string
+
+	LokiEnabled              bool
+	LokiURL                  string
+	LokiLabels               map[string]string
+	LokiBatchSize            int
+	LokiBatchIntervalSeconds int
+}
+
+// Logger dispatches entries at or above its level to every configured sink. With
+// returns a child Logger that merges additional fields onto every entry it logs,
+// which is how request-scoped fields travel through loggingMiddleware.
+type Logger struct {
+	level  Level
+	format Format
+	sinks  []Sink
+	fields Fields
+	ring   *ringSink
+}
+
+// New builds a Logger from cfg, opening the file/syslog/Loki sinks it asks for. It
+// fails only when a requested sink cannot be constructed (e.g. the log file's
+// directory can't be created), since a half-built logger would silently drop logs.
+func New(cfg Config) (*Logger, error) {
+	level := ParseLevel(cfg.Level)
+	format := ParseFormat(cfg.Format)
+
+	var sinks []Sink
+
+	if cfg.Stdout {
+		sinks = append(sinks, newWriterSink(os.Stdout, format))
+	}
+
+	if cfg.FilePath != "" {
+		fileSink, err := newFileSink(cfg.FilePath, cfg.FileMaxSizeMB, cfg.FileMaxAgeDays, format)
+		if err != nil {
+			return nil, fmt.Errorf("logging: failed to open log file %s: %w", cfg.FilePath, err)
+		}
+		sinks = append(sinks, fileSink)
+	}
+
+	if cfg.SyslogEnabled {
+		syslogSink, err := newSyslogSink(cfg.SyslogNetwork, cfg.SyslogAddress, cfg.SyslogTag)
+		if err != nil {
+			return nil, fmt.Errorf("logging: failed to dial syslog: %w", err)
+		}
+		sinks = append(sinks, syslogSink)
+	}
+
+	if cfg.LokiEnabled {
+		sinks = append(sinks, newLokiSink(cfg.LokiURL, cfg.LokiLabels, cfg.LokiBatchSize, time.Duration(cfg.LokiBatchIntervalSeconds)*time.Second, format))
+	}
+
+	// We always fall back to stdout so a misconfiguration (every sink disabled)
+	// doesn't leave the process logging into a void.
+	if len(sinks) == 0 {
+		sinks = append(sinks, newWriterSink(os.Stdout, format))
+	}
+
+	ring := newRingSink(defaultRingCapacity)
+	sinks = append(sinks, ring)
+
+	return &Logger{level: level, format: format, sinks: sinks, ring: ring}, nil
+}
+
+// With returns a child Logger that attaches fields to every entry it logs in addition
+// to the parent's own fields, without mutating the parent.
+func (l *Logger) With(fields Fields) *Logger {
+	return &Logger{
+		level:  l.level,
+		format: l.format,
+		sinks:  l.sinks,
+		fields: mergeFields(l.fields, fields),
+		ring:   l.ring,
+	}
+}
+
+func (l *Logger) log(level Level, message string, fields Fields) {
+	if level < l.level {
+		return
+	}
+
+	entry := Entry{
+		Time:    time.Now(),
+		Level:   level,
+		Message: message,
+		Fields:  mergeFields(l.fields, fields),
+	}
+
+	for _, sink := range l.sinks {
+		if err := sink.Write(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "logging: sink write failed: %v\n", err)
+		}
+	}
+}
+
+// Debug logs a low-level diagnostic line, suppressed unless LogLevel is "debug".
+func (l *Logger) Debug(message string, fields Fields) { l.log(LevelDebug, message, fields) }
+
+// Info logs routine operational events.
+func (l *Logger) Info(message string, fields Fields) { l.log(LevelInfo, message, fields) }
+
+// Warn logs a recoverable problem worth an operator's attention.
+func (l *Logger) Warn(message string, fields Fields) { l.log(LevelWarn, message, fields) }
+
+// Error logs a failure that affected the current request or operation.
+func (l *Logger) Error(message string, fields Fields) { l.log(LevelError, message, fields) }
+
+// Close flushes and closes every sink, returning the first error encountered.
+func (l *Logger) Close() error {
+	var firstErr error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func mergeFields(base, extra Fields) Fields {
+	if len(base) == 0 {
+		return extra
+	}
+	merged := make(Fields, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// formatEntry renders entry as a single line (including its trailing newline) in
+// either text or JSON form.
+func formatEntry(entry Entry, format Format) []byte {
+	switch format {
+	case FormatJSON:
+		return formatJSON(entry)
+	case FormatColor:
+		return formatText(entry, true)
+	default:
+		return formatText(entry, false)
+	}
+}
+
+func formatJSON(entry Entry) []byte {
+	doc := make(map[string]interface{}, len(entry.Fields)+3)
+	for k, v := range entry.Fields {
+		doc[k] = v
+	}
+	doc["time"] = entry.Time.Format(time.RFC3339Nano)
+	doc["level"] = entry.Level.String()
+	doc["message"] = entry.Message
+
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		encoded = []byte(fmt.Sprintf(`{"level":"ERROR","message":"logging: failed to marshal entry: %s"}`, err))
+	}
+	return append(encoded, '\n')
+}
+
+// ansiLevelColor returns the escape code a colorized handler wraps the level name in; warnings
+// and errors stand out in yellow/red, debug fades to gray, info stays the default cyan.
+func ansiLevelColor(level Level) string {
+	switch level {
+	case LevelDebug:
+		return "\x1b[90m"
+	case LevelWarn:
+		return "\x1b[33m"
+	case LevelError:
+		return "\x1b[31m"
+	default:
+		return "\x1b[36m"
+	}
+}
+
+const ansiReset = "\x1b[0m"
+
+// formatText renders entry as plain text (ringSink's support-bundle dump always passes
+// colorize=false, regardless of the configured Format, since that output is as likely to land
+// in a file as a terminal); colorize=true is what FormatColor uses for an operator's terminal.
+func formatText(entry Entry, colorize bool) []byte {
+	var buf bytes.Buffer
+
+	levelField := fmt.Sprintf("%-5s", entry.Level.String())
+	if colorize {
+		levelField = ansiLevelColor(entry.Level) + levelField + ansiReset
+	}
+	fmt.Fprintf(&buf, "%s %s %s", entry.Time.Format(time.RFC3339), levelField, entry.Message)
+
+	keys := make([]string, 0, len(entry.Fields))
+	for k := range entry.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&buf, " %s=%v", k, entry.Fields[k])
+	}
+
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// writerSink formats and writes every entry to an io.Writer (stdout in practice),
+// serializing writes so concurrent requests don't interleave partial lines.
+type writerSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format Format
+}
+
+func newWriterSink(w io.Writer, format Format) *writerSink {
+	return &writerSink{w: w, format: format}
+}
+
+func (s *writerSink) Write(entry Entry) error {
+	line := formatEntry(entry, s.format)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.w.Write(line)
+	return err
+}
+
+func (s *writerSink) Close() error { return nil }
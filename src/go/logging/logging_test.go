@@ -0,0 +1,147 @@
+/*
+ * File: src/go/logging/logging_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the structured logger
+ * Purpose: Ensures level filtering, field merging, JSON/text formatting, and the
+ *          rotating file sink behave
+ * Reason: Provides regression coverage for the structured logging subsystem
+ *
+ * Change Log:
+ * 2025-08-09: Initial tests for New, With, and the rotating file sink
+ * 2025-08-10: Added tests for ParseFormat's color/console/dev aliases and FormatColor's
+ *             ANSI-wrapped level field
+ */
+
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoggerFiltersBelowConfiguredLevel confirms Debug lines are dropped when the
+// configured level is Info.
+func TestLoggerFiltersBelowConfiguredLevel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := New(Config{Level: "info", Format: "text", FilePath: path})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Close()
+
+	logger.Debug("should not appear", nil)
+	logger.Info("should appear", nil)
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if strings.Contains(string(contents), "should not appear") {
+		t.Error("expected debug line to be filtered out at info level")
+	}
+	if !strings.Contains(string(contents), "should appear") {
+		t.Error("expected info line to be written")
+	}
+}
+
+// TestLoggerWithMergesFields confirms a child Logger's fields are attached to every
+// entry it logs, alongside the parent's own fields.
+func TestLoggerWithMergesFields(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	logger, err := New(Config{Level: "debug", Format: "json", FilePath: path})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer logger.Close()
+
+	child := logger.With(Fields{"request_id": "abc-123"})
+	child.Info("handled request", Fields{"status": 200})
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(contents, &doc); err != nil {
+		t.Fatalf("failed to decode JSON line: %v (line: %s)", err, contents)
+	}
+	if doc["request_id"] != "abc-123" {
+		t.Errorf("expected request_id field to carry through, got %v", doc["request_id"])
+	}
+	if doc["status"] != float64(200) {
+		t.Errorf("expected status field, got %v", doc["status"])
+	}
+	if doc["message"] != "handled request" {
+		t.Errorf("expected message field, got %v", doc["message"])
+	}
+}
+
+// TestFileSinkRotatesPastMaxSize confirms the file sink rotates once the configured
+// size limit would be exceeded, leaving a rotated file behind.
+func TestFileSinkRotatesPastMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+
+	sink, err := newFileSink(path, 0, 0, FormatText)
+	if err != nil {
+		t.Fatalf("newFileSink failed: %v", err)
+	}
+	sink.maxSize = 10 // force rotation almost immediately
+	defer sink.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := sink.Write(Entry{Message: "a reasonably long log line", Level: LevelInfo, Fields: Fields{}}); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("glob failed: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Error("expected at least one rotated log file")
+	}
+}
+
+// TestParseFormatRecognizesColorAliases confirms "color", "console", and "dev" all select
+// FormatColor, the CLI-friendly development handler, while an unrecognized value still falls
+// back to plain text.
+func TestParseFormatRecognizesColorAliases(t *testing.T) {
+	for _, alias := range []string{"color", "console", "dev", "COLOR"} {
+		if got := ParseFormat(alias); got != FormatColor {
+			t.Errorf("ParseFormat(%q) = %v, want FormatColor", alias, got)
+		}
+	}
+	if got := ParseFormat("bogus"); got != FormatText {
+		t.Errorf("ParseFormat(\"bogus\") = %v, want FormatText", got)
+	}
+}
+
+// TestFormatColorWrapsLevelInAnsiCodes confirms FormatColor adds an ANSI escape around the
+// level field that plain FormatText doesn't, while leaving the message and fields untouched.
+func TestFormatColorWrapsLevelInAnsiCodes(t *testing.T) {
+	entry := Entry{Level: LevelError, Message: "boom", Fields: Fields{"request_id": "abc-123"}}
+
+	plain := string(formatEntry(entry, FormatText))
+	colored := string(formatEntry(entry, FormatColor))
+
+	if strings.Contains(plain, "\x1b[") {
+		t.Errorf("expected plain text to carry no ANSI codes, got %q", plain)
+	}
+	if !strings.Contains(colored, "\x1b[31m") || !strings.Contains(colored, ansiReset) {
+		t.Errorf("expected colorized ERROR line to carry ANSI codes, got %q", colored)
+	}
+	if !strings.Contains(colored, "boom") || !strings.Contains(colored, "request_id=abc-123") {
+		t.Errorf("expected colorized line to still carry message and fields, got %q", colored)
+	}
+}
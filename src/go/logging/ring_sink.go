@@ -0,0 +1,88 @@
+/*
+ * File: src/go/logging/ring_sink.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: In-Memory Ring Buffer Sink
+ * Purpose: Keeps the last N rendered log lines in memory so a support bundle can include
+ *          recent log history without tailing the file/syslog/Loki sinks
+ * Reason: The debug subsystem's support-bundle endpoint needs "last N log lines" and none
+ *         of the existing sinks expose their history back to the process
+ *
+ * Change Log:
+ * 2025-08-09: Initial fixed-capacity ring sink and Logger.RecentLines
+ *
+ * Carry-over Context:
+ * - Always text-formatted regardless of the configured Format, since the bundle is read by a
+ *   human, not a log aggregator
+ * - Every Logger gets one of these unconditionally, the same way New() falls back to stdout -
+ *   it is cheap and has no external dependency to misconfigure
+ */
+
+package logging
+
+import "sync"
+
+const defaultRingCapacity = 1000
+
+// ringSink retains the most recent entries as rendered text lines in a fixed-size circular
+// buffer, overwriting the oldest entry once full.
+type ringSink struct {
+	mu       sync.Mutex
+	lines    []string
+	capacity int
+	next     int
+	filled   bool
+}
+
+func newRingSink(capacity int) *ringSink {
+	if capacity <= 0 {
+		capacity = defaultRingCapacity
+	}
+	return &ringSink{lines: make([]string, capacity), capacity: capacity}
+}
+
+func (r *ringSink) Write(entry Entry) error {
+	line := string(formatText(entry, false))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % r.capacity
+	if r.next == 0 {
+		r.filled = true
+	}
+	return nil
+}
+
+func (r *ringSink) Close() error { return nil }
+
+// Recent returns up to n of the most recently written lines, oldest first.
+func (r *ringSink) Recent(n int) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	size := r.next
+	if r.filled {
+		size = r.capacity
+	}
+	if n <= 0 || n > size {
+		n = size
+	}
+
+	ordered := make([]string, 0, size)
+	if r.filled {
+		ordered = append(ordered, r.lines[r.next:]...)
+	}
+	ordered = append(ordered, r.lines[:r.next]...)
+
+	return ordered[len(ordered)-n:]
+}
+
+// RecentLines returns up to n of the most recently logged lines, oldest first, for
+// inclusion in a diagnostic dump.
+func (l *Logger) RecentLines(n int) []string {
+	if l.ring == nil {
+		return nil
+	}
+	return l.ring.Recent(n)
+}
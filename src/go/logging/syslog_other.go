@@ -0,0 +1,23 @@
+//go:build windows || plan9
+
+/*
+ * File: src/go/logging/syslog_other.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Syslog Sink Stub (Windows/Plan 9)
+ * Purpose: Reports a clear configuration error instead of failing to compile, since
+ *          log/syslog doesn't exist on these platforms
+ * Reason: Keeps `log_syslog_enabled = true` a runtime config mistake rather than a
+ *         build break for operators cross-compiling this binary
+ *
+ * Change Log:
+ * 2025-08-09: Initial stub
+ */
+
+package logging
+
+import "fmt"
+
+func newSyslogSink(network, address, tag string) (Sink, error) {
+	return nil, fmt.Errorf("syslog sink is not supported on this platform")
+}
@@ -0,0 +1,57 @@
+//go:build !windows && !plan9
+
+/*
+ * File: src/go/logging/syslog_unix.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Syslog Sink (Unix)
+ * Purpose: Delivers log entries to a local or remote syslog daemon
+ * Reason: log/syslog is unix-only, so this sink is split from the Windows/Plan 9 stub
+ *         by build tag
+ *
+ * Change Log:
+ * 2025-08-09: Initial syslog sink backed by log/syslog
+ */
+
+package logging
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// newSyslogSink dials syslog at network/address (both empty dials the local daemon)
+// and tags every message with tag.
+func newSyslogSink(network, address, tag string) (Sink, error) {
+	if tag == "" {
+		tag = "ternary-fission-api"
+	}
+
+	writer, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, fmt.Errorf("syslog dial failed: %w", err)
+	}
+	return &syslogSink{writer: writer}, nil
+}
+
+func (s *syslogSink) Write(entry Entry) error {
+	line := string(formatEntry(entry, FormatText))
+	switch entry.Level {
+	case LevelDebug:
+		return s.writer.Debug(line)
+	case LevelWarn:
+		return s.writer.Warning(line)
+	case LevelError:
+		return s.writer.Err(line)
+	default:
+		return s.writer.Info(line)
+	}
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
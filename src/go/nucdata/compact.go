@@ -0,0 +1,125 @@
+/*
+ * File: src/go/nucdata/compact.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Compact On-Disk Nuclide Table Format
+ * Purpose: Defines the tab-separated row format both the embedded fallback table and
+ *          cmd/nucdata-import's output use, so LoadIndex's embedded path and a freshly
+ *          imported AME2020/ENSDF index are interchangeable
+ * Reason: The official AME2020/ENSDF text tables are large and not bundled in the binary;
+ *         this compact format is what actually ships in the embedded fallback
+ *
+ * Change Log:
+ * 2025-08-09: Initial compact format: one tab-separated row per nuclide, decay modes
+ *             packed into a single semicolon-separated column
+ */
+
+package nucdata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteCompactTable writes nuclides as the tab-separated compact format: symbol, Z, A,
+// mass excess (keV), binding energy per nucleon (keV), spin-parity, and a
+// semicolon-separated "type,ratio,daughterZ,daughterA" list of decay modes.
+func WriteCompactTable(w io.Writer, nuclides []Nuclide) error {
+	for _, n := range nuclides {
+		modeFields := make([]string, len(n.DecayModes))
+		for i, m := range n.DecayModes {
+			modeFields[i] = fmt.Sprintf("%s,%g,%d,%d", m.Type, m.BranchingRatio, m.DaughterZ, m.DaughterA)
+		}
+		row := []string{
+			n.Symbol,
+			strconv.Itoa(n.Z),
+			strconv.Itoa(n.A),
+			strconv.FormatFloat(n.MassExcessKeV, 'g', -1, 64),
+			strconv.FormatFloat(n.BindingEnergyPerNucleonKeV, 'g', -1, 64),
+			n.SpinParity,
+			strings.Join(modeFields, ";"),
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseCompactTable reads the tab-separated compact format WriteCompactTable produces.
+func ParseCompactTable(r io.Reader) ([]Nuclide, error) {
+	scanner := bufio.NewScanner(r)
+	var nuclides []Nuclide
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("nucdata: malformed compact table row %q", line)
+		}
+
+		z, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("nucdata: invalid Z in row %q: %w", line, err)
+		}
+		a, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("nucdata: invalid A in row %q: %w", line, err)
+		}
+		massExcess, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("nucdata: invalid mass excess in row %q: %w", line, err)
+		}
+		bindingEnergy, err := strconv.ParseFloat(fields[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("nucdata: invalid binding energy in row %q: %w", line, err)
+		}
+
+		nuclide := Nuclide{
+			Symbol:                     fields[0],
+			Z:                          z,
+			A:                          a,
+			MassExcessKeV:              massExcess,
+			BindingEnergyPerNucleonKeV: bindingEnergy,
+			SpinParity:                 fields[5],
+		}
+
+		if fields[6] != "" {
+			for _, modeField := range strings.Split(fields[6], ";") {
+				parts := strings.Split(modeField, ",")
+				if len(parts) != 4 {
+					return nil, fmt.Errorf("nucdata: malformed decay mode %q in row %q", modeField, line)
+				}
+				ratio, err := strconv.ParseFloat(parts[1], 64)
+				if err != nil {
+					return nil, fmt.Errorf("nucdata: invalid branching ratio in row %q: %w", line, err)
+				}
+				daughterZ, err := strconv.Atoi(parts[2])
+				if err != nil {
+					return nil, fmt.Errorf("nucdata: invalid daughter Z in row %q: %w", line, err)
+				}
+				daughterA, err := strconv.Atoi(parts[3])
+				if err != nil {
+					return nil, fmt.Errorf("nucdata: invalid daughter A in row %q: %w", line, err)
+				}
+				nuclide.DecayModes = append(nuclide.DecayModes, DecayModeRef{
+					Type:           parts[0],
+					BranchingRatio: ratio,
+					DaughterZ:      daughterZ,
+					DaughterA:      daughterA,
+				})
+			}
+		}
+
+		nuclides = append(nuclides, nuclide)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nuclides, nil
+}
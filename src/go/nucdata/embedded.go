@@ -0,0 +1,51 @@
+/*
+ * File: src/go/nucdata/embedded.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Checksum-Verified Embedded Nuclide Fallback Table
+ * Purpose: Ships a small, hand-curated nuclide table inside the binary so lookups still
+ *          work when no AME2020/ENSDF data directory is configured
+ * Reason: The official tables are tens of megabytes and not vendored into this repo;
+ *         operators who haven't run cmd/nucdata-import still need a working service
+ *
+ * Change Log:
+ * 2025-08-09: Initial embedded fallback: the six requested actinide parents (Th-232,
+ *             U-233, U-238, Pu-239, Cm-245, Cf-252) plus U-235 and the fission-product
+ *             chains already known to api.ternary.fission.decay.go, with a SHA-256
+ *             checksum verified at load time to catch a corrupted embed
+ */
+
+package nucdata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+//go:embed data/fallback_nuclides.tsv
+var embeddedTableData []byte
+
+//go:embed data/fallback_nuclides.tsv.sha256
+var embeddedTableChecksum []byte
+
+// NewEmbeddedIndex parses the embedded fallback table after verifying it against its
+// embedded checksum, so a corrupted embed fails loudly instead of serving silently wrong
+// mass data.
+func NewEmbeddedIndex() (*Index, error) {
+	sum := sha256.Sum256(embeddedTableData)
+	got := hex.EncodeToString(sum[:])
+	want := strings.TrimSpace(string(embeddedTableChecksum))
+	if got != want {
+		return nil, fmt.Errorf("nucdata: embedded fallback table checksum mismatch: got %s, want %s", got, want)
+	}
+
+	nuclides, err := ParseCompactTable(bytes.NewReader(embeddedTableData))
+	if err != nil {
+		return nil, fmt.Errorf("nucdata: parsing embedded fallback table: %w", err)
+	}
+	return newIndex(nuclides), nil
+}
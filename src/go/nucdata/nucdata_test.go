@@ -0,0 +1,111 @@
+/*
+ * File: src/go/nucdata/nucdata_test.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Tests for the nuclide data service
+ * Purpose: Ensures the embedded fallback loads, exact (Z, A)/symbol lookups resolve, and
+ *          the compact table round-trips through WriteCompactTable/ParseCompactTable
+ * Reason: Provides regression coverage for LoadIndex's fallback path and cmd/nucdata-import
+ *
+ * Change Log:
+ * 2025-08-09: Initial tests for NewEmbeddedIndex, LookupNuclide/LookupSymbol, and the
+ *             compact table writer/parser round trip
+ */
+
+package nucdata
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestNewEmbeddedIndexLoadsParentPresets confirms every actinide in ParentPresets
+// resolves through the embedded fallback, since the dashboard's parent-nucleus selector
+// depends on all of them being lookupable offline.
+func TestNewEmbeddedIndexLoadsParentPresets(t *testing.T) {
+	idx, err := NewEmbeddedIndex()
+	if err != nil {
+		t.Fatalf("NewEmbeddedIndex() failed: %v", err)
+	}
+
+	for symbol, preset := range ParentPresets {
+		nuclide, err := idx.LookupNuclide(preset.Z, preset.A)
+		if err != nil {
+			t.Errorf("expected %s (Z=%d, A=%d) to be indexed: %v", symbol, preset.Z, preset.A, err)
+			continue
+		}
+		if nuclide.Symbol != symbol {
+			t.Errorf("expected symbol %s, got %s", symbol, nuclide.Symbol)
+		}
+	}
+}
+
+// TestLookupNuclideReturnsErrorForUnknownEntry confirms a missing (Z, A) is a reported
+// error rather than a zero-value Nuclide silently masquerading as real data.
+func TestLookupNuclideReturnsErrorForUnknownEntry(t *testing.T) {
+	idx, err := NewEmbeddedIndex()
+	if err != nil {
+		t.Fatalf("NewEmbeddedIndex() failed: %v", err)
+	}
+
+	if _, err := idx.LookupNuclide(1, 1); err == nil {
+		t.Error("expected an error for an unindexed (Z, A)")
+	}
+}
+
+// TestLookupSymbolMatchesLookupNuclide confirms both lookup paths resolve to the same row.
+func TestLookupSymbolMatchesLookupNuclide(t *testing.T) {
+	idx, err := NewEmbeddedIndex()
+	if err != nil {
+		t.Fatalf("NewEmbeddedIndex() failed: %v", err)
+	}
+
+	bySymbol, err := idx.LookupSymbol("U-235")
+	if err != nil {
+		t.Fatalf("LookupSymbol(U-235) failed: %v", err)
+	}
+	byZA, err := idx.LookupNuclide(92, 235)
+	if err != nil {
+		t.Fatalf("LookupNuclide(92, 235) failed: %v", err)
+	}
+	if bySymbol.Symbol != byZA.Symbol || bySymbol.MassExcessKeV != byZA.MassExcessKeV {
+		t.Errorf("expected LookupSymbol and LookupNuclide to agree, got %+v vs %+v", bySymbol, byZA)
+	}
+}
+
+// TestCompactTableRoundTrips confirms WriteCompactTable output parses back to the same
+// nuclides, including a multi-mode decay entry like Cf-252's alpha/spontaneous-fission split.
+func TestCompactTableRoundTrips(t *testing.T) {
+	original := []Nuclide{
+		{
+			Symbol: "Cf-252", Z: 98, A: 252,
+			MassExcessKeV: 76035.3, BindingEnergyPerNucleonKeV: 7465.3, SpinParity: "0+",
+			DecayModes: []DecayModeRef{
+				{Type: "alpha", BranchingRatio: 0.9691, DaughterZ: 96, DaughterA: 248},
+				{Type: "spontaneous_fission", BranchingRatio: 0.0309, DaughterZ: 0, DaughterA: 0},
+			},
+		},
+		{Symbol: "Zr-92", Z: 40, A: 92, MassExcessKeV: -88459.6, BindingEnergyPerNucleonKeV: 8692.7, SpinParity: "0+"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCompactTable(&buf, original); err != nil {
+		t.Fatalf("WriteCompactTable() failed: %v", err)
+	}
+
+	parsed, err := ParseCompactTable(&buf)
+	if err != nil {
+		t.Fatalf("ParseCompactTable() failed: %v", err)
+	}
+	if len(parsed) != len(original) {
+		t.Fatalf("expected %d nuclides, got %d", len(original), len(parsed))
+	}
+	for i := range original {
+		if parsed[i].Symbol != original[i].Symbol || parsed[i].Z != original[i].Z || parsed[i].A != original[i].A {
+			t.Errorf("row %d: expected %+v, got %+v", i, original[i], parsed[i])
+		}
+		if len(parsed[i].DecayModes) != len(original[i].DecayModes) {
+			t.Errorf("row %d: expected %d decay modes, got %d", i, len(original[i].DecayModes), len(parsed[i].DecayModes))
+		}
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * File: src/go/pb/codec.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: grpc.Codec for the Hand-Encoded Wire Types
+ * Purpose: Registers a grpc-go encoding.Codec that marshals/unmarshals through wireMessage
+ *          instead of the real "proto" codec (which requires proto.Message/ProtoReflect -
+ *          machinery only protoc-gen-go's generated code provides)
+ * Reason: grpc.ClientConn.Invoke/NewStream pick a codec by call-content-subtype; this lets
+ *         reactor_grpc.pb.go's hand-written stubs round-trip our own message types without
+ *         pulling in a full generated-code pipeline
+ *
+ * Change Log:
+ * 2025-08-09: Initial "ternarywire" codec, registered at package init
+ */
+
+package pb
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// CodecName is the grpc call-content-subtype this package's client/server stubs request via
+// grpc.CallContentSubtype, so every RPC in this package uses wireCodec instead of grpc-go's
+// default "proto" codec.
+const CodecName = "ternarywire"
+
+func init() {
+	encoding.RegisterCodec(wireCodec{})
+}
+
+// wireCodec adapts wireMessage's Marshal/Unmarshal to grpc-go's encoding.Codec interface.
+type wireCodec struct{}
+
+func (wireCodec) Name() string { return CodecName }
+
+func (wireCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(wireMessage)
+	if !ok {
+		return nil, fmt.Errorf("pb: %T does not implement wireMessage", v)
+	}
+	return msg.Marshal()
+}
+
+func (wireCodec) Unmarshal(data []byte, v interface{}) error {
+	msg, ok := v.(wireMessage)
+	if !ok {
+		return fmt.Errorf("pb: %T does not implement wireMessage", v)
+	}
+	return msg.Unmarshal(data)
+}
@@ -0,0 +1,517 @@
+/*
+ * File: src/go/pb/reactor.pb.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Hand-Encoded Wire Types for reactor.proto
+ * Purpose: Go types for every message in reactor.proto, each with a Marshal/Unmarshal pair
+ *          implemented directly against google.golang.org/protobuf/encoding/protowire
+ * Reason: This build has no protoc (and no codegen step at all - see go.mod); the same
+ *         constraint already led api.ternary.fission.metrics.go to hand-encode the Prometheus
+ *         remote_write wire format instead of vendoring prometheus/prometheus for its generated
+ *         types. These types follow that precedent: no reflection, no descriptor, just the
+ *         field-by-field encode/decode a protoc-gen-go output would otherwise generate
+ *
+ * Change Log:
+ * 2025-08-09: Initial EnergyField/SystemStatus/FissionFragment/FissionEvent wire types and the
+ *             empty/simple request/response messages ReactorTransport's RPCs use
+ *
+ * Carry-over Context:
+ * - Field numbers here must stay in lockstep with reactor.proto; there is no generator to catch
+ *   drift, so a field renumber in one must be mirrored in the other by hand
+ * - Unknown fields are skipped via protowire.ConsumeFieldValue rather than rejected, the same
+ *   forward-compatible behavior real generated proto3 code gives you for free
+ */
+
+package pb
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// wireMessage is implemented by every type in this file; the "ternarywire" grpc codec
+// (see codec.go) only ever talks to messages through this interface.
+type wireMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal([]byte) error
+}
+
+// consumeTagLoop walks buf one field at a time, calling fn with the field number, wire type,
+// and the remaining bytes starting at that field's value; fn must return how many bytes of
+// value it consumed (or a protowire.ConsumeFieldValue-style negative count to skip). It is the
+// shared decode loop every Unmarshal in this file is built on.
+func consumeTagLoop(buf []byte, fn func(num protowire.Number, typ protowire.Type, rest []byte) (int, error)) error {
+	for len(buf) > 0 {
+		num, typ, tagLen := protowire.ConsumeTag(buf)
+		if tagLen < 0 {
+			return fmt.Errorf("pb: invalid tag: %w", protowire.ParseError(tagLen))
+		}
+		buf = buf[tagLen:]
+
+		n, err := fn(num, typ, buf)
+		if err != nil {
+			return err
+		}
+		if n < 0 {
+			n = protowire.ConsumeFieldValue(num, typ, buf)
+			if n < 0 {
+				return fmt.Errorf("pb: invalid field %d: %w", num, protowire.ParseError(n))
+			}
+		}
+		buf = buf[n:]
+	}
+	return nil
+}
+
+// =============================================================================
+// EnergyField
+// =============================================================================
+
+type EnergyField struct {
+	FieldID             string
+	EnergyMeV           float64
+	MemoryBytes         uint64
+	CPUCycles           uint64
+	EntropyFactor       float64
+	DissipationRate     float64
+	StabilityFactor     float64
+	InteractionStrength float64
+	Active              bool
+	TotalEnergyMeV      float64
+	Status              string
+}
+
+func (m *EnergyField) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+	buf = protowire.AppendString(buf, m.FieldID)
+	buf = protowire.AppendTag(buf, 2, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.EnergyMeV))
+	buf = protowire.AppendTag(buf, 3, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, m.MemoryBytes)
+	buf = protowire.AppendTag(buf, 4, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, m.CPUCycles)
+	buf = protowire.AppendTag(buf, 5, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.EntropyFactor))
+	buf = protowire.AppendTag(buf, 6, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.DissipationRate))
+	buf = protowire.AppendTag(buf, 7, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.StabilityFactor))
+	buf = protowire.AppendTag(buf, 8, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.InteractionStrength))
+	buf = protowire.AppendTag(buf, 9, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, protowire.EncodeBool(m.Active))
+	buf = protowire.AppendTag(buf, 10, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.TotalEnergyMeV))
+	buf = protowire.AppendTag(buf, 11, protowire.BytesType)
+	buf = protowire.AppendString(buf, m.Status)
+	return buf, nil
+}
+
+func (m *EnergyField) Unmarshal(buf []byte) error {
+	*m = EnergyField{}
+	return consumeTagLoop(buf, func(num protowire.Number, typ protowire.Type, rest []byte) (int, error) {
+		switch num {
+		case 1:
+			v, n := protowire.ConsumeString(rest)
+			m.FieldID = v
+			return n, nil
+		case 2:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.EnergyMeV = math.Float64frombits(v)
+			return n, nil
+		case 3:
+			v, n := protowire.ConsumeVarint(rest)
+			m.MemoryBytes = v
+			return n, nil
+		case 4:
+			v, n := protowire.ConsumeVarint(rest)
+			m.CPUCycles = v
+			return n, nil
+		case 5:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.EntropyFactor = math.Float64frombits(v)
+			return n, nil
+		case 6:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.DissipationRate = math.Float64frombits(v)
+			return n, nil
+		case 7:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.StabilityFactor = math.Float64frombits(v)
+			return n, nil
+		case 8:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.InteractionStrength = math.Float64frombits(v)
+			return n, nil
+		case 9:
+			v, n := protowire.ConsumeVarint(rest)
+			m.Active = protowire.DecodeBool(v)
+			return n, nil
+		case 10:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.TotalEnergyMeV = math.Float64frombits(v)
+			return n, nil
+		case 11:
+			v, n := protowire.ConsumeString(rest)
+			m.Status = v
+			return n, nil
+		default:
+			return -1, nil
+		}
+	})
+}
+
+// =============================================================================
+// SystemStatus
+// =============================================================================
+
+type SystemStatus struct {
+	UptimeSeconds                      int64
+	TotalFissionEvents                 uint64
+	TotalEnergySimulatedMeV            float64
+	ActiveEnergyFields                 int32
+	PeakMemoryUsageBytes               uint64
+	AverageCalculationTimeMicroseconds float64
+	TotalCalculations                  uint64
+	SimulationRunning                  bool
+	CPUUsagePercent                    float64
+	MemoryUsagePercent                 float64
+	EstimatedPowerMeV                  float64
+	PortalDurationRemainingSeconds     int32
+	DecayHeatMeVPerSecond              float64
+}
+
+func (m *SystemStatus) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = protowire.AppendTag(buf, 1, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, uint64(m.UptimeSeconds))
+	buf = protowire.AppendTag(buf, 2, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, m.TotalFissionEvents)
+	buf = protowire.AppendTag(buf, 3, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.TotalEnergySimulatedMeV))
+	buf = protowire.AppendTag(buf, 4, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, uint64(m.ActiveEnergyFields))
+	buf = protowire.AppendTag(buf, 5, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, m.PeakMemoryUsageBytes)
+	buf = protowire.AppendTag(buf, 6, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.AverageCalculationTimeMicroseconds))
+	buf = protowire.AppendTag(buf, 7, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, m.TotalCalculations)
+	buf = protowire.AppendTag(buf, 8, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, protowire.EncodeBool(m.SimulationRunning))
+	buf = protowire.AppendTag(buf, 9, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.CPUUsagePercent))
+	buf = protowire.AppendTag(buf, 10, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.MemoryUsagePercent))
+	buf = protowire.AppendTag(buf, 11, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.EstimatedPowerMeV))
+	buf = protowire.AppendTag(buf, 12, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, uint64(m.PortalDurationRemainingSeconds))
+	buf = protowire.AppendTag(buf, 13, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.DecayHeatMeVPerSecond))
+	return buf, nil
+}
+
+func (m *SystemStatus) Unmarshal(buf []byte) error {
+	*m = SystemStatus{}
+	return consumeTagLoop(buf, func(num protowire.Number, typ protowire.Type, rest []byte) (int, error) {
+		switch num {
+		case 1:
+			v, n := protowire.ConsumeVarint(rest)
+			m.UptimeSeconds = int64(v)
+			return n, nil
+		case 2:
+			v, n := protowire.ConsumeVarint(rest)
+			m.TotalFissionEvents = v
+			return n, nil
+		case 3:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.TotalEnergySimulatedMeV = math.Float64frombits(v)
+			return n, nil
+		case 4:
+			v, n := protowire.ConsumeVarint(rest)
+			m.ActiveEnergyFields = int32(v)
+			return n, nil
+		case 5:
+			v, n := protowire.ConsumeVarint(rest)
+			m.PeakMemoryUsageBytes = v
+			return n, nil
+		case 6:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.AverageCalculationTimeMicroseconds = math.Float64frombits(v)
+			return n, nil
+		case 7:
+			v, n := protowire.ConsumeVarint(rest)
+			m.TotalCalculations = v
+			return n, nil
+		case 8:
+			v, n := protowire.ConsumeVarint(rest)
+			m.SimulationRunning = protowire.DecodeBool(v)
+			return n, nil
+		case 9:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.CPUUsagePercent = math.Float64frombits(v)
+			return n, nil
+		case 10:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.MemoryUsagePercent = math.Float64frombits(v)
+			return n, nil
+		case 11:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.EstimatedPowerMeV = math.Float64frombits(v)
+			return n, nil
+		case 12:
+			v, n := protowire.ConsumeVarint(rest)
+			m.PortalDurationRemainingSeconds = int32(v)
+			return n, nil
+		case 13:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.DecayHeatMeVPerSecond = math.Float64frombits(v)
+			return n, nil
+		default:
+			return -1, nil
+		}
+	})
+}
+
+// =============================================================================
+// FissionFragment / FissionEvent
+// =============================================================================
+
+type FissionFragment struct {
+	Label            string
+	Z                int32
+	MassNumber       float64
+	KineticEnergyMeV float64
+	MomentumMeVPerC  float64
+	DirectionX       float64
+	DirectionY       float64
+	DirectionZ       float64
+}
+
+func (m *FissionFragment) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+	buf = protowire.AppendString(buf, m.Label)
+	buf = protowire.AppendTag(buf, 2, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, uint64(m.Z))
+	buf = protowire.AppendTag(buf, 3, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.MassNumber))
+	buf = protowire.AppendTag(buf, 4, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.KineticEnergyMeV))
+	buf = protowire.AppendTag(buf, 5, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.MomentumMeVPerC))
+	buf = protowire.AppendTag(buf, 6, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.DirectionX))
+	buf = protowire.AppendTag(buf, 7, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.DirectionY))
+	buf = protowire.AppendTag(buf, 8, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.DirectionZ))
+	return buf, nil
+}
+
+func (m *FissionFragment) Unmarshal(buf []byte) error {
+	*m = FissionFragment{}
+	return consumeTagLoop(buf, func(num protowire.Number, typ protowire.Type, rest []byte) (int, error) {
+		switch num {
+		case 1:
+			v, n := protowire.ConsumeString(rest)
+			m.Label = v
+			return n, nil
+		case 2:
+			v, n := protowire.ConsumeVarint(rest)
+			m.Z = int32(v)
+			return n, nil
+		case 3:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.MassNumber = math.Float64frombits(v)
+			return n, nil
+		case 4:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.KineticEnergyMeV = math.Float64frombits(v)
+			return n, nil
+		case 5:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.MomentumMeVPerC = math.Float64frombits(v)
+			return n, nil
+		case 6:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.DirectionX = math.Float64frombits(v)
+			return n, nil
+		case 7:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.DirectionY = math.Float64frombits(v)
+			return n, nil
+		case 8:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.DirectionZ = math.Float64frombits(v)
+			return n, nil
+		default:
+			return -1, nil
+		}
+	})
+}
+
+type FissionEvent struct {
+	EventID        uint64
+	TotalEnergyMeV float64
+	Fragments      []FissionFragment
+	Status         *SystemStatus
+}
+
+func (m *FissionEvent) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = protowire.AppendTag(buf, 1, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, m.EventID)
+	buf = protowire.AppendTag(buf, 2, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, math.Float64bits(m.TotalEnergyMeV))
+	for i := range m.Fragments {
+		encoded, err := m.Fragments[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		buf = protowire.AppendTag(buf, 3, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, encoded)
+	}
+	if m.Status != nil {
+		encoded, err := m.Status.Marshal()
+		if err != nil {
+			return nil, err
+		}
+		buf = protowire.AppendTag(buf, 4, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, encoded)
+	}
+	return buf, nil
+}
+
+func (m *FissionEvent) Unmarshal(buf []byte) error {
+	*m = FissionEvent{}
+	return consumeTagLoop(buf, func(num protowire.Number, typ protowire.Type, rest []byte) (int, error) {
+		switch num {
+		case 1:
+			v, n := protowire.ConsumeVarint(rest)
+			m.EventID = v
+			return n, nil
+		case 2:
+			v, n := protowire.ConsumeFixed64(rest)
+			m.TotalEnergyMeV = math.Float64frombits(v)
+			return n, nil
+		case 3:
+			v, n := protowire.ConsumeBytes(rest)
+			if n < 0 {
+				return n, nil
+			}
+			var frag FissionFragment
+			if err := frag.Unmarshal(v); err != nil {
+				return 0, err
+			}
+			m.Fragments = append(m.Fragments, frag)
+			return n, nil
+		case 4:
+			v, n := protowire.ConsumeBytes(rest)
+			if n < 0 {
+				return n, nil
+			}
+			status := &SystemStatus{}
+			if err := status.Unmarshal(v); err != nil {
+				return 0, err
+			}
+			m.Status = status
+			return n, nil
+		default:
+			return -1, nil
+		}
+	})
+}
+
+// =============================================================================
+// Requests / simple responses
+// =============================================================================
+
+// GetStatusRequest, ListEnergyFieldsRequest, and SubscribeEventsRequest carry no fields today;
+// Marshal/Unmarshal are still defined so they satisfy wireMessage like every other message.
+type GetStatusRequest struct{}
+
+func (m *GetStatusRequest) Marshal() ([]byte, error) { return nil, nil }
+func (m *GetStatusRequest) Unmarshal(buf []byte) error {
+	*m = GetStatusRequest{}
+	return nil
+}
+
+type GetEnergyFieldRequest struct {
+	FieldID string
+}
+
+func (m *GetEnergyFieldRequest) Marshal() ([]byte, error) {
+	var buf []byte
+	buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+	buf = protowire.AppendString(buf, m.FieldID)
+	return buf, nil
+}
+
+func (m *GetEnergyFieldRequest) Unmarshal(buf []byte) error {
+	*m = GetEnergyFieldRequest{}
+	return consumeTagLoop(buf, func(num protowire.Number, typ protowire.Type, rest []byte) (int, error) {
+		if num == 1 {
+			v, n := protowire.ConsumeString(rest)
+			m.FieldID = v
+			return n, nil
+		}
+		return -1, nil
+	})
+}
+
+type ListEnergyFieldsRequest struct{}
+
+func (m *ListEnergyFieldsRequest) Marshal() ([]byte, error) { return nil, nil }
+func (m *ListEnergyFieldsRequest) Unmarshal(buf []byte) error {
+	*m = ListEnergyFieldsRequest{}
+	return nil
+}
+
+type ListEnergyFieldsResponse struct {
+	Fields []EnergyField
+}
+
+func (m *ListEnergyFieldsResponse) Marshal() ([]byte, error) {
+	var buf []byte
+	for i := range m.Fields {
+		encoded, err := m.Fields[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, encoded)
+	}
+	return buf, nil
+}
+
+func (m *ListEnergyFieldsResponse) Unmarshal(buf []byte) error {
+	*m = ListEnergyFieldsResponse{}
+	return consumeTagLoop(buf, func(num protowire.Number, typ protowire.Type, rest []byte) (int, error) {
+		if num == 1 {
+			v, n := protowire.ConsumeBytes(rest)
+			if n < 0 {
+				return n, nil
+			}
+			var field EnergyField
+			if err := field.Unmarshal(v); err != nil {
+				return 0, err
+			}
+			m.Fields = append(m.Fields, field)
+			return n, nil
+		}
+		return -1, nil
+	})
+}
+
+type SubscribeEventsRequest struct{}
+
+func (m *SubscribeEventsRequest) Marshal() ([]byte, error) { return nil, nil }
+func (m *SubscribeEventsRequest) Unmarshal(buf []byte) error {
+	*m = SubscribeEventsRequest{}
+	return nil
+}
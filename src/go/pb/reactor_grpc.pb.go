@@ -0,0 +1,235 @@
+/*
+ * File: src/go/pb/reactor_grpc.pb.go
+ * Author: bthlops (David StJ)
+ * Date: August 9, 2025
+ * Title: Hand-Written Client/Server Stubs for the ReactorTransport Service
+ * Purpose: The client interface, server interface, and grpc.ServiceDesc that protoc-gen-go-grpc
+ *          would normally generate from reactor.proto's "service ReactorTransport" block
+ * Reason: No protoc in this build (see reactor.pb.go's header); grpc-go's public
+ *         ClientConn.Invoke/NewStream and grpc.Server.RegisterService APIs are exactly what
+ *         generated stubs call underneath, so writing this by hand reproduces the same
+ *         wire behavior without a codegen step
+ *
+ * Change Log:
+ * 2025-08-09: Initial ReactorTransportClient/ReactorTransportServer and service descriptor for
+ *             GetStatus, GetEnergyField, ListEnergyFields, SubscribeEvents
+ *
+ * Carry-over Context:
+ * - Every call here passes grpc.CallContentSubtype(CodecName) so it round-trips through
+ *   wireCodec (codec.go) instead of grpc-go's default "proto" codec
+ */
+
+package pb
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	reactorTransportServiceName = "reactor.ReactorTransport"
+
+	reactorTransportGetStatusMethod        = "/" + reactorTransportServiceName + "/GetStatus"
+	reactorTransportGetEnergyFieldMethod   = "/" + reactorTransportServiceName + "/GetEnergyField"
+	reactorTransportListEnergyFieldsMethod = "/" + reactorTransportServiceName + "/ListEnergyFields"
+	reactorTransportSubscribeEventsMethod  = "/" + reactorTransportServiceName + "/SubscribeEvents"
+)
+
+// ReactorTransportClient is the client API for the ReactorTransport service.
+type ReactorTransportClient interface {
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*SystemStatus, error)
+	GetEnergyField(ctx context.Context, in *GetEnergyFieldRequest, opts ...grpc.CallOption) (*EnergyField, error)
+	ListEnergyFields(ctx context.Context, in *ListEnergyFieldsRequest, opts ...grpc.CallOption) (*ListEnergyFieldsResponse, error)
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (ReactorTransport_SubscribeEventsClient, error)
+}
+
+type reactorTransportClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewReactorTransportClient wraps conn for the ReactorTransport service; every call rides the
+// "ternarywire" codec (see codec.go), so conn need not know about grpc-go's "proto" codec at all.
+func NewReactorTransportClient(conn grpc.ClientConnInterface) ReactorTransportClient {
+	return &reactorTransportClient{cc: conn}
+}
+
+func (c *reactorTransportClient) callOpts(opts []grpc.CallOption) []grpc.CallOption {
+	return append([]grpc.CallOption{grpc.CallContentSubtype(CodecName)}, opts...)
+}
+
+func (c *reactorTransportClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*SystemStatus, error) {
+	out := new(SystemStatus)
+	if err := c.cc.Invoke(ctx, reactorTransportGetStatusMethod, in, out, c.callOpts(opts)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactorTransportClient) GetEnergyField(ctx context.Context, in *GetEnergyFieldRequest, opts ...grpc.CallOption) (*EnergyField, error) {
+	out := new(EnergyField)
+	if err := c.cc.Invoke(ctx, reactorTransportGetEnergyFieldMethod, in, out, c.callOpts(opts)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactorTransportClient) ListEnergyFields(ctx context.Context, in *ListEnergyFieldsRequest, opts ...grpc.CallOption) (*ListEnergyFieldsResponse, error) {
+	out := new(ListEnergyFieldsResponse)
+	if err := c.cc.Invoke(ctx, reactorTransportListEnergyFieldsMethod, in, out, c.callOpts(opts)...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactorTransportClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (ReactorTransport_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &reactorTransportServiceDesc.Streams[0], reactorTransportSubscribeEventsMethod, c.callOpts(opts)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &reactorTransportSubscribeEventsClient{stream}, nil
+}
+
+// ReactorTransport_SubscribeEventsClient is returned by a client's SubscribeEvents call; Recv
+// blocks for the next FissionEvent until the stream ends (io.EOF) or the server errors it.
+type ReactorTransport_SubscribeEventsClient interface {
+	Recv() (*FissionEvent, error)
+	grpc.ClientStream
+}
+
+type reactorTransportSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *reactorTransportSubscribeEventsClient) Recv() (*FissionEvent, error) {
+	m := new(FissionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ReactorTransportServer is the server API for the ReactorTransport service.
+type ReactorTransportServer interface {
+	GetStatus(context.Context, *GetStatusRequest) (*SystemStatus, error)
+	GetEnergyField(context.Context, *GetEnergyFieldRequest) (*EnergyField, error)
+	ListEnergyFields(context.Context, *ListEnergyFieldsRequest) (*ListEnergyFieldsResponse, error)
+	SubscribeEvents(*SubscribeEventsRequest, ReactorTransport_SubscribeEventsServer) error
+}
+
+// ReactorTransport_SubscribeEventsServer is handed to a ReactorTransportServer implementation's
+// SubscribeEvents method to push FissionEvents to the subscribed client.
+type ReactorTransport_SubscribeEventsServer interface {
+	Send(*FissionEvent) error
+	grpc.ServerStream
+}
+
+type reactorTransportSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *reactorTransportSubscribeEventsServer) Send(m *FissionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// UnimplementedReactorTransportServer can be embedded in a ReactorTransportServer
+// implementation to satisfy the interface for methods it doesn't define, the same
+// forward-compatibility embedding protoc-gen-go-grpc generates.
+type UnimplementedReactorTransportServer struct{}
+
+func (UnimplementedReactorTransportServer) GetStatus(context.Context, *GetStatusRequest) (*SystemStatus, error) {
+	return nil, fmt.Errorf("method GetStatus not implemented")
+}
+func (UnimplementedReactorTransportServer) GetEnergyField(context.Context, *GetEnergyFieldRequest) (*EnergyField, error) {
+	return nil, fmt.Errorf("method GetEnergyField not implemented")
+}
+func (UnimplementedReactorTransportServer) ListEnergyFields(context.Context, *ListEnergyFieldsRequest) (*ListEnergyFieldsResponse, error) {
+	return nil, fmt.Errorf("method ListEnergyFields not implemented")
+}
+func (UnimplementedReactorTransportServer) SubscribeEvents(*SubscribeEventsRequest, ReactorTransport_SubscribeEventsServer) error {
+	return fmt.Errorf("method SubscribeEvents not implemented")
+}
+
+// RegisterReactorTransportServer registers srv with s, the same as a generated
+// _ReactorTransport_serviceDesc registration would.
+func RegisterReactorTransportServer(s grpc.ServiceRegistrar, srv ReactorTransportServer) {
+	s.RegisterService(&reactorTransportServiceDesc, srv)
+}
+
+func reactorTransportGetStatusHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactorTransportServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: reactorTransportGetStatusMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactorTransportServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func reactorTransportGetEnergyFieldHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEnergyFieldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactorTransportServer).GetEnergyField(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: reactorTransportGetEnergyFieldMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactorTransportServer).GetEnergyField(ctx, req.(*GetEnergyFieldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func reactorTransportListEnergyFieldsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEnergyFieldsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactorTransportServer).ListEnergyFields(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: reactorTransportListEnergyFieldsMethod}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactorTransportServer).ListEnergyFields(ctx, req.(*ListEnergyFieldsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func reactorTransportSubscribeEventsHandler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(ReactorTransportServer).SubscribeEvents(in, &reactorTransportSubscribeEventsServer{stream})
+}
+
+var reactorTransportServiceDesc = grpc.ServiceDesc{
+	ServiceName: reactorTransportServiceName,
+	HandlerType: (*ReactorTransportServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetStatus", Handler: reactorTransportGetStatusHandler},
+		{MethodName: "GetEnergyField", Handler: reactorTransportGetEnergyFieldHandler},
+		{MethodName: "ListEnergyFields", Handler: reactorTransportListEnergyFieldsHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       reactorTransportSubscribeEventsHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "reactor.proto",
+}